@@ -0,0 +1,187 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tochemey/goakt/v4/actor"
+	"github.com/tochemey/goakt/v4/extension"
+)
+
+const (
+	DrawingStoreExtensionID = "pictograph_drawings"
+
+	// drawingStoreTimeout bounds a single save or load, so a slow
+	// database cannot hold a share link or a room's save goroutine.
+	drawingStoreTimeout = 2 * time.Second
+
+	// maxArchivedDrawings bounds the in-memory store; the oldest round
+	// is evicted once it is full. A round is a few KB typically; strokes
+	// are stored quantized and simplified (see compactPoints).
+	maxArchivedDrawings = 512
+)
+
+// drawingStore keeps the stroke timeline of finished rounds, keyed by
+// room code, game number and round, so they can be rendered over HTTP.
+// It ships as memDrawingStore and pgDrawingStore, selected in main.go
+// by DATABASE_URL. Only Postgres is shared between nodes, so only with
+// it does a share link resolve on whichever node serves it.
+type drawingStore interface {
+	extension.Extension
+	Save(ctx context.Context, code string, drawing *RoundDrawing) error
+	Get(ctx context.Context, code string, game, round int) (drawing *RoundDrawing, found bool, err error)
+}
+
+// memDrawingStore keeps the last maxArchivedDrawings rounds in a
+// process-local map: enough for a single node, but a link opened
+// through any other node returns 404.
+type memDrawingStore struct {
+	mu       sync.Mutex
+	drawings map[string]*RoundDrawing
+	order    []string
+}
+
+var _ drawingStore = (*memDrawingStore)(nil)
+
+func newMemDrawingStore() *memDrawingStore {
+	return &memDrawingStore{drawings: make(map[string]*RoundDrawing)}
+}
+
+func (s *memDrawingStore) ID() string { return DrawingStoreExtensionID }
+
+// Save stores drawing under room code. Room codes are reused once a
+// room shuts down, so a later save for the same key replaces the
+// earlier one.
+func (s *memDrawingStore) Save(_ context.Context, code string, drawing *RoundDrawing) error {
+	key := drawingKey(code, drawing.Game, drawing.Round)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.drawings[key]; ok {
+		s.drawings[key] = drawing
+		return nil
+	}
+
+	if len(s.order) >= maxArchivedDrawings {
+		delete(s.drawings, s.order[0])
+		s.order = s.order[1:]
+	}
+
+	s.drawings[key] = drawing
+	s.order = append(s.order, key)
+
+	return nil
+}
+
+func (s *memDrawingStore) Get(_ context.Context, code string, game, round int) (*RoundDrawing, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	drawing, ok := s.drawings[drawingKey(code, game, round)]
+
+	return drawing, ok, nil
+}
+
+func drawingStoreFromExtension(system actor.ActorSystem) drawingStore {
+	for _, ext := range system.Extensions() {
+		if ext.ID() == DrawingStoreExtensionID {
+			if store, ok := ext.(drawingStore); ok {
+				return store
+			}
+		}
+	}
+
+	return nil
+}
+
+// drawingHandler serves GET /drawings/{code}/{game}/{round}.svg as the
+// finished picture and .../{round}.gif as an animated replay of how it
+// was drawn. A round without an extension is served as SVG.
+func drawingHandler(store drawingStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		code := strings.ToUpper(strings.TrimSpace(r.PathValue("code")))
+		game, err := strconv.Atoi(r.PathValue("game"))
+		if err != nil || game < 1 {
+			http.Error(w, "bad game number", http.StatusBadRequest)
+			return
+		}
+
+		file := r.PathValue("round")
+		ext := path.Ext(file)
+		round, err := strconv.Atoi(strings.TrimSuffix(file, ext))
+		if err != nil || round < 1 {
+			http.Error(w, "bad round number", http.StatusBadRequest)
+			return
+		}
+
+		getCtx, cancel := context.WithTimeout(r.Context(), drawingStoreTimeout)
+		drawing, ok, err := store.Get(getCtx, code, game, round)
+		cancel()
+		if err != nil {
+			http.Error(w, "drawing store unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		var (
+			buf         bytes.Buffer
+			contentType string
+		)
+		switch ext {
+		case "", ".svg":
+			ext, contentType = ".svg", "image/svg+xml"
+			err = renderSVG(&buf, drawing)
+		case ".gif":
+			contentType = "image/gif"
+			err = renderGIF(&buf, drawing)
+		default:
+			http.Error(w, "unsupported format "+ext, http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", fmt.Sprintf("%s-%d-%d%s", code, game, round, ext)))
+		_, _ = w.Write(buf.Bytes())
+	}
+}
+
+func drawingKey(code string, game, round int) string {
+	return code + "#" + strconv.Itoa(game) + "#" + strconv.Itoa(round)
+}
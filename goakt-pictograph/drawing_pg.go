@@ -0,0 +1,121 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// pgDrawingStore keeps finished rounds in Postgres, so a share link
+// resolves on every node, whichever one hosted the room.
+type pgDrawingStore struct {
+	pool *pgxpool.Pool
+}
+
+var _ drawingStore = (*pgDrawingStore)(nil)
+
+const roundDrawingSchema = `
+CREATE TABLE IF NOT EXISTS round_drawings (
+    code     TEXT        NOT NULL,
+    game     INTEGER     NOT NULL,
+    round    INTEGER     NOT NULL,
+    drawing  JSONB       NOT NULL,
+    saved_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (code, game, round)
+);`
+
+// newPgDrawingStore connects to Postgres, runs the idempotent schema
+// migration, and returns a store ready for use. The caller owns
+// lifecycle: call Close on shutdown.
+func newPgDrawingStore(ctx context.Context, dsn string) (*pgDrawingStore, error) {
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parse DATABASE_URL: %w", err)
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+
+	if _, err := pool.Exec(ctx, roundDrawingSchema); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("migrate: %w", err)
+	}
+
+	return &pgDrawingStore{pool: pool}, nil
+}
+
+func (s *pgDrawingStore) ID() string { return DrawingStoreExtensionID }
+
+func (s *pgDrawingStore) Close() {
+	if s.pool != nil {
+		s.pool.Close()
+	}
+}
+
+// Save upserts the drawing. Room codes are reused once a room shuts
+// down, so a later game under the same code replaces the earlier one.
+func (s *pgDrawingStore) Save(ctx context.Context, code string, drawing *RoundDrawing) error {
+	const q = `
+INSERT INTO round_drawings (code, game, round, drawing, saved_at)
+VALUES ($1, $2, $3, $4, now())
+ON CONFLICT (code, game, round) DO UPDATE SET
+    drawing  = EXCLUDED.drawing,
+    saved_at = now();`
+
+	data, err := json.Marshal(drawing)
+	if err != nil {
+		return fmt.Errorf("encode drawing: %w", err)
+	}
+
+	_, err = s.pool.Exec(ctx, q, code, drawing.Game, drawing.Round, data)
+
+	return err
+}
+
+func (s *pgDrawingStore) Get(ctx context.Context, code string, game, round int) (*RoundDrawing, bool, error) {
+	const q = `SELECT drawing FROM round_drawings WHERE code = $1 AND game = $2 AND round = $3`
+
+	var data []byte
+	err := s.pool.QueryRow(ctx, q, code, game, round).Scan(&data)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return nil, false, nil
+	case err != nil:
+		return nil, false, err
+	}
+
+	var drawing RoundDrawing
+	if err := json.Unmarshal(data, &drawing); err != nil {
+		return nil, false, fmt.Errorf("decode drawing: %w", err)
+	}
+
+	return &drawing, true, nil
+}
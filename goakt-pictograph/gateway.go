@@ -27,7 +27,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -104,16 +107,19 @@ func requestRoom(ctx context.Context, system actor.ActorSystem, msg *JoinOrCreat
 // teardown path as a normal client-initiated close. wg lets main wait
 // for every handler's teardown to complete before stopping the actor
 // system (http.Server.Shutdown does NOT wait for hijacked connections).
-func wsHandler(system actor.ActorSystem, leaderboard *Leaderboard, drainCtx context.Context, wg *sync.WaitGroup, logger log.Logger) http.HandlerFunc {
+func wsHandler(system actor.ActorSystem, leaderboard *Leaderboard, packs *WordPackStore, drainCtx context.Context, wg *sync.WaitGroup, logger log.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		wg.Add(1)
 		defer wg.Done()
 
 		// Accept-options: InsecureSkipVerify mirrors goakt-tetris's stance
 		// for an unauthenticated demo. A real deployment would enforce
-		// Origin.
+		// Origin. Subprotocols is in preference order: a browser that
+		// offers StrokeSubprotocol gets binary strokes; one that offers
+		// none is served JSON, as before.
 		conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
 			InsecureSkipVerify: true,
+			Subprotocols:       []string{StrokeSubprotocol, JSONSubprotocol},
 		})
 		if err != nil {
 			logger.Errorf("ws accept: %v", err)
@@ -134,8 +140,12 @@ func wsHandler(system actor.ActorSystem, leaderboard *Leaderboard, drainCtx cont
 		}
 		room := strings.ToUpper(strings.TrimSpace(q.Get("room")))
 
+		// ?quick=1 without a room code asks the lobby for any open
+		// public room; ?public=1 lists a newly created room.
 		roomPID, code, err := requestRoom(r.Context(), system, &JoinOrCreate{
 			Room: room, PlayerID: playerID, PlayerName: name,
+			Words: wordSettings(q, packs), Game: gameSettings(q),
+			Public: q.Get("public") == "1", QuickPlay: q.Get("quick") == "1",
 		})
 		if err != nil {
 			_ = conn.Close(websocket.StatusInternalError, "lobby unavailable")
@@ -152,6 +162,10 @@ func wsHandler(system actor.ActorSystem, leaderboard *Leaderboard, drainCtx cont
 			name:      name,
 			roomCode:  code,
 			topicName: RoomTopicPrefix + code,
+
+			remoteAddr: clientAddr(r),
+
+			binaryStrokes: conn.Subprotocol() == StrokeSubprotocol,
 		}
 
 		sessionPID, err := system.Spawn(r.Context(), sessionName, session, actor.WithLongLived())
@@ -212,6 +226,103 @@ func wsHandler(system actor.ActorSystem, leaderboard *Leaderboard, drainCtx cont
 	}
 }
 
+// wordSettings reads the create-room word choice off the /ws query:
+// ?lang=, ?difficulty= and a comma-separated ?packs=. An uploaded list
+// in packs is resolved here, on the node that received the upload.
+func wordSettings(q url.Values, packs *WordPackStore) WordSettings {
+	settings := WordSettings{
+		Language:   strings.ToLower(strings.TrimSpace(q.Get("lang"))),
+		Difficulty: strings.ToLower(strings.TrimSpace(q.Get("difficulty"))),
+	}
+
+	for _, id := range strings.Split(q.Get("packs"), ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+
+		if custom, ok := packs.Custom(id); ok {
+			settings.CustomWords = append(settings.CustomWords, custom.Words...)
+			if settings.Language == "" {
+				settings.Language = custom.Language
+			}
+			continue
+		}
+		settings.Packs = append(settings.Packs, id)
+	}
+
+	return settings
+}
+
+// gameSettings reads the create-room rules off the /ws query: ?rounds=,
+// ?draw= (seconds), ?choices=, ?hints= and ?teams=1. A missing or
+// unparsable value keeps its default; the room clamps the rest.
+func gameSettings(q url.Values) GameSettings {
+	settings := DefaultGameSettings()
+	for key, field := range map[string]*int{
+		"rounds":  &settings.Rounds,
+		"draw":    &settings.DrawSeconds,
+		"choices": &settings.WordChoices,
+		"hints":   &settings.Hints,
+	} {
+		if n, err := strconv.Atoi(q.Get(key)); err == nil {
+			*field = n
+		}
+	}
+	settings.Teams = q.Get("teams") == "1"
+	return settings
+}
+
+// roomsHandler serves GET /rooms: the lobby's public rooms as JSON,
+// optionally narrowed with ?lang=.
+func roomsHandler(system actor.ActorSystem) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lobby, err := system.ActorOf(r.Context(), LobbyActorName)
+		if err != nil {
+			http.Error(w, "lobby unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		language := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("lang")))
+		reply, err := actor.Ask(r.Context(), lobby, &ListRooms{Language: language}, lobbyAskTimeout)
+		if err != nil {
+			http.Error(w, "lobby unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		list, ok := reply.(*RoomList)
+		if !ok {
+			http.Error(w, "unexpected lobby reply", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"rooms": list.Rooms})
+	}
+}
+
 func shortID() string {
 	return uuid.NewString()[:6]
 }
+
+// clientAddr returns the IP of the browser behind r. With
+// --client-ip-header set, the last entry of that header is used: it is
+// the one the trusted proxy in front of the node appended, whatever the
+// client put before it. Otherwise it is the TCP peer.
+func clientAddr(r *http.Request) string {
+	if *clientIPHeader != "" {
+		if values := r.Header.Values(*clientIPHeader); len(values) > 0 {
+			entries := strings.Split(values[len(values)-1], ",")
+			if addr := strings.TrimSpace(entries[len(entries)-1]); addr != "" {
+				return addr
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
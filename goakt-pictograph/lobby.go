@@ -23,10 +23,13 @@
 package main
 
 import (
+	"cmp"
 	"errors"
 	"math/rand/v2"
+	"slices"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/tochemey/goakt/v4/actor"
 	gerrors "github.com/tochemey/goakt/v4/errors"
 )
@@ -37,18 +40,32 @@ import (
 // code and spawns the RoomActor on whichever cluster node has the
 // lightest load.
 //
-// On singleton failover the rebuilt LobbyActor starts with an empty
-// directory — it does NOT enumerate the cluster's existing rooms. The
-// directory recovers lazily: a player presenting an existing room
-// code (via URL ?room=) triggers a SpawnOn that returns
-// ErrActorAlreadyExists, which handleJoinOrCreate treats as success
-// and re-registers the code → name mapping. Codes that nobody is
-// holding (no URL share, no active player) are effectively lost.
+// Alongside the directory it keeps an index of every room's last
+// RoomStatus, which backs the room browser and quick play. Rooms push
+// those reports themselves; the lobby never polls.
+//
+// On singleton failover the rebuilt LobbyActor starts empty and
+// publishes RefreshRoomStatus on LobbyTopic; every running room
+// answers with its status, which refills both the index and the
+// directory. A room whose report is still in flight is also covered
+// the old way: a player presenting its code (via URL ?room=) triggers
+// a SpawnOn that returns ErrActorAlreadyExists, which
+// handleJoinOrCreate treats as success.
 type LobbyActor struct {
 	// rooms is a local map of code → cluster-stable RoomActor name.
 	// The PIDs themselves aren't cached — `ActorOf` resolves them
 	// fresh per request so cross-node placement is transparent.
 	rooms map[string]string
+
+	// index is code → the room's last report. The PID is kept only to
+	// match the Terminated of a room whose node died without a
+	// PostStop.
+	index map[string]*indexedRoom
+}
+
+type indexedRoom struct {
+	status RoomStatus
+	pid    *actor.PID
 }
 
 var _ actor.Actor = (*LobbyActor)(nil)
@@ -62,11 +79,28 @@ func (l *LobbyActor) Receive(ctx *actor.ReceiveContext) {
 		// Initialize state here — SpawnSingleton uses `new(LobbyActor)`
 		// so a constructor that allocated maps would be bypassed.
 		l.rooms = make(map[string]string)
+		l.index = make(map[string]*indexedRoom)
+		if topic := ctx.ActorSystem().TopicActor(); topic != nil {
+			ctx.Tell(topic, actor.NewPublish(uuid.NewString(), LobbyTopic, &RefreshRoomStatus{}))
+		}
 		ctx.Logger().Infof("lobby singleton ready on %s", ctx.Self().Path().HostPort())
 
 	case *JoinOrCreate:
 		l.handleJoinOrCreate(ctx, msg)
 
+	case *RoomStatus:
+		l.indexRoom(ctx, msg)
+
+	case *actor.Terminated:
+		for code, room := range l.index {
+			if room.pid != nil && room.pid.Path().Equals(msg.ActorPath()) {
+				l.forget(code)
+			}
+		}
+
+	case *ListRooms:
+		ctx.Response(&RoomList{Rooms: l.publicRooms(msg.Language)})
+
 	default:
 		ctx.Unhandled()
 	}
@@ -74,6 +108,12 @@ func (l *LobbyActor) Receive(ctx *actor.ReceiveContext) {
 
 func (l *LobbyActor) handleJoinOrCreate(ctx *actor.ReceiveContext, msg *JoinOrCreate) {
 	code := strings.ToUpper(strings.TrimSpace(msg.Room))
+	if code == "" && msg.QuickPlay {
+		code = l.quickPlay(msg.Words.Language)
+		// Should that find nothing to join, the room created below is
+		// public, so the next quick play can find it.
+		msg.Public = true
+	}
 
 	// Existing room? Just hand back the actor name; gateway resolves
 	// the PID via ActorOf so cross-node placement stays transparent.
@@ -128,11 +168,20 @@ func (l *LobbyActor) handleJoinOrCreate(ctx *actor.ReceiveContext, msg *JoinOrCr
 
 	l.rooms[code] = name
 
+	// The room starts on default words and rules; hand it the creator's
+	// choice. This Tell is queued before the gateway even learns the
+	// room's name, so it lands well inside the room's gather window.
+	// Only the creating request configures a room: joiners' settings,
+	// and those of a request that found the room already running, are
+	// ignored.
+	ctx.Tell(pid, &ConfigureRoom{Words: msg.Words, Game: msg.Game, Public: msg.Public})
+
 	placement := "local"
 	if pid != nil && pid.IsRemote() {
 		placement = "remote@" + pid.Path().HostPort()
 	}
-	ctx.Logger().Infof("lobby: spawned %s (%s) for player %s", name, placement, msg.PlayerName)
+	ctx.Logger().Infof("lobby: spawned %s (%s) for player %s: %d rounds, teams=%t, public=%t",
+		name, placement, msg.PlayerName, msg.Game.Rounds, msg.Game.Teams, msg.Public)
 	ctx.Response(&JoinOrCreateResult{RoomCode: code, RoomName: name})
 }
 
@@ -161,3 +210,71 @@ func (l *LobbyActor) generateCode() string {
 	}
 	return string(code)
 }
+
+// indexRoom records a room's report. The first report from a room is
+// also when the lobby starts watching it, and puts it back in the
+// directory if this lobby has never seen it (a failover rebuild).
+func (l *LobbyActor) indexRoom(ctx *actor.ReceiveContext, status *RoomStatus) {
+	if status.Stopped {
+		l.forget(status.Code)
+		return
+	}
+
+	room, ok := l.index[status.Code]
+	if !ok {
+		room = &indexedRoom{pid: ctx.Sender()}
+		l.index[status.Code] = room
+		if room.pid != nil {
+			ctx.Watch(room.pid)
+		}
+	}
+	room.status = *status
+	l.rooms[status.Code] = status.Name
+}
+
+func (l *LobbyActor) forget(code string) {
+	delete(l.index, code)
+	delete(l.rooms, code)
+}
+
+// publicRooms lists the browsable rooms, optionally in one language:
+// joinable ones first, fullest first, then by code so the order is
+// stable between polls.
+func (l *LobbyActor) publicRooms(language string) []RoomStatus {
+	out := make([]RoomStatus, 0, len(l.index))
+	for _, room := range l.index {
+		if room.status.Public && (language == "" || room.status.Language == language) {
+			out = append(out, room.status)
+		}
+	}
+
+	slices.SortFunc(out, func(a, b RoomStatus) int {
+		if a.Joinable != b.Joinable {
+			if a.Joinable {
+				return -1
+			}
+			return 1
+		}
+		if a.Players != b.Players {
+			return cmp.Compare(b.Players, a.Players)
+		}
+		return cmp.Compare(a.Code, b.Code)
+	})
+	return out
+}
+
+// quickPlay picks the fullest joinable public room, or "" if there is
+// none. The pick is counted against the room right away, so a burst of
+// quick plays cannot overfill it before the room's next report.
+func (l *LobbyActor) quickPlay(language string) string {
+	for _, status := range l.publicRooms(language) {
+		if !status.Joinable {
+			break
+		}
+		room := l.index[status.Code]
+		room.status.Players++
+		room.status.Joinable = room.status.Players < room.status.MaxPlayers
+		return status.Code
+	}
+	return ""
+}
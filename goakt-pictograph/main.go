@@ -59,17 +59,29 @@ const (
 //go:embed web/index.html web/main.js
 var webFS embed.FS
 
+// packsFS holds the bundled word packs; --word-packs adds more.
+//
+//go:embed packs/*.json
+var packsFS embed.FS
+
 // Same CLI shape as goakt-tetris so the docker-compose / Makefile
 // recipes from that example translate one-to-one.
 var (
-	httpPort      = flag.Int("http-port", 8080, "HTTP/WebSocket port for browser clients")
-	bindHost      = flag.String("bind-host", defaultBindHost, "Host this node advertises for cluster traffic")
-	remotingPort  = flag.Int("remoting-port", 9000, "gRPC port for inter-node actor messaging")
-	discoveryPort = flag.Int("discovery-port", 9001, "Gossip port used by the static discovery provider")
-	peersPort     = flag.Int("peers-port", 9002, "Cluster peer state-sync port")
-	peers         = flag.String("peers", "", "Comma-separated host:discoveryPort list of cluster bootstrap peers; defaults to this node only")
+	httpPort       = flag.Int("http-port", 8080, "HTTP/WebSocket port for browser clients")
+	bindHost       = flag.String("bind-host", defaultBindHost, "Host this node advertises for cluster traffic")
+	remotingPort   = flag.Int("remoting-port", 9000, "gRPC port for inter-node actor messaging")
+	discoveryPort  = flag.Int("discovery-port", 9001, "Gossip port used by the static discovery provider")
+	peersPort      = flag.Int("peers-port", 9002, "Cluster peer state-sync port")
+	peers          = flag.String("peers", "", "Comma-separated host:discoveryPort list of cluster bootstrap peers; defaults to this node only")
+	wordPacksDir   = flag.String("word-packs", "", "Directory of extra word packs (*.json) loaded on top of the bundled ones; must match on every node")
+	chatFilter     = flag.String("chat-filter", "", "File of words to mask in chat, one per line; must match on every node")
+	clientIPHeader = flag.String("client-ip-header", "", "Header a trusted reverse proxy sets to the client IP (e.g. X-Forwarded-For); vote-kick bans key on it instead of the TCP peer")
+	databaseURL    = flag.String("database-url", "", "Postgres DSN for the drawing store (defaults to $DATABASE_URL; in-memory fallback if unset)")
 )
 
+// drawingStoreInitTimeout bounds the Postgres connect + migration at boot.
+const drawingStoreInitTimeout = 10 * time.Second
+
 func main() {
 	flag.Parse()
 	ctx := context.Background()
@@ -78,12 +90,30 @@ func main() {
 	store := newProfileStore()
 	leaderboard := NewLeaderboard()
 
-	// Both the profile store and the leaderboard are registered as
-	// system Extensions: cluster-spawned actors (RoomActor, LobbyActor)
+	drawings, closeDrawings, err := buildDrawingStore(ctx, logger)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	defer closeDrawings()
+
+	packs, err := loadWordPacks()
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	filter := NewChatFilter(nil)
+	if *chatFilter != "" {
+		if filter, err = LoadChatFilter(*chatFilter); err != nil {
+			logger.Fatal(err)
+		}
+	}
+
+	// The profile store, the leaderboard, the drawing store, the word
+	// packs and the chat filter are registered as system Extensions: cluster-spawned actors (RoomActor, LobbyActor)
 	// are re-instantiated via the kind registry on whichever node hosts
 	// them — that path bypasses constructor-injected deps, so anything
 	// load-bearing must be reachable from the system itself.
-	system, err := buildActorSystem(logger, store, leaderboard)
+	system, err := buildActorSystem(logger, store, leaderboard, drawings, packs, filter)
 	if err != nil {
 		logger.Fatal(err)
 	}
@@ -135,7 +165,11 @@ func main() {
 	var wsHandlers sync.WaitGroup
 
 	mux := http.NewServeMux()
-	mux.HandleFunc("/ws", wsHandler(system, leaderboard, drainCtx, &wsHandlers, logger))
+	mux.HandleFunc("/ws", wsHandler(system, leaderboard, packs, drainCtx, &wsHandlers, logger))
+	mux.HandleFunc("GET /rooms", roomsHandler(system))
+	mux.HandleFunc("GET /packs", packsHandler(packs))
+	mux.HandleFunc("POST /packs", packsHandler(packs))
+	mux.HandleFunc("GET /drawings/{code}/{game}/{round}", drawingHandler(drawings))
 	mux.Handle("/", http.FileServer(http.FS(web)))
 
 	addr := fmt.Sprintf(":%d", *httpPort)
@@ -193,9 +227,10 @@ func main() {
 
 // buildActorSystem assembles the cluster-aware ActorSystem: remoting
 // (with CBOR serializers for every cross-node message type), pub/sub,
-// the profile-store and leaderboard extensions, and a cluster config
-// that registers our actor kinds and turns on CRDT replication.
-func buildActorSystem(logger log.Logger, store *profileStore, leaderboard *Leaderboard) (actor.ActorSystem, error) {
+// the profile-store, leaderboard, drawing-store, word-pack and
+// chat-filter extensions, and a cluster config that registers our actor
+// kinds and turns on CRDT replication.
+func buildActorSystem(logger log.Logger, store *profileStore, leaderboard *Leaderboard, drawings drawingStore, packs *WordPackStore, filter *ChatFilter) (actor.ActorSystem, error) {
 	cbor := remote.NewCBORSerializer()
 	// Every type a remote actor might receive needs a registered
 	// serializer. Lobby↔Gateway (JoinOrCreate/JoinOrCreateResult),
@@ -205,6 +240,11 @@ func buildActorSystem(logger log.Logger, store *profileStore, leaderboard *Leade
 	remoteCfg := remoting.NewConfig(*bindHost, *remotingPort,
 		remote.WithSerializers((*JoinOrCreate)(nil), cbor),
 		remote.WithSerializers((*JoinOrCreateResult)(nil), cbor),
+		remote.WithSerializers((*ConfigureRoom)(nil), cbor),
+		remote.WithSerializers((*RoomStatus)(nil), cbor),
+		remote.WithSerializers((*RefreshRoomStatus)(nil), cbor),
+		remote.WithSerializers((*ListRooms)(nil), cbor),
+		remote.WithSerializers((*RoomList)(nil), cbor),
 		remote.WithSerializers((*PlayerHello)(nil), cbor),
 		remote.WithSerializers((*GoodbyePlayer)(nil), cbor),
 		remote.WithSerializers((*PlayerInput)(nil), cbor),
@@ -219,6 +259,8 @@ func buildActorSystem(logger log.Logger, store *profileStore, leaderboard *Leade
 		remote.WithSerializers((*SecretWordEvent)(nil), cbor),
 		remote.WithSerializers((*RoundOverEvent)(nil), cbor),
 		remote.WithSerializers((*GameOverEvent)(nil), cbor),
+		remote.WithSerializers((*CloseGuessEvent)(nil), cbor),
+		remote.WithSerializers((*KickedEvent)(nil), cbor),
 		remote.WithSerializers((*ErrorEvent)(nil), cbor),
 		// Grain wire payloads.
 		remote.WithSerializers((*GetProfile)(nil), cbor),
@@ -251,10 +293,62 @@ func buildActorSystem(logger log.Logger, store *profileStore, leaderboard *Leade
 		actor.WithRemote(remoteCfg),
 		actor.WithCluster(clusterCfg),
 		actor.WithPubSub(),
-		actor.WithExtensions(store, leaderboard),
+		actor.WithExtensions(store, leaderboard, drawings, packs, filter),
 	)
 }
 
+// buildDrawingStore picks the drawing-store backend. If --database-url
+// (or $DATABASE_URL) is set, it connects to Postgres, runs the schema
+// migration, and returns a pgDrawingStore + its Close function, so a
+// share link resolves on every node. Otherwise it returns the in-memory
+// store. A non-empty DSN that fails to connect is a hard error; we
+// don't silently degrade to in-memory.
+func buildDrawingStore(ctx context.Context, logger log.Logger) (drawingStore, func(), error) {
+	dsn := strings.TrimSpace(*databaseURL)
+	if dsn == "" {
+		dsn = os.Getenv("DATABASE_URL")
+	}
+	if dsn == "" {
+		logger.Info("drawing store: using in-memory backend (set DATABASE_URL for Postgres)")
+		return newMemDrawingStore(), func() {}, nil
+	}
+
+	initCtx, cancel := context.WithTimeout(ctx, drawingStoreInitTimeout)
+	defer cancel()
+
+	pg, err := newPgDrawingStore(initCtx, dsn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("postgres drawing store: %w", err)
+	}
+
+	logger.Info("drawing store: using Postgres backend")
+
+	return pg, pg.Close, nil
+}
+
+// loadWordPacks reads the bundled packs, then --word-packs on top. The
+// room resolves its creator's packs on whichever node hosts it, so the
+// directory has to be the same on every node.
+func loadWordPacks() (*WordPackStore, error) {
+	store := NewWordPackStore()
+
+	bundled, err := fs.Sub(packsFS, "packs")
+	if err != nil {
+		return nil, err
+	}
+	if err := store.Load(bundled); err != nil {
+		return nil, err
+	}
+
+	if *wordPacksDir != "" {
+		if err := store.Load(os.DirFS(*wordPacksDir)); err != nil {
+			return nil, fmt.Errorf("--word-packs: %w", err)
+		}
+	}
+
+	return store, nil
+}
+
 // peerList parses --peers ("host:port,host:port,…"), or — if empty —
 // returns just this node's own discovery endpoint, which is enough for
 // single-node bootstrap into a cluster of size 1.
@@ -0,0 +1,314 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/tochemey/goakt/v4/actor"
+	"github.com/tochemey/goakt/v4/extension"
+)
+
+const (
+	ChatFilterExtensionID = "pictograph_chat_filter"
+
+	// minKickVoters is the fewest eligible voters a kick vote needs.
+	// Below it "majority" means one player — in a two-player room,
+	// either could throw the other out alone.
+	minKickVoters = 2
+)
+
+// ChatFilter masks blocked words in player chat. The list comes from
+// --chat-filter, one word per line; with no list it lets everything
+// through. Words are compared folded (case and accents, as guesses
+// are), whole word against whole word, so a blocked word inside a
+// longer harmless one is left alone.
+//
+// Like the word packs it is process-local and must be the same on
+// every node: the room filters on whichever node hosts it.
+type ChatFilter struct {
+	blocked map[string]bool
+}
+
+var _ extension.Extension = (*ChatFilter)(nil)
+
+func NewChatFilter(words []string) *ChatFilter {
+	blocked := make(map[string]bool, len(words))
+	for _, word := range words {
+		if folded := normalizeGuess("", word); folded != "" {
+			blocked[folded] = true
+		}
+	}
+	return &ChatFilter{blocked: blocked}
+}
+
+// LoadChatFilter reads a filter list file; blank lines and lines
+// starting with # are skipped.
+func LoadChatFilter(path string) (*ChatFilter, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			words = append(words, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewChatFilter(words), nil
+}
+
+func (f *ChatFilter) ID() string { return ChatFilterExtensionID }
+
+// Clean returns text with every blocked word replaced by asterisks.
+func (f *ChatFilter) Clean(text string) string {
+	if f == nil || len(f.blocked) == 0 {
+		return text
+	}
+
+	words := strings.Fields(text)
+	changed := false
+	for i, word := range words {
+		core := strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+		if core == "" || !f.blocked[normalizeGuess("", core)] {
+			continue
+		}
+		words[i] = strings.Replace(word, core, strings.Repeat("*", len([]rune(core))), 1)
+		changed = true
+	}
+
+	if !changed {
+		return text
+	}
+	return strings.Join(words, " ")
+}
+
+func chatFilterFromExtension(system actor.ActorSystem) *ChatFilter {
+	for _, ext := range system.Extensions() {
+		if ext.ID() == ChatFilterExtensionID {
+			if filter, ok := ext.(*ChatFilter); ok {
+				return filter
+			}
+		}
+	}
+
+	return nil
+}
+
+// chat publishes a player's chat line through the room's chat filter.
+func (r *RoomActor) chat(ctx *actor.ReceiveContext, playerID, text string) {
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	r.publish(ctx, &ChatEvent{From: r.nameFor(playerID), Text: r.filter.Clean(text)})
+}
+
+// chatKnowingWord is chat for the drawer and players who have guessed
+// during the drawing phase: they may talk, but a line that gives the
+// word away (see leaksWord) is bounced back to the sender instead of
+// reaching those still guessing.
+func (r *RoomActor) chatKnowingWord(ctx *actor.ReceiveContext, playerID, text string) {
+	if r.leaksWord(text) {
+		r.publish(ctx, &ErrorEvent{For: playerID, Message: "not sent — that gives the word away"})
+		return
+	}
+	r.chat(ctx, playerID, text)
+}
+
+// leaksWord reports whether text gives the secret word away: a word
+// (or, for a multi-word secret, a run of up to as many words) that
+// matches it, or letters spelled out one at a time ("c a t") that contain it.
+// Words are compared whole, so "great catch" does not leak "cat"; a
+// long secret also matches one typo away.
+func (r *RoomActor) leaksWord(text string) bool {
+	word := normalizeGuess(r.language, r.word)
+	if word == "" {
+		return false
+	}
+
+	tokens := strings.FieldsFunc(text, func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsNumber(c) && c != '-' && c != '\'' && c != '’'
+	})
+	for i := range tokens {
+		tokens[i] = normalizeGuess(r.language, tokens[i])
+	}
+
+	distance := closeGuessDistance(word) - 1
+	for span := 1; span <= max(len(strings.Fields(r.word)), 1); span++ {
+		for i := 0; i+span <= len(tokens); i++ {
+			if editDistance(strings.Join(tokens[i:i+span], ""), word) <= distance {
+				return true
+			}
+		}
+	}
+
+	for i := 0; i < len(tokens); {
+		j := i
+		var letters strings.Builder
+		for j < len(tokens) && len([]rune(tokens[j])) == 1 {
+			letters.WriteString(tokens[j])
+			j++
+		}
+		if j-i > 1 && strings.Contains(letters.String(), word) {
+			return true
+		}
+		i = max(j, i+1)
+	}
+
+	return false
+}
+
+// moderate handles the inputs every phase treats alike — kick votes
+// and drawing reports. It reports whether it consumed msg and whether
+// a player was kicked, so the behavior can run its own checks for a
+// player leaving (maybeAbortRound, maybeShutdown).
+func (r *RoomActor) moderate(ctx *actor.ReceiveContext, msg *PlayerInput, phase string) (handled, kicked bool) {
+	switch msg.In.Type {
+	case InTypeVoteKick:
+		return true, r.voteKick(ctx, msg.PlayerID, msg.In.Target, phase)
+	case InTypeReport:
+		if phase == PhaseDrawing {
+			r.reportDrawing(ctx, msg.PlayerID)
+		}
+		return true, false
+	}
+	return false, false
+}
+
+// voteKick records voterID's vote to remove targetID and kicks the
+// target once a majority of the eligible voters — everyone but the
+// target and the drawer — agree. Votes from players who have since
+// left no longer count.
+func (r *RoomActor) voteKick(ctx *actor.ReceiveContext, voterID, targetID, phase string) bool {
+	voter, target := r.playerByID(voterID), r.playerByID(targetID)
+	if voter == nil || target == nil || voterID == targetID {
+		return false
+	}
+
+	voters := r.kickVoters(targetID)
+	if voterID == r.drawerID {
+		r.publish(ctx, &ErrorEvent{For: voterID, Message: "the drawer can't vote"})
+		return false
+	}
+	if len(voters) < minKickVoters {
+		r.publish(ctx, &ErrorEvent{For: voterID, Message: "not enough players for a kick vote"})
+		return false
+	}
+
+	votes := r.kickVotes[targetID]
+	if votes == nil {
+		votes = make(map[string]bool)
+		r.kickVotes[targetID] = votes
+	}
+	votes[voterID] = true
+
+	count, need := countVotes(votes, voters), len(voters)/2+1
+	if count < need {
+		r.publish(ctx, &ChatEvent{
+			From: "🗳",
+			Text: fmt.Sprintf("%s voted to kick %s (%d/%d)", voter.name, target.name, count, need),
+		})
+		return false
+	}
+
+	r.kick(ctx, target, phase)
+	return true
+}
+
+// kick removes target for good: the session is told to hang up and
+// both the player id and the client IP are banned. The id alone is
+// whatever the browser sent, so a fresh ?id= would walk straight back
+// in; the IP is what the gateway saw.
+func (r *RoomActor) kick(ctx *actor.ReceiveContext, target *roomPlayer, phase string) {
+	r.banned[target.id] = true
+	if target.addr != "" {
+		r.banned[target.addr] = true
+	}
+	r.removePlayerByName(target.sessionName)
+
+	r.publish(ctx, &KickedEvent{For: target.id, Message: "you were voted out of this room"})
+	r.publish(ctx, &ChatEvent{From: "📣", Text: fmt.Sprintf("%s was voted out", target.name)})
+	ctx.Logger().Infof("room %s: %s kicked by vote", r.code, target.name)
+	r.broadcastState(ctx, phase)
+}
+
+// kickVoters lists the players who may vote on kicking targetID.
+func (r *RoomActor) kickVoters(targetID string) []string {
+	var out []string
+	for _, player := range r.players {
+		if player.id != targetID && player.id != r.drawerID {
+			out = append(out, player.id)
+		}
+	}
+	return out
+}
+
+// reportDrawing records a report of the current drawing. Every report
+// is logged for the operator; once a majority of the guessers agree
+// the canvas is wiped, the round ends, and the drawing is kept out of
+// the archive.
+func (r *RoomActor) reportDrawing(ctx *actor.ReceiveContext, reporterID string) {
+	if reporterID == r.drawerID || r.playerByID(reporterID) == nil || r.reports[reporterID] {
+		return
+	}
+	r.reports[reporterID] = true
+
+	guessers := r.kickVoters(r.drawerID)
+	count, need := countVotes(r.reports, guessers), len(guessers)/2+1
+	ctx.Logger().Warnf("room %s: %s reported round %d's drawing by %s (word %q) — %d/%d",
+		r.code, r.nameFor(reporterID), r.round, r.nameFor(r.drawerID), r.word, count, need)
+	r.publish(ctx, &ChatEvent{For: reporterID, From: "🚩", Text: "thanks — the drawing was reported"})
+
+	if count < need {
+		return
+	}
+
+	r.takenDown = true
+	r.strokes = nil
+	r.publish(ctx, &ClearEvent{})
+	r.publish(ctx, &ChatEvent{From: "🚩", Text: "the drawing was taken down after reports"})
+	r.enterRoundOver(ctx)
+}
+
+// countVotes counts the votes cast by players in eligible.
+func countVotes(votes map[string]bool, eligible []string) int {
+	n := 0
+	for _, id := range eligible {
+		if votes[id] {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,299 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/tochemey/goakt/v4/actor"
+	"github.com/tochemey/goakt/v4/extension"
+)
+
+const (
+	WordPackExtensionID = "pictograph_word_packs"
+
+	// CustomPackPrefix marks the id of an uploaded word list.
+	CustomPackPrefix = "custom-"
+
+	// maxCustomPacks bounds the uploaded lists a node keeps; the oldest
+	// is evicted once it is full. A list only needs to live until its
+	// room has been created.
+	maxCustomPacks = 256
+
+	// Upload limits: a list must offer at least one full set of word
+	// choices and is capped so it fits comfortably in a JoinOrCreate.
+	minCustomWords = WordChoices
+	maxCustomWords = 500
+	maxWordRunes   = 32
+	maxUploadBytes = 64 << 10
+)
+
+// Difficulty levels a pack can be tagged with.
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard"
+)
+
+// WordPack is a themed list of drawable words in one language. The
+// bundled packs live in packs/*.json; --word-packs adds more from disk
+// in the same format.
+type WordPack struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Language   string   `json:"language"`
+	Category   string   `json:"category"`
+	Difficulty string   `json:"difficulty"`
+	Words      []string `json:"words,omitempty"`
+}
+
+// WordPackStore holds every word pack this node knows: the bundled and
+// on-disk packs, loaded at startup, and the custom lists players upload
+// before creating a room.
+//
+// It is process-local. The bundled and on-disk packs are the same on
+// every node of a deployment, so the room resolves them on whichever
+// node it lands; a custom list is resolved by the gateway that received
+// the upload and travels to the room inline in JoinOrCreate.
+type WordPackStore struct {
+	mu     sync.RWMutex
+	packs  map[string]*WordPack
+	custom []string // upload order, for eviction
+}
+
+var _ extension.Extension = (*WordPackStore)(nil)
+
+func NewWordPackStore() *WordPackStore {
+	return &WordPackStore{packs: make(map[string]*WordPack)}
+}
+
+func (s *WordPackStore) ID() string { return WordPackExtensionID }
+
+// Load reads every *.json pack in the root of fsys. A pack whose id is
+// already known replaces it, so a --word-packs directory can override a
+// bundled pack.
+func (s *WordPackStore) Load(fsys fs.FS) error {
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+
+		var pack WordPack
+		if err := json.Unmarshal(data, &pack); err != nil {
+			return fmt.Errorf("word pack %s: %w", file, err)
+		}
+		if pack.ID == "" {
+			pack.ID = strings.TrimSuffix(path.Base(file), ".json")
+		}
+		if pack.Language == "" || strings.HasPrefix(pack.ID, CustomPackPrefix) {
+			return fmt.Errorf("word pack %s: missing language or reserved id %q", file, pack.ID)
+		}
+		pack.Language = strings.ToLower(pack.Language)
+		pack.Words = cleanWordList(pack.Words)
+		if len(pack.Words) == 0 {
+			return fmt.Errorf("word pack %s: no words", file)
+		}
+
+		s.mu.Lock()
+		s.packs[pack.ID] = &pack
+		s.mu.Unlock()
+	}
+
+	return nil
+}
+
+// Packs returns the metadata of every pack except uploaded lists,
+// sorted by language then id. Words are left out.
+func (s *WordPackStore) Packs() []WordPack {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]WordPack, 0, len(s.packs))
+	for _, pack := range s.packs {
+		if strings.HasPrefix(pack.ID, CustomPackPrefix) {
+			continue
+		}
+		meta := *pack
+		meta.Words = nil
+		out = append(out, meta)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Language != out[j].Language {
+			return out[i].Language < out[j].Language
+		}
+		return out[i].ID < out[j].ID
+	})
+
+	return out
+}
+
+// Languages returns the codes that have at least one pack.
+func (s *WordPackStore) Languages() []string {
+	var out []string
+	for _, pack := range s.Packs() {
+		if !slices.Contains(out, pack.Language) {
+			out = append(out, pack.Language)
+		}
+	}
+	return out
+}
+
+// Pool returns the words of the language's packs named in ids, or of all
+// its packs when ids is empty, keeping only difficulty when it is set.
+// Unknown ids and packs of another language are skipped.
+func (s *WordPackStore) Pool(language string, ids []string, difficulty string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var (
+		pool []string
+		seen = make(map[string]bool)
+	)
+	for _, pack := range s.packs {
+		if pack.Language != language || strings.HasPrefix(pack.ID, CustomPackPrefix) {
+			continue
+		}
+		if len(ids) > 0 && !slices.Contains(ids, pack.ID) {
+			continue
+		}
+		if difficulty != "" && pack.Difficulty != difficulty {
+			continue
+		}
+		for _, word := range pack.Words {
+			if !seen[word] {
+				seen[word] = true
+				pool = append(pool, word)
+			}
+		}
+	}
+
+	// Map iteration order is random; sort so a pool is reproducible.
+	sort.Strings(pool)
+
+	return pool
+}
+
+// AddCustom stores an uploaded word list and returns its pack id.
+func (s *WordPackStore) AddCustom(language string, words []string) (string, error) {
+	words = cleanWordList(words)
+	switch {
+	case len(words) < minCustomWords:
+		return "", fmt.Errorf("a word list needs at least %d distinct words", minCustomWords)
+	case len(words) > maxCustomWords:
+		return "", fmt.Errorf("a word list is limited to %d words", maxCustomWords)
+	}
+
+	id := CustomPackPrefix + uuid.NewString()[:8]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.custom) >= maxCustomPacks {
+		delete(s.packs, s.custom[0])
+		s.custom = s.custom[1:]
+	}
+
+	s.packs[id] = &WordPack{ID: id, Name: "Custom", Language: language, Category: "custom", Words: words}
+	s.custom = append(s.custom, id)
+
+	return id, nil
+}
+
+// Custom returns an uploaded list by id.
+func (s *WordPackStore) Custom(id string) (*WordPack, bool) {
+	if !strings.HasPrefix(id, CustomPackPrefix) {
+		return nil, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pack, ok := s.packs[id]
+
+	return pack, ok
+}
+
+func wordPackStoreFromExtension(system actor.ActorSystem) *WordPackStore {
+	for _, ext := range system.Extensions() {
+		if ext.ID() == WordPackExtensionID {
+			if store, ok := ext.(*WordPackStore); ok {
+				return store
+			}
+		}
+	}
+
+	return nil
+}
+
+// packsHandler serves GET /packs, the pack catalogue the create-room
+// form offers, and POST /packs?lang=, which uploads a custom list (one
+// word per line) and answers with the id to pass as ?packs= on /ws.
+func packsHandler(store *WordPackStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"packs":     store.Packs(),
+				"languages": store.Languages(),
+			})
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxUploadBytes))
+		if err != nil {
+			http.Error(w, "word list too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		language := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("lang")))
+		if language == "" {
+			language = DefaultLanguage
+		}
+
+		id, err := store.AddCustom(language, strings.Split(string(body), "\n"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": id})
+	}
+}
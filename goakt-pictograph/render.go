@@ -0,0 +1,271 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Rendering geometry. Stroke points are normalized, but stroke widths
+// are in the pixels of the browser's 800×500 <canvas> (web/index.html),
+// so that is the coordinate space replays are drawn in.
+const (
+	canvasWidth    = 800
+	canvasHeight   = 500
+	maxStrokeWidth = 20 // the toolbar's width slider tops out here
+
+	// The GIF is rendered at half size: a replay is a share thumbnail,
+	// not an archive copy, and every frame costs bytes.
+	gifScale = 0.5
+
+	// gifMaxFrames bounds the frame count; strokes are bucketed into
+	// steps of at least gifMinStepMs of drawing time.
+	gifMaxFrames = 60
+	gifMinStepMs = 100
+
+	// gifPlaybackMs is the longest a replay plays for. Slower rounds are
+	// sped up to fit; the last frame then holds for gifHoldDelay
+	// hundredths of a second before the loop restarts.
+	gifPlaybackMs = 10_000
+	gifHoldDelay  = 300
+)
+
+// strokePalette mirrors PALETTE in web/main.ts: StrokeEvent.Color is an
+// index into it. The last entry, white, is also the canvas background.
+var strokePalette = color.Palette{
+	color.RGBA{0x00, 0x00, 0x00, 0xff},
+	color.RGBA{0xe6, 0x39, 0x46, 0xff},
+	color.RGBA{0xf4, 0xa2, 0x61, 0xff},
+	color.RGBA{0xe9, 0xc4, 0x6a, 0xff},
+	color.RGBA{0x2a, 0x9d, 0x8f, 0xff},
+	color.RGBA{0x26, 0x46, 0x53, 0xff},
+	color.RGBA{0x9d, 0x4e, 0xdd, 0xff},
+	color.RGBA{0xff, 0xff, 0xff, 0xff},
+}
+
+const backgroundIndex = 7
+
+// renderSVG writes the finished picture of a round: every stroke after
+// the last clear, as the guessers saw it when time ran out.
+func renderSVG(w io.Writer, drawing *RoundDrawing) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d">`,
+		canvasWidth, canvasHeight, canvasWidth, canvasHeight)
+	b.WriteString("<title>")
+	_ = xml.EscapeText(&b, []byte(fmt.Sprintf("%s by %s", drawing.Word, drawing.DrawerName)))
+	b.WriteString("</title>")
+	b.WriteString(`<rect width="100%" height="100%" fill="#ffffff"/>`)
+
+	for _, stroke := range visibleStrokes(drawing.Strokes) {
+		if len(stroke.Points) == 0 {
+			continue
+		}
+
+		rgba := strokeColor(stroke.Color)
+		fmt.Fprintf(&b, `<polyline fill="none" stroke="#%02x%02x%02x" stroke-width="%d" stroke-linecap="round" stroke-linejoin="round" points="`,
+			rgba.R, rgba.G, rgba.B, strokeWidth(stroke.Width))
+		for i, point := range stroke.Points {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(strconv.FormatFloat(clamp01(point[0])*canvasWidth, 'f', 1, 64))
+			b.WriteByte(',')
+			b.WriteString(strconv.FormatFloat(clamp01(point[1])*canvasHeight, 'f', 1, 64))
+		}
+		b.WriteString(`"/>`)
+	}
+
+	b.WriteString("</svg>")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// renderGIF writes an animated replay of a round. Strokes are bucketed
+// by timestamp into at most gifMaxFrames frames; each frame after the
+// first only carries the rectangle that changed since the previous one
+// (DisposalNone keeps everything underneath), so a replay costs little
+// more than its final frame.
+func renderGIF(w io.Writer, drawing *RoundDrawing) error {
+	width, height := int(canvasWidth*gifScale), int(canvasHeight*gifScale)
+	canvas := image.NewPaletted(image.Rect(0, 0, width, height), strokePalette)
+	fillBackground(canvas)
+
+	var total int64
+	if n := len(drawing.Strokes); n > 0 {
+		total = drawing.Strokes[n-1].At
+	}
+	step := max(total/gifMaxFrames, gifMinStepMs)
+	speed := max(1, total/gifPlaybackMs)
+
+	anim := &gif.GIF{
+		Config: image.Config{ColorModel: strokePalette, Width: width, Height: height},
+	}
+	var times []int64
+
+	// The first frame always covers the whole canvas so the background
+	// is painted before any partial frame lands on it.
+	dirty := canvas.Bounds()
+	bucket := int64(0)
+
+	flush := func() {
+		if dirty.Empty() {
+			return
+		}
+		frame := image.NewPaletted(dirty, strokePalette)
+		for y := dirty.Min.Y; y < dirty.Max.Y; y++ {
+			copy(frame.Pix[frame.PixOffset(dirty.Min.X, y):frame.PixOffset(dirty.Max.X, y)],
+				canvas.Pix[canvas.PixOffset(dirty.Min.X, y):canvas.PixOffset(dirty.Max.X, y)])
+		}
+		anim.Image = append(anim.Image, frame)
+		anim.Disposal = append(anim.Disposal, gif.DisposalNone)
+		times = append(times, bucket*step)
+		dirty = image.Rectangle{}
+	}
+
+	for _, stroke := range drawing.Strokes {
+		if b := min(stroke.At/step, gifMaxFrames-1); b != bucket {
+			flush()
+			bucket = b
+		}
+
+		if stroke.Clear {
+			fillBackground(canvas)
+			dirty = canvas.Bounds()
+			continue
+		}
+		dirty = dirty.Union(rasterizeStroke(canvas, stroke, gifScale))
+	}
+	flush()
+
+	anim.Delay = make([]int, len(anim.Image))
+	for i := range anim.Delay {
+		if i+1 == len(times) {
+			anim.Delay[i] = gifHoldDelay
+			continue
+		}
+		// GIF delays are in hundredths of a second; browsers treat
+		// anything under 2 as "as slow as we like", so floor it there.
+		anim.Delay[i] = int(max(2, (times[i+1]-times[i])/speed/10))
+	}
+
+	return gif.EncodeAll(w, anim)
+}
+
+// visibleStrokes returns the strokes drawn after the last clear.
+func visibleStrokes(strokes []TimedStroke) []TimedStroke {
+	for i := len(strokes) - 1; i >= 0; i-- {
+		if strokes[i].Clear {
+			return strokes[i+1:]
+		}
+	}
+	return strokes
+}
+
+// rasterizeStroke draws stroke onto img by stamping round discs along
+// every segment — the same round caps and joins the browser's canvas
+// uses — and returns the rectangle it touched.
+func rasterizeStroke(img *image.Paletted, stroke TimedStroke, scale float64) image.Rectangle {
+	if len(stroke.Points) == 0 {
+		return image.Rectangle{}
+	}
+
+	// An unknown palette index draws black, as in the browser.
+	idx := uint8(0)
+	if stroke.Color > 0 && stroke.Color < len(strokePalette) {
+		idx = uint8(stroke.Color)
+	}
+
+	bounds := img.Bounds()
+	fw, fh := float64(bounds.Dx()), float64(bounds.Dy())
+	radius := float64(strokeWidth(stroke.Width)) * scale / 2
+	spacing := max(radius/2, 0.5)
+
+	x0, y0 := clamp01(stroke.Points[0][0])*fw, clamp01(stroke.Points[0][1])*fh
+	touched := stampDisc(img, x0, y0, radius, idx)
+	for _, point := range stroke.Points[1:] {
+		x1, y1 := clamp01(point[0])*fw, clamp01(point[1])*fh
+		steps := int(math.Hypot(x1-x0, y1-y0)/spacing) + 1
+		for s := 1; s <= steps; s++ {
+			t := float64(s) / float64(steps)
+			touched = touched.Union(stampDisc(img, x0+(x1-x0)*t, y0+(y1-y0)*t, radius, idx))
+		}
+		x0, y0 = x1, y1
+	}
+
+	return touched
+}
+
+// stampDisc fills the pixels whose centres lie within radius of (cx, cy)
+// and returns their bounding rectangle, clipped to img.
+func stampDisc(img *image.Paletted, cx, cy, radius float64, idx uint8) image.Rectangle {
+	area := image.Rect(
+		int(math.Floor(cx-radius)), int(math.Floor(cy-radius)),
+		int(math.Ceil(cx+radius))+1, int(math.Ceil(cy+radius))+1,
+	).Intersect(img.Bounds())
+
+	r2 := max(radius*radius, 0.5)
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		dy := float64(y) + 0.5 - cy
+		for x := area.Min.X; x < area.Max.X; x++ {
+			dx := float64(x) + 0.5 - cx
+			if dx*dx+dy*dy <= r2 {
+				img.Pix[img.PixOffset(x, y)] = idx
+			}
+		}
+	}
+
+	return area
+}
+
+func fillBackground(img *image.Paletted) {
+	for i := range img.Pix {
+		img.Pix[i] = backgroundIndex
+	}
+}
+
+// strokeColor resolves a palette index the way the browser does: an
+// unknown index draws black.
+func strokeColor(i int) color.RGBA {
+	if i < 0 || i >= len(strokePalette) {
+		i = 0
+	}
+	return strokePalette[i].(color.RGBA)
+}
+
+func strokeWidth(w int) int {
+	return min(max(w, 1), maxStrokeWidth)
+}
+
+func clamp01(v float64) float64 {
+	return min(max(v, 0), 1)
+}
@@ -0,0 +1,167 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image"
+	"image/gif"
+	"strings"
+	"testing"
+)
+
+// testDrawing is a round with a stroke that is wiped by a clear, then
+// two strokes that survive it, the last one near the end of the round.
+func testDrawing() *RoundDrawing {
+	return &RoundDrawing{
+		Game:       1,
+		Round:      2,
+		Word:       "cat & dog",
+		DrawerName: "Ada",
+		Strokes: []TimedStroke{
+			{At: 500, Points: [][2]float64{{0.1, 0.1}, {0.9, 0.9}}, Color: 1, Width: 4},
+			{At: 1_000, Clear: true},
+			{At: 2_000, Points: [][2]float64{{0.25, 0.5}, {0.75, 0.5}}, Color: 4, Width: 6},
+			{At: 60_000, Points: [][2]float64{{0.5, 0.2}, {0.5, 0.8}, {1.5, -1}}, Color: 99, Width: 50},
+		},
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := renderSVG(&buf, testDrawing()); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+
+	var svg struct {
+		ViewBox   string `xml:"viewBox,attr"`
+		Title     string `xml:"title"`
+		Polylines []struct {
+			Stroke      string `xml:"stroke,attr"`
+			StrokeWidth string `xml:"stroke-width,attr"`
+			Points      string `xml:"points,attr"`
+		} `xml:"polyline"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &svg); err != nil {
+		t.Fatalf("not well-formed XML: %v\n%s", err, buf.String())
+	}
+
+	if svg.ViewBox != "0 0 800 500" {
+		t.Errorf("viewBox %q", svg.ViewBox)
+	}
+	if svg.Title != "cat & dog by Ada" {
+		t.Errorf("title %q", svg.Title)
+	}
+
+	// The stroke before the clear is gone; the out-of-range colour,
+	// width and point of the last one are clamped.
+	want := []struct{ stroke, width, points string }{
+		{"#2a9d8f", "6", "200.0,250.0 600.0,250.0"},
+		{"#000000", "20", "400.0,100.0 400.0,400.0 800.0,0.0"},
+	}
+	if len(svg.Polylines) != len(want) {
+		t.Fatalf("got %d polylines want %d", len(svg.Polylines), len(want))
+	}
+	for i, w := range want {
+		got := svg.Polylines[i]
+		if got.Stroke != w.stroke || got.StrokeWidth != w.width || got.Points != w.points {
+			t.Errorf("polyline %d: %s/%s %q want %s/%s %q", i, got.Stroke, got.StrokeWidth, got.Points, w.stroke, w.width, w.points)
+		}
+	}
+}
+
+func TestRenderGIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := renderGIF(&buf, testDrawing()); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	width, height := int(canvasWidth*gifScale), int(canvasHeight*gifScale)
+	if anim.Config.Width != width || anim.Config.Height != height {
+		t.Fatalf("size %dx%d want %dx%d", anim.Config.Width, anim.Config.Height, width, height)
+	}
+	if n := len(anim.Image); n < 2 || n > gifMaxFrames {
+		t.Fatalf("got %d frames want 2..%d", n, gifMaxFrames)
+	}
+	if anim.Image[0].Bounds() != image.Rect(0, 0, width, height) {
+		t.Errorf("first frame %v does not cover the canvas", anim.Image[0].Bounds())
+	}
+	if last := anim.Delay[len(anim.Delay)-1]; last != gifHoldDelay {
+		t.Errorf("last frame holds %d want %d", last, gifHoldDelay)
+	}
+
+	total := 0
+	for _, delay := range anim.Delay {
+		total += delay
+	}
+	if limit := (gifPlaybackMs/10 + gifHoldDelay) * 11 / 10; total > limit {
+		t.Errorf("replay plays for %d/100 s, over %d", total, limit)
+	}
+
+	// Composite the frames as a viewer would (DisposalNone) and check
+	// the final picture: the cleared stroke is gone, the others are
+	// there in their palette colours.
+	canvas := image.NewPaletted(image.Rect(0, 0, width, height), strokePalette)
+	for _, frame := range anim.Image {
+		for y := frame.Rect.Min.Y; y < frame.Rect.Max.Y; y++ {
+			for x := frame.Rect.Min.X; x < frame.Rect.Max.X; x++ {
+				canvas.SetColorIndex(x, y, frame.ColorIndexAt(x, y))
+			}
+		}
+	}
+
+	at := func(x, y float64) uint8 {
+		return canvas.ColorIndexAt(int(x*float64(width)), int(y*float64(height)))
+	}
+	for _, c := range []struct {
+		name string
+		x, y float64
+		want uint8
+	}{
+		{"cleared stroke", 0.1, 0.1, backgroundIndex},
+		{"horizontal stroke", 0.3, 0.5, 4},
+		{"vertical stroke", 0.5, 0.7, 0},
+		{"background", 0.1, 0.9, backgroundIndex},
+	} {
+		if got := at(c.x, c.y); got != c.want {
+			t.Errorf("%s: palette index %d want %d", c.name, got, c.want)
+		}
+	}
+}
+
+func TestRenderSVGEscapesTitle(t *testing.T) {
+	var buf bytes.Buffer
+	drawing := &RoundDrawing{Word: "</title><script>", DrawerName: "x"}
+	if err := renderSVG(&buf, drawing); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if strings.Contains(buf.String(), "<script>") {
+		t.Errorf("title not escaped: %s", buf.String())
+	}
+}
@@ -44,12 +44,6 @@ const (
 	schedRefShutdown  = "shutdown."
 	schedRefStartGame = "startgame."
 
-	// strokeBufferMax bounds the per-round stroke history kept for
-	// late-joiners. Each stroke is small (~tens of bytes) but a fast
-	// drawer can emit thousands per round; clip so memory doesn't grow
-	// without bound for very long matches.
-	strokeBufferMax = 2000
-
 	// joinGatherWindow gives a forming room a few seconds for more
 	// players to arrive before round 1 starts. Resets every time a new
 	// player joins (so 8 players trickling in over 30s all play together).
@@ -63,8 +57,10 @@ type roomPlayer struct {
 	name        string
 	sessionName string // cluster-stable name; matches PlayerSessionActor.Self().Name()
 	sessionPID  *actor.PID
+	addr        string // client IP from PlayerHello.RemoteAddr
 	score       int
 	hasDrawn    bool // set true on becoming drawer; reset when all have drawn
+	team        int  // TeamRed or TeamBlue in team mode, else 0
 }
 
 // RoomActor owns one match. The FSM is implemented as four named
@@ -79,22 +75,50 @@ type roomPlayer struct {
 // extensions. No constructor-injected deps allowed.
 type RoomActor struct {
 	leaderboard *Leaderboard // resolved in PostStart from system extensions
+	filter      *ChatFilter  // ditto; nil lets all chat through
+
+	code       string
+	settings   GameSettings // the creator's rules, normalized
+	gameNumber int          // bumped on every game start; keys the drawing archive
 
-	code      string
-	maxRounds int
+	// word pool, resolved from the creator's WordSettings; usedWords
+	// holds every word offered this game so none comes round twice.
+	language  string
+	words     []string
+	usedWords map[string]bool
 
 	players []*roomPlayer
 
 	// round state
 	round       int
 	drawerID    string
+	drawTeam    int // the drawer's team in team mode; the other team draws next
 	word        string
 	wordChoices []string
 	timeLeft    int
+	hints       []int           // rune positions of r.word to reveal, in order
+	revealed    int             // how many of hints the guessers can see
 	guessed     map[string]bool // playerIDs that already guessed correctly
 
-	// per-round stroke buffer for state replay to late-joiners
-	strokes []*StrokeEvent
+	// moderation: kickVotes maps a target's player id to the ids that
+	// voted against them; a kicked player's id and client IP go into
+	// banned, and a PlayerHello matching either is refused for the
+	// room's lifetime. reports holds this round's drawing reports, and
+	// takenDown keeps a reported drawing out of the archive.
+	kickVotes map[string]map[string]bool
+	banned    map[string]bool
+	reports   map[string]bool
+	takenDown bool
+
+	// per-round stroke timeline, for state replay to late-joiners and
+	// the drawing archive. Offsets are measured from drawStart. A fresh
+	// slice every round: the old one is handed off in RoundOverEvent.
+	strokes   []TimedStroke
+	drawStart time.Time
+
+	// drawings lists the archived rounds of the current game for the
+	// GameOverEvent's share links.
+	drawings []DrawingView
 
 	// schedule reference suffix (per-actor unique) — see schedRef*().
 	schedSuffix string
@@ -106,6 +130,12 @@ type RoomActor struct {
 	// PostStart and the first PlayerHello (same guard pattern as
 	// MatchActor in goakt-tetris).
 	hadPlayer bool
+
+	// public lists the room in the lobby's room browser; reported is
+	// the last RoomStatus sent, so unchanged ones (every drawing tick)
+	// are not re-sent.
+	public   bool
+	reported RoomStatus
 }
 
 var _ actor.Actor = (*RoomActor)(nil)
@@ -114,11 +144,12 @@ func (*RoomActor) PreStart(*actor.Context) error { return nil }
 
 // PostStop cancels every schedule the room ever set so no further
 // messages are queued to a dead actor (which would land in the dead-
-// letter queue).
+// letter queue), then tells the lobby to drop the room from its index.
 func (r *RoomActor) PostStop(ctx *actor.Context) error {
 	for ref := range r.activeRefs {
 		_ = ctx.ActorSystem().CancelSchedule(ref)
 	}
+	r.reportStopped(ctx)
 	return nil
 }
 
@@ -131,8 +162,12 @@ func (r *RoomActor) Receive(ctx *actor.ReceiveContext) {
 		// (lobby spawns rooms as "room.<lowercase code>"); upper-case
 		// it back for the public-facing UI.
 		r.code = strings.ToUpper(strings.TrimPrefix(ctx.Self().Name(), RoomActorPrefix))
-		r.maxRounds = DefaultRounds
+		r.settings = DefaultGameSettings()
 		r.guessed = make(map[string]bool)
+		r.usedWords = make(map[string]bool)
+		r.kickVotes = make(map[string]map[string]bool)
+		r.banned = make(map[string]bool)
+		r.reports = make(map[string]bool)
 		r.activeRefs = make(map[string]struct{})
 		r.topic = RoomTopicPrefix + r.code
 		r.schedSuffix = "." + ctx.Self().Name()
@@ -140,6 +175,13 @@ func (r *RoomActor) Receive(ctx *actor.ReceiveContext) {
 		if r.leaderboard == nil {
 			ctx.Logger().Errorf("room %s: leaderboard extension not registered — leaderboard disabled", r.code)
 		}
+		r.filter = chatFilterFromExtension(ctx.ActorSystem())
+		// A restarted lobby asks every room to report in on this topic.
+		if topic := ctx.ActorSystem().TopicActor(); topic != nil {
+			ctx.Tell(topic, actor.NewSubscribe(LobbyTopic))
+		}
+		// Default words until (unless) the lobby's ConfigureRoom lands.
+		r.configureWords(ctx, WordSettings{})
 		ctx.Become(r.waitingBehavior)
 	default:
 		// Defensive: route any straggler to waiting.
@@ -196,25 +238,38 @@ func (r *RoomActor) broadcastState(ctx *actor.ReceiveContext, phase string) {
 	r.publish(ctx, &StateEvent{
 		Phase:     phase,
 		Round:     r.round,
-		MaxRounds: r.maxRounds,
+		MaxRounds: r.settings.Rounds,
 		TimeLeft:  r.timeLeft,
 		Players:   r.playerViews(),
 		DrawerID:  r.drawerID,
 		WordMask:  r.currentMask(),
+		Language:  r.language,
+		Settings:  r.settings,
 	})
+	r.reportStatus(ctx, phase)
 }
 
 func (r *RoomActor) currentMask() string {
 	if r.word == "" {
 		return ""
 	}
-	return maskWord(r.word)
+	return maskWord(r.word, r.hints[:r.revealed])
+}
+
+// revealDueHints uncovers the next hint letters once their time has
+// come. The hints split the drawing phase into equal slices: with two
+// hints over 80 s the letters land at 53 s and 26 s left.
+func (r *RoomActor) revealDueHints() {
+	n := len(r.hints)
+	for r.revealed < n && r.timeLeft*(n+1) <= r.settings.DrawSeconds*(n-r.revealed) {
+		r.revealed++
+	}
 }
 
 func (r *RoomActor) playerViews() []PlayerView {
 	out := make([]PlayerView, 0, len(r.players))
 	for _, player := range r.players {
-		out = append(out, PlayerView{ID: player.id, Name: player.name, Score: player.score})
+		out = append(out, PlayerView{ID: player.id, Name: player.name, Score: player.score, Team: player.team})
 	}
 	return out
 }
@@ -237,11 +292,17 @@ func (r *RoomActor) playerByID(id string) *roomPlayer {
 // pick up where they left off without the old GoodbyePlayer evicting
 // them — its SessionName no longer matches anything.
 func (r *RoomActor) addPlayer(ctx *actor.ReceiveContext, msg *PlayerHello, sender *actor.PID) bool {
+	if r.banned[msg.PlayerID] || (msg.RemoteAddr != "" && r.banned[msg.RemoteAddr]) {
+		ctx.Tell(sender, &KickedEvent{For: msg.PlayerID, Message: "you were voted out of this room"})
+		return false
+	}
+
 	r.leaderboard.RememberName(msg.PlayerID, msg.Name)
 
 	if existing := r.playerByID(msg.PlayerID); existing != nil {
 		existing.sessionName = msg.SessionName
 		existing.sessionPID = sender
+		existing.addr = msg.RemoteAddr
 		existing.name = msg.Name
 		if sender != nil {
 			ctx.Watch(sender)
@@ -259,6 +320,8 @@ func (r *RoomActor) addPlayer(ctx *actor.ReceiveContext, msg *PlayerHello, sende
 		name:        msg.Name,
 		sessionName: msg.SessionName,
 		sessionPID:  sender,
+		addr:        msg.RemoteAddr,
+		team:        r.smallerTeam(),
 	})
 	r.hadPlayer = true
 
@@ -278,6 +341,7 @@ func (r *RoomActor) removePlayerByName(name string) bool {
 	for i, player := range r.players {
 		if player.sessionName == name {
 			r.players = append(r.players[:i], r.players[i+1:]...)
+			delete(r.kickVotes, player.id)
 			return true
 		}
 	}
@@ -289,6 +353,7 @@ func (r *RoomActor) removePlayerByPath(path actor.Path) (*roomPlayer, bool) {
 		if player.sessionPID != nil && player.sessionPID.Path().Equals(path) {
 			removed := player
 			r.players = append(r.players[:i], r.players[i+1:]...)
+			delete(r.kickVotes, player.id)
 			return removed, true
 		}
 	}
@@ -299,24 +364,38 @@ func (r *RoomActor) removePlayerByPath(path actor.Path) (*roomPlayer, bool) {
 // hasDrawn when they become drawer; once every player has drawn we
 // reset the flags and start again. New joiners default to hasDrawn=false
 // so they'll be picked sooner than already-drawn players.
+//
+// In team mode the teams take turns and the rotation runs within the
+// drawing team, so teammates alternate at the pen.
 func (r *RoomActor) pickNextDrawer() string {
-	if len(r.players) == 0 {
+	candidates := r.players
+	if r.settings.Teams {
+		r.drawTeam = r.nextDrawTeam()
+		candidates = nil
+		for _, player := range r.players {
+			if player.team == r.drawTeam {
+				candidates = append(candidates, player)
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
 		return ""
 	}
 
-	for _, player := range r.players {
+	for _, player := range candidates {
 		if !player.hasDrawn {
 			player.hasDrawn = true
 			return player.id
 		}
 	}
 
-	for _, player := range r.players {
+	for _, player := range candidates {
 		player.hasDrawn = false
 	}
 
-	r.players[0].hasDrawn = true
-	return r.players[0].id
+	candidates[0].hasDrawn = true
+	return candidates[0].id
 }
 
 func (r *RoomActor) waitingBehavior(ctx *actor.ReceiveContext) {
@@ -353,19 +432,32 @@ func (r *RoomActor) waitingBehavior(ctx *actor.ReceiveContext) {
 		r.maybeShutdown(ctx)
 
 	case *PlayerInput:
+		if handled, kicked := r.moderate(ctx, msg, PhaseWaiting); handled {
+			if kicked {
+				r.cancelGatherIfBelowMin(ctx)
+			}
+			return
+		}
 		// In waiting phase only chat is meaningful; treat any
 		// chat-shaped input as such, ignore the rest.
 		if msg.In.Type == InTypeGuess {
-			r.publish(ctx, &ChatEvent{From: r.nameFor(msg.PlayerID), Text: msg.In.Text})
+			r.chat(ctx, msg.PlayerID, msg.In.Text)
 		}
 
+	case *ConfigureRoom:
+		r.public = msg.Public
+		r.configureWords(ctx, msg.Words)
+		r.configureGame(ctx, msg.Game)
+		r.broadcastState(ctx, PhaseWaiting)
+
 	case *nextRound:
 		// Gather window elapsed — start the first round.
 		r.round = 0 // enterChoosing increments to 1
+		r.startGame()
 		r.enterChoosing(ctx)
 
 	default:
-		ctx.Unhandled()
+		r.unhandled(ctx)
 	}
 }
 
@@ -401,17 +493,19 @@ func (r *RoomActor) sendJoined(ctx *actor.ReceiveContext, msg *PlayerHello) {
 func (r *RoomActor) enterChoosing(ctx *actor.ReceiveContext) {
 	r.cancelSchedule(ctx, schedRefStartGame)
 	r.round++
-	if r.round > r.maxRounds {
+	if r.round > r.settings.Rounds {
 		r.enterGameOver(ctx)
 		return
 	}
 
+	r.balanceTeams(ctx)
 	r.drawerID = r.pickNextDrawer()
-	r.wordChoices = pickWords(WordChoices)
+	r.wordChoices = pickWords(r.words, r.usedWords, r.settings.WordChoices)
 	r.word = ""
+	r.hints, r.revealed = nil, 0
 	r.timeLeft = ChooseSeconds
 	r.guessed = make(map[string]bool)
-	r.strokes = r.strokes[:0]
+	r.strokes = nil
 
 	// StateEvent before WordChoicesEvent: the client clears any stale
 	// overlay (e.g. the gameOver winner reveal after a restart) on
@@ -446,6 +540,12 @@ func (r *RoomActor) choosingBehavior(ctx *actor.ReceiveContext) {
 		r.maybeAbortRound(ctx)
 
 	case *PlayerInput:
+		if handled, kicked := r.moderate(ctx, msg, PhaseChoosing); handled {
+			if kicked {
+				r.maybeAbortRound(ctx)
+			}
+			return
+		}
 		switch msg.In.Type {
 		case InTypePickWord:
 			if msg.PlayerID != r.drawerID || !r.isValidChoice(msg.In.Word) {
@@ -469,7 +569,7 @@ func (r *RoomActor) choosingBehavior(ctx *actor.ReceiveContext) {
 		r.enterDrawing(ctx)
 
 	default:
-		ctx.Unhandled()
+		r.unhandled(ctx)
 	}
 }
 
@@ -478,7 +578,10 @@ func (r *RoomActor) isValidChoice(word string) bool {
 }
 
 func (r *RoomActor) enterDrawing(ctx *actor.ReceiveContext) {
-	r.timeLeft = DrawSeconds
+	r.timeLeft = r.settings.DrawSeconds
+	r.drawStart = time.Now()
+	r.hints, r.revealed = hintOrder(r.word, r.settings.Hints), 0
+	r.reports, r.takenDown = make(map[string]bool), false
 	// Send the secret to the drawer only; others see only the mask.
 	r.publish(ctx, &SecretWordEvent{For: r.drawerID, Word: r.word})
 	r.broadcastState(ctx, PhaseDrawing)
@@ -495,14 +598,10 @@ func (r *RoomActor) drawingBehavior(ctx *actor.ReceiveContext) {
 		if r.addPlayer(ctx, msg, ctx.Sender()) {
 			r.sendJoined(ctx, msg)
 			r.broadcastState(ctx, PhaseDrawing)
-			// Replay strokes so the late joiner sees the in-progress drawing.
-			for _, stroke := range r.strokes {
-				ctx.Tell(ctx.Sender(), &StrokeEvent{
-					For:    msg.PlayerID,
-					Points: stroke.Points,
-					Color:  stroke.Color,
-					Width:  stroke.Width,
-				})
+			// Replay the whole in-progress drawing in one frame so the
+			// late joiner sees it as everyone else does.
+			if visible := visibleStrokes(r.strokes); len(visible) > 0 {
+				ctx.Tell(ctx.Sender(), &StrokeEvent{For: msg.PlayerID, Data: encodeStrokes(visible)})
 			}
 		}
 
@@ -519,21 +618,36 @@ func (r *RoomActor) drawingBehavior(ctx *actor.ReceiveContext) {
 		r.maybeAbortRound(ctx)
 
 	case *PlayerInput:
+		if handled, kicked := r.moderate(ctx, msg, PhaseDrawing); handled {
+			if kicked {
+				r.maybeAbortRound(ctx)
+			}
+			return
+		}
 		switch msg.In.Type {
 		case InTypeStroke:
 			if msg.PlayerID != r.drawerID {
 				return
 			}
-			evt := &StrokeEvent{Points: msg.In.Points, Color: msg.In.Color, Width: msg.In.Width}
-			if len(r.strokes) < strokeBufferMax {
-				r.strokes = append(r.strokes, evt)
+			points := compactPoints(msg.In.Points)
+			if len(points) == 0 {
+				return
+			}
+			// Every stroke is kept — quantized and simplified it is a
+			// few bytes a point — and packed once here for the topic.
+			stroke := TimedStroke{
+				At:     time.Since(r.drawStart).Milliseconds(),
+				Points: points,
+				Color:  msg.In.Color,
+				Width:  msg.In.Width,
 			}
-			r.publish(ctx, evt)
+			r.strokes = append(r.strokes, stroke)
+			r.publish(ctx, &StrokeEvent{Data: encodeStrokes([]TimedStroke{stroke})})
 		case InTypeClear:
 			if msg.PlayerID != r.drawerID {
 				return
 			}
-			r.strokes = r.strokes[:0]
+			r.strokes = append(r.strokes, TimedStroke{At: time.Since(r.drawStart).Milliseconds(), Clear: true})
 			r.publish(ctx, &ClearEvent{})
 		case InTypeGuess:
 			r.handleGuess(ctx, msg)
@@ -547,10 +661,12 @@ func (r *RoomActor) drawingBehavior(ctx *actor.ReceiveContext) {
 		}
 		// Light-weight tick: state event is small (≤ a few hundred bytes
 		// even with 8 players); 1 Hz is fine for the countdown display.
+		// It also carries any hint letter that just came due.
+		r.revealDueHints()
 		r.broadcastState(ctx, PhaseDrawing)
 
 	default:
-		ctx.Unhandled()
+		r.unhandled(ctx)
 	}
 }
 
@@ -560,22 +676,28 @@ func (r *RoomActor) handleGuess(ctx *actor.ReceiveContext, msg *PlayerInput) {
 		return
 	}
 
-	if msg.PlayerID == r.drawerID {
-		return // drawer can't guess
-	}
-
-	if r.guessed[msg.PlayerID] {
-		return // already won this round
+	if !r.canGuess(player) || r.guessed[msg.PlayerID] {
+		// The drawer (and in team mode their team) can't guess, and a
+		// correct guesser already has the word: all may chat, but not
+		// spell it out for those still guessing.
+		r.chatKnowingWord(ctx, msg.PlayerID, msg.In.Text)
+		return
 	}
 
-	guess := strings.ToLower(strings.TrimSpace(msg.In.Text))
+	guess := normalizeGuess(r.language, msg.In.Text)
 	if guess == "" {
 		return
 	}
 
-	if guess != strings.ToLower(r.word) {
+	if word := normalizeGuess(r.language, r.word); guess != word {
+		// A near miss stays between the room and the guesser — shown as
+		// chat it would hand everyone else the word.
+		if editDistance(guess, word) <= closeGuessDistance(word) {
+			r.publish(ctx, &CloseGuessEvent{For: player.id, Guess: msg.In.Text})
+			return
+		}
 		// Wrong guess goes out as ordinary chat so others can see it.
-		r.publish(ctx, &ChatEvent{From: player.name, Text: msg.In.Text})
+		r.chat(ctx, player.id, msg.In.Text)
 		return
 	}
 
@@ -591,22 +713,23 @@ func (r *RoomActor) handleGuess(ctx *actor.ReceiveContext, msg *PlayerInput) {
 		r.publish(ctx, &ScoreEvent{PlayerID: drawer.id, Delta: DrawerBonus, Total: drawer.score})
 	}
 
-	// If every non-drawer has guessed, end the round early.
-	if r.allNonDrawersGuessed() {
+	// If everyone who can guess has, end the round early.
+	if r.allGuessersGuessed() {
 		r.enterRoundOver(ctx)
 	}
 }
 
 func (r *RoomActor) scoreForCorrectGuess() int {
-	// Linear decay from BaseScore at t=Draw down to MinGuessScore at t=0.
-	pct := float64(r.timeLeft) / float64(DrawSeconds)
+	// Linear decay from BaseScore at t=Draw down to MinGuessScore at t=0,
+	// less HintPenalty for every letter the guesser was shown.
+	pct := float64(r.timeLeft) / float64(r.settings.DrawSeconds)
 	score := int(float64(BaseScore)*pct + float64(MinGuessScore)*(1-pct))
-	return max(score, MinGuessScore)
+	return max(score-HintPenalty*r.revealed, MinGuessScore)
 }
 
-func (r *RoomActor) allNonDrawersGuessed() bool {
+func (r *RoomActor) allGuessersGuessed() bool {
 	for _, player := range r.players {
-		if player.id == r.drawerID {
+		if !r.canGuess(player) {
 			continue
 		}
 
@@ -627,6 +750,7 @@ func (r *RoomActor) enterRoundOver(ctx *actor.ReceiveContext) {
 	r.cancelSchedule(ctx, schedRefCountdown)
 	r.cancelSchedule(ctx, schedRefChoose)
 
+	r.archiveDrawing(ctx)
 	r.publish(ctx, &RoundOverEvent{Word: r.word, Scores: r.scoreEntries()})
 	r.broadcastState(ctx, PhaseRoundOver)
 	r.schedule(ctx, &nextRound{}, RoundOverSecs*time.Second, schedRefRoundEnd)
@@ -654,8 +778,11 @@ func (r *RoomActor) roundOverBehavior(ctx *actor.ReceiveContext) {
 		r.maybeShutdown(ctx)
 
 	case *PlayerInput:
+		if handled, _ := r.moderate(ctx, msg, PhaseRoundOver); handled {
+			return
+		}
 		if msg.In.Type == InTypeGuess {
-			r.publish(ctx, &ChatEvent{From: r.nameFor(msg.PlayerID), Text: msg.In.Text})
+			r.chat(ctx, msg.PlayerID, msg.In.Text)
 		}
 
 	case *nextRound:
@@ -667,7 +794,7 @@ func (r *RoomActor) roundOverBehavior(ctx *actor.ReceiveContext) {
 		r.enterChoosing(ctx)
 
 	default:
-		ctx.Unhandled()
+		r.unhandled(ctx)
 	}
 }
 
@@ -675,7 +802,7 @@ func (r *RoomActor) enterGameOver(ctx *actor.ReceiveContext) {
 	r.cancelSchedule(ctx, schedRefCountdown)
 	r.cancelSchedule(ctx, schedRefRoundEnd)
 
-	winnerID, winnerName := r.computeWinner()
+	winnerID, winnerName, winnerTeam := r.computeWinner()
 
 	// Provisional GameOverEvent — leaderboard may be stale by a few ms
 	// while the win is being persisted across the cluster. A follow-up
@@ -683,14 +810,17 @@ func (r *RoomActor) enterGameOver(ctx *actor.ReceiveContext) {
 	r.publish(ctx, &GameOverEvent{
 		WinnerID:   winnerID,
 		WinnerName: winnerName,
+		WinnerTeam: winnerTeam,
 		Scores:     r.scoreEntries(),
+		GameNumber: r.gameNumber,
+		Drawings:   r.drawings,
 	})
 
 	// Off-actor work: increment CRDT counters + roll into profile
 	// grains + fetch the fresh leaderboard. PipeTo runs the closure on
 	// a separate goroutine and delivers the result to the actor's
 	// mailbox so we don't block the room.
-	r.recordResults(ctx, winnerID)
+	r.recordResults(ctx, winnerID, winnerTeam)
 
 	r.broadcastState(ctx, PhaseGameOver)
 	r.schedule(ctx, &shutdownRoom{}, GameOverSecs*time.Second, schedRefShutdown)
@@ -702,12 +832,15 @@ func (r *RoomActor) gameOverBehavior(ctx *actor.ReceiveContext) {
 	case []LeaderboardEntry:
 		// Fresh leaderboard arrived from the CRDT read — broadcast the
 		// final game-over with the updated standings.
-		winnerID, winnerName := r.computeWinner()
+		winnerID, winnerName, winnerTeam := r.computeWinner()
 		r.publish(ctx, &GameOverEvent{
 			WinnerID:    winnerID,
 			WinnerName:  winnerName,
+			WinnerTeam:  winnerTeam,
 			Scores:      r.scoreEntries(),
 			Leaderboard: msg,
+			GameNumber:  r.gameNumber,
+			Drawings:    r.drawings,
 		})
 
 	case *PlayerHello:
@@ -718,24 +851,31 @@ func (r *RoomActor) gameOverBehavior(ctx *actor.ReceiveContext) {
 		}
 
 	case *GoodbyePlayer:
-		r.removePlayerByName(msg.SessionName)
+		if r.removePlayerByName(msg.SessionName) {
+			r.broadcastState(ctx, PhaseGameOver)
+		}
 
 	case *actor.Terminated:
-		r.removePlayerByPath(msg.ActorPath())
+		if _, ok := r.removePlayerByPath(msg.ActorPath()); ok {
+			r.broadcastState(ctx, PhaseGameOver)
+		}
 
 	case *PlayerInput:
+		if handled, _ := r.moderate(ctx, msg, PhaseGameOver); handled {
+			return
+		}
 		switch msg.In.Type {
 		case InTypeRestart:
 			r.restartGame(ctx, msg.PlayerID)
 		case InTypeGuess:
-			r.publish(ctx, &ChatEvent{From: r.nameFor(msg.PlayerID), Text: msg.In.Text})
+			r.chat(ctx, msg.PlayerID, msg.In.Text)
 		}
 
 	case *shutdownRoom:
 		ctx.Shutdown()
 
 	default:
-		ctx.Unhandled()
+		r.unhandled(ctx)
 	}
 }
 
@@ -762,11 +902,13 @@ func (r *RoomActor) restartGame(ctx *actor.ReceiveContext, byPlayerID string) {
 	}
 	r.round = 0
 	r.drawerID = ""
+	r.drawTeam = 0
 	r.word = ""
 	r.wordChoices = nil
 	r.timeLeft = 0
 	r.guessed = make(map[string]bool)
-	r.strokes = r.strokes[:0]
+	r.strokes = nil
+	r.startGame()
 
 	r.publish(ctx, &ChatEvent{
 		From: "📣",
@@ -782,17 +924,102 @@ func (r *RoomActor) restartGame(ctx *actor.ReceiveContext, byPlayerID string) {
 	r.enterChoosing(ctx)
 }
 
-func (r *RoomActor) computeWinner() (string, string) {
+// startGame opens a new game number so its rounds archive under fresh
+// keys instead of overwriting the previous game's drawings.
+func (r *RoomActor) startGame() {
+	r.gameNumber++
+	r.drawings = nil
+	clear(r.usedWords)
+}
+
+// configureWords resolves the creator's WordSettings against this
+// node's WordPackStore. An uploaded list is used on its own unless packs
+// were picked alongside it. Settings that leave fewer than WordChoices
+// words fall back to every pack of the default language.
+func (r *RoomActor) configureWords(ctx *actor.ReceiveContext, settings WordSettings) {
+	store := wordPackStoreFromExtension(ctx.ActorSystem())
+	if store == nil {
+		ctx.Logger().Errorf("room %s: word pack extension not registered — no words to draw", r.code)
+		return
+	}
+
+	language := strings.ToLower(strings.TrimSpace(settings.Language))
+	if language == "" {
+		language = DefaultLanguage
+	}
+
+	pool := cleanWordList(settings.CustomWords)
+	if len(pool) == 0 || len(settings.Packs) > 0 {
+		pool = cleanWordList(append(pool, store.Pool(language, settings.Packs, settings.Difficulty)...))
+	}
+
+	if len(pool) < WordChoices {
+		ctx.Logger().Warnf("room %s: only %d words for lang=%s packs=%v difficulty=%q — using the %s packs",
+			r.code, len(pool), language, settings.Packs, settings.Difficulty, DefaultLanguage)
+		language, pool = DefaultLanguage, store.Pool(DefaultLanguage, nil, "")
+	}
+
+	r.language = language
+	r.words = pool
+	clear(r.usedWords)
+}
+
+// archiveDrawing saves the round's stroke timeline in the drawingStore
+// and lists it for the game-over screen. The save runs in a PipeTo
+// goroutine so a store round-trip never blocks the mailbox; a round
+// aborted before anything was drawn is not archived.
+func (r *RoomActor) archiveDrawing(ctx *actor.ReceiveContext) {
+	if r.word == "" || r.takenDown || len(visibleStrokes(r.strokes)) == 0 {
+		return
+	}
+
+	code := r.code
+	drawing := &RoundDrawing{
+		Game:       r.gameNumber,
+		Round:      r.round,
+		Word:       r.word,
+		DrawerName: r.nameFor(r.drawerID),
+		Strokes:    r.strokes,
+	}
+	r.drawings = append(r.drawings, DrawingView{Round: drawing.Round, Word: drawing.Word, DrawerName: drawing.DrawerName})
+
+	store := drawingStoreFromExtension(ctx.ActorSystem())
+	if store == nil {
+		return
+	}
+
+	ctx.PipeTo(ctx.Self(), func() (any, error) {
+		saveCtx, cancel := context.WithTimeout(context.Background(), drawingStoreTimeout)
+		defer cancel()
+
+		return nil, store.Save(saveCtx, code, drawing)
+	})
+}
+
+// computeWinner picks the top scorer. In team mode the team with the
+// higher total wins and its top scorer stands in for it; a tie between
+// the teams has no winner.
+func (r *RoomActor) computeWinner() (string, string, int) {
+	team := 0
+	if r.settings.Teams {
+		if team = r.teamWinner(); team == 0 {
+			return "", "", 0
+		}
+	}
+
 	var winnerID, winnerName string
 	best := -1
 	for _, player := range r.players {
-		if player.score > best {
+		if player.team == team && player.score > best {
 			best = player.score
 			winnerID = player.id
 			winnerName = player.name
 		}
 	}
-	return winnerID, winnerName
+	if team != 0 {
+		winnerName = "Team " + teamNames[team]
+	}
+	return winnerID, winnerName, team
 }
 
 // recordResults fires off the side-effecting persistence work for game
@@ -806,14 +1033,20 @@ func (r *RoomActor) computeWinner() (string, string) {
 // internal context as soon as Receive returns. We use context.Background
 // for the off-actor work because it's fire-and-forget anyway; the
 // actor lifecycle (shutdownRoom schedule) provides the timeout instead.
-func (r *RoomActor) recordResults(ctx *actor.ReceiveContext, winnerID string) {
+//
+// In team mode every member of winnerTeam is credited with the win.
+func (r *RoomActor) recordResults(ctx *actor.ReceiveContext, winnerID string, winnerTeam int) {
 	system := ctx.ActorSystem()
 	store := profileStoreFromExtension(system)
 	leaderboard := r.leaderboard
 
+	var winners []ScoreEntry
 	for _, player := range r.players {
 		pid, name, score := player.id, player.name, player.score
-		won := pid == winnerID
+		won := pid == winnerID || (winnerTeam != 0 && player.team == winnerTeam)
+		if won {
+			winners = append(winners, ScoreEntry{PlayerID: pid, Name: name})
+		}
 
 		ctx.PipeTo(ctx.Self(), func() (any, error) {
 			bg := context.Background()
@@ -836,20 +1069,17 @@ func (r *RoomActor) recordResults(ctx *actor.ReceiveContext, winnerID string) {
 		})
 	}
 
-	if winnerID == "" {
+	if len(winners) == 0 {
 		return
 	}
 
-	winnerName := ""
-	if player := r.playerByID(winnerID); player != nil {
-		winnerName = player.name
-	}
-
 	ctx.PipeTo(ctx.Self(), func() (any, error) {
 		bg := context.Background()
 
-		if err := leaderboard.RecordWin(bg, winnerID, winnerName); err != nil {
-			return nil, err
+		for _, winner := range winners {
+			if err := leaderboard.RecordWin(bg, winner.PlayerID, winner.Name); err != nil {
+				return nil, err
+			}
 		}
 
 		return leaderboard.Top(bg, 10)
@@ -859,7 +1089,7 @@ func (r *RoomActor) recordResults(ctx *actor.ReceiveContext, winnerID string) {
 func (r *RoomActor) scoreEntries() []ScoreEntry {
 	out := make([]ScoreEntry, 0, len(r.players))
 	for _, player := range r.players {
-		out = append(out, ScoreEntry{PlayerID: player.id, Name: player.name, Score: player.score})
+		out = append(out, ScoreEntry{PlayerID: player.id, Name: player.name, Score: player.score, Team: player.team})
 	}
 	return out
 }
@@ -884,6 +1114,11 @@ func (r *RoomActor) maybeAbortRound(ctx *actor.ReceiveContext) {
 		r.enterRoundOver(ctx)
 		return
 	}
+
+	// Team mode: the whole other team may have left.
+	if !r.hasGuessers() {
+		r.enterRoundOver(ctx)
+	}
 }
 
 // maybeShutdown stops the room once its last player goes away. The
@@ -55,6 +55,15 @@ type PlayerSessionActor struct {
 	name      string
 	roomCode  string
 	topicName string
+
+	// remoteAddr is the client IP the gateway accepted the socket from.
+	// It is set by the server, unlike playerID, so a vote-kick ban on it
+	// survives the client picking a new ?id=.
+	remoteAddr string
+
+	// binaryStrokes is settled at the WebSocket handshake: true when
+	// the browser picked StrokeSubprotocol.
+	binaryStrokes bool
 }
 
 var _ actor.Actor = (*PlayerSessionActor)(nil)
@@ -76,6 +85,7 @@ func (p *PlayerSessionActor) Receive(ctx *actor.ReceiveContext) {
 			PlayerID:    p.playerID,
 			Name:        p.name,
 			SessionName: ctx.Self().Name(),
+			RemoteAddr:  p.remoteAddr,
 		})
 
 	case *actor.SubscribeAck, *actor.UnsubscribeAck:
@@ -106,6 +116,7 @@ func (p *PlayerSessionActor) encodeWS(ctx *actor.ReceiveContext) {
 	var (
 		target  string
 		payload any
+		hangUp  bool // close the socket once payload is written
 	)
 	switch event := msg.(type) {
 	case *JoinedEvent:
@@ -128,16 +139,19 @@ func (p *PlayerSessionActor) encodeWS(ctx *actor.ReceiveContext) {
 			"players":      event.Players,
 			"drawerID":     event.DrawerID,
 			"wordMask":     event.WordMask,
+			"language":     event.Language,
+			"settings":     event.Settings,
 			"youAreDrawer": event.DrawerID == p.playerID,
 		}
 	case *StrokeEvent:
-		target = event.For
-		payload = map[string]any{
-			"type":   OutTypeStroke,
-			"points": event.Points,
-			"color":  event.Color,
-			"width":  event.Width,
+		// Strokes skip the JSON payload below: they are the bulk of a
+		// round's traffic and go out in whichever encoding this
+		// browser negotiated.
+		if event.For != "" && event.For != p.playerID {
+			return
 		}
+		p.writeStrokes(ctx, event.Data)
+		return
 	case *ClearEvent:
 		target = event.For
 		payload = map[string]any{"type": OutTypeClear}
@@ -188,8 +202,24 @@ func (p *PlayerSessionActor) encodeWS(ctx *actor.ReceiveContext) {
 			"type":        OutTypeGameOver,
 			"winnerID":    event.WinnerID,
 			"winnerName":  event.WinnerName,
+			"winnerTeam":  event.WinnerTeam,
 			"scores":      event.Scores,
 			"leaderboard": event.Leaderboard,
+			"gameNumber":  event.GameNumber,
+			"drawings":    event.Drawings,
+		}
+	case *CloseGuessEvent:
+		target = event.For
+		payload = map[string]any{
+			"type":  OutTypeCloseGuess,
+			"guess": event.Guess,
+		}
+	case *KickedEvent:
+		target = event.For
+		hangUp = true
+		payload = map[string]any{
+			"type":    OutTypeKicked,
+			"message": event.Message,
 		}
 	case *ErrorEvent:
 		target = event.For
@@ -215,11 +245,59 @@ func (p *PlayerSessionActor) encodeWS(ctx *actor.ReceiveContext) {
 		return
 	}
 
+	if !p.write(ctx, websocket.MessageText, data) {
+		return
+	}
+
+	// Closing the conn unblocks the gateway's reader, which then runs
+	// the usual teardown (GoodbyePlayer, closed) for this session.
+	if hangUp {
+		_ = p.conn.Close(websocket.StatusPolicyViolation, "removed from room")
+	}
+}
+
+// writeStrokes forwards a binary stroke frame untouched when the
+// browser negotiated StrokeSubprotocol. Otherwise it falls back to one
+// JSON "stroke" message per stroke, the shape older clients expect.
+func (p *PlayerSessionActor) writeStrokes(ctx *actor.ReceiveContext, frame []byte) {
+	if p.binaryStrokes {
+		p.write(ctx, websocket.MessageBinary, frame)
+		return
+	}
+
+	strokes, err := decodeStrokes(frame)
+	if err != nil {
+		ctx.Err(err)
+		return
+	}
+	for _, stroke := range strokes {
+		data, err := json.Marshal(map[string]any{
+			"type":   OutTypeStroke,
+			"points": stroke.Points,
+			"color":  stroke.Color,
+			"width":  stroke.Width,
+		})
+		if err != nil {
+			ctx.Err(err)
+			return
+		}
+		if !p.write(ctx, websocket.MessageText, data) {
+			return
+		}
+	}
+}
+
+// write sends one frame within writeBudget. A failed write means the
+// browser is gone or too slow to keep up; the session stops and
+// reports false.
+func (p *PlayerSessionActor) write(ctx *actor.ReceiveContext, typ websocket.MessageType, data []byte) bool {
 	wctx, cancel := context.WithTimeout(ctx.Context(), writeBudget)
-	err = p.conn.Write(wctx, websocket.MessageText, data)
+	err := p.conn.Write(wctx, typ, data)
 	cancel()
 	if err != nil {
 		ctx.Logger().Infof("ws write failed for %s: %v", ctx.Self().Name(), err)
 		ctx.Shutdown()
+		return false
 	}
+	return true
 }
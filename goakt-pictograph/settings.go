@@ -0,0 +1,164 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"github.com/tochemey/goakt/v4/actor"
+)
+
+// teamNames indexes by team number; 0 is no team.
+var teamNames = [...]string{"", "Red", "Blue"}
+
+// DefaultGameSettings is what a room plays by unless its creator
+// picks otherwise.
+func DefaultGameSettings() GameSettings {
+	return GameSettings{
+		Rounds:      DefaultRounds,
+		DrawSeconds: DrawSeconds,
+		WordChoices: WordChoices,
+		Hints:       HintCount,
+	}
+}
+
+// normalized clamps every field into its bounds.
+func (s GameSettings) normalized() GameSettings {
+	s.Rounds = min(max(s.Rounds, 1), RoundsMax)
+	s.DrawSeconds = min(max(s.DrawSeconds, DrawSecondsMin), DrawSecondsMax)
+	s.WordChoices = min(max(s.WordChoices, 1), WordChoicesMax)
+	s.Hints = min(max(s.Hints, 0), HintCountMax)
+	return s
+}
+
+// configureGame applies the creator's GameSettings. Turning team mode
+// on deals the players already in the room into teams; turning it off
+// clears them.
+func (r *RoomActor) configureGame(ctx *actor.ReceiveContext, settings GameSettings) {
+	r.settings = settings.normalized()
+	for _, player := range r.players {
+		player.team = 0
+	}
+	if r.settings.Teams {
+		for _, player := range r.players {
+			player.team = r.smallerTeam()
+		}
+	}
+	ctx.Logger().Infof("room %s: %d rounds, %ds to draw, %d word choices, %d hints, teams=%t", r.code,
+		r.settings.Rounds, r.settings.DrawSeconds, r.settings.WordChoices, r.settings.Hints, r.settings.Teams)
+}
+
+// smallerTeam is the team a newcomer joins: the one with fewer
+// players, Red on a tie. Outside team mode it is 0.
+func (r *RoomActor) smallerTeam() int {
+	if !r.settings.Teams {
+		return 0
+	}
+	counts := r.teamCounts()
+	if counts[TeamBlue] < counts[TeamRed] {
+		return TeamBlue
+	}
+	return TeamRed
+}
+
+func (r *RoomActor) teamCounts() [len(teamNames)]int {
+	var counts [len(teamNames)]int
+	for _, player := range r.players {
+		counts[player.team]++
+	}
+	return counts
+}
+
+// balanceTeams evens the teams out after players leave, moving the
+// latest joiners of the bigger team across. It runs between rounds, so
+// nobody switches sides mid-drawing.
+func (r *RoomActor) balanceTeams(ctx *actor.ReceiveContext) {
+	if !r.settings.Teams {
+		return
+	}
+	for {
+		counts := r.teamCounts()
+		from, to := TeamRed, TeamBlue
+		if counts[TeamBlue] > counts[TeamRed] {
+			from, to = TeamBlue, TeamRed
+		}
+		if counts[from]-counts[to] <= 1 {
+			return
+		}
+		for i := len(r.players) - 1; i >= 0; i-- {
+			if player := r.players[i]; player.team == from {
+				player.team, player.hasDrawn = to, false
+				r.publish(ctx, &ChatEvent{From: "📣", Text: player.name + " moved to team " + teamNames[to]})
+				break
+			}
+		}
+	}
+}
+
+// nextDrawTeam is the team whose turn it is to draw: the other one
+// from last round (Red to open a game), unless it has nobody left.
+func (r *RoomActor) nextDrawTeam() int {
+	next := TeamRed
+	if r.drawTeam == TeamRed {
+		next = TeamBlue
+	}
+	if r.teamCounts()[next] == 0 {
+		next = TeamRed + TeamBlue - next
+	}
+	return next
+}
+
+// canGuess reports whether player's guesses count this round: anyone
+// but the drawer, and in team mode only the drawer's opponents.
+func (r *RoomActor) canGuess(player *roomPlayer) bool {
+	if player.id == r.drawerID {
+		return false
+	}
+	if drawer := r.playerByID(r.drawerID); r.settings.Teams && drawer != nil {
+		return player.team != drawer.team
+	}
+	return true
+}
+
+// hasGuessers reports whether anyone is left to guess this round.
+func (r *RoomActor) hasGuessers() bool {
+	for _, player := range r.players {
+		if r.canGuess(player) {
+			return true
+		}
+	}
+	return false
+}
+
+// teamWinner totals the teams' scores. A tie has no winner.
+func (r *RoomActor) teamWinner() int {
+	var totals [len(teamNames)]int
+	for _, player := range r.players {
+		totals[player.team] += player.score
+	}
+	switch {
+	case totals[TeamRed] > totals[TeamBlue]:
+		return TeamRed
+	case totals[TeamBlue] > totals[TeamRed]:
+		return TeamBlue
+	}
+	return 0
+}
@@ -0,0 +1,242 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+const (
+	// StrokeSubprotocol is the WebSocket subprotocol a browser offers
+	// to receive strokes as binary frames; JSONSubprotocol (or none at
+	// all) keeps every frame JSON. Everything but strokes is JSON
+	// either way.
+	StrokeSubprotocol = "pictograph.strokes.v1"
+	JSONSubprotocol   = "pictograph.json"
+
+	// strokeGrid is the number of steps each normalized axis is
+	// quantized to — about a quarter of a pixel on the 800×500 canvas.
+	strokeGrid = 4095
+
+	// strokeTolerance is how far, in grid steps, simplification may
+	// move the line: points closer than this to the segment joining
+	// their neighbours are dropped. Half a pixel across.
+	strokeTolerance = 2.5
+
+	// strokeFrameTag opens every binary stroke frame, so the format
+	// can grow other frame kinds without breaking old clients.
+	strokeFrameTag byte = 0x01
+)
+
+var errStrokeFrame = errors.New("malformed stroke frame")
+
+// Binary stroke frame, all integers unsigned varints unless noted:
+//
+//	tag (1 byte, strokeFrameTag)
+//	stroke count
+//	per stroke:
+//	  color, width, point count
+//	  first point: x, y on the strokeGrid
+//	  each later point: dx, dy as zig-zag signed varints
+//
+// A batch of a freehand line moves a few grid steps per point, so most
+// deltas fit in one byte — against ~15 bytes a point as JSON floats.
+
+// compactPoints quantizes points onto the strokeGrid and simplifies the
+// line. The ends are always kept, so consecutive batches of one line
+// still join up. What it returns round-trips through the binary frame
+// exactly, so JSON and binary sessions draw the same line.
+func compactPoints(points [][2]float64) [][2]float64 {
+	if len(points) == 0 {
+		return nil
+	}
+
+	grid := make([][2]int, 0, len(points))
+	for _, point := range points {
+		q := [2]int{quantize(point[0]), quantize(point[1])}
+		if n := len(grid); n > 0 && grid[n-1] == q {
+			continue
+		}
+		grid = append(grid, q)
+	}
+
+	keep := make([]bool, len(grid))
+	keep[0], keep[len(grid)-1] = true, true
+	simplify(grid, 0, len(grid)-1, keep)
+
+	out := make([][2]float64, 0, len(grid))
+	for i, q := range grid {
+		if keep[i] {
+			out = append(out, [2]float64{float64(q[0]) / strokeGrid, float64(q[1]) / strokeGrid})
+		}
+	}
+	return out
+}
+
+// simplify is Ramer–Douglas–Peucker over grid[first..last]: keep the
+// point farthest from the chord if it is beyond strokeTolerance and
+// recurse on both halves.
+func simplify(grid [][2]int, first, last int, keep []bool) {
+	if last-first < 2 {
+		return
+	}
+
+	ax, ay := float64(grid[first][0]), float64(grid[first][1])
+	bx, by := float64(grid[last][0]), float64(grid[last][1])
+	dx, dy := bx-ax, by-ay
+	length := math.Hypot(dx, dy)
+
+	farthest, index := 0.0, -1
+	for i := first + 1; i < last; i++ {
+		px, py := float64(grid[i][0]), float64(grid[i][1])
+		var d float64
+		if length == 0 {
+			d = math.Hypot(px-ax, py-ay)
+		} else {
+			d = math.Abs(dy*px-dx*py+bx*ay-by*ax) / length
+		}
+		if d > farthest {
+			farthest, index = d, i
+		}
+	}
+
+	if farthest <= strokeTolerance {
+		return
+	}
+	keep[index] = true
+	simplify(grid, first, index, keep)
+	simplify(grid, index, last, keep)
+}
+
+// encodeStrokes packs strokes into one binary stroke frame. Clears in
+// the slice are skipped; callers pass visibleStrokes for a replay.
+func encodeStrokes(strokes []TimedStroke) []byte {
+	count := 0
+	size := 1 + binary.MaxVarintLen32
+	for _, stroke := range strokes {
+		if !stroke.Clear {
+			count++
+			size += 3 + 2*len(stroke.Points)*2
+		}
+	}
+
+	buf := make([]byte, 0, size)
+	buf = append(buf, strokeFrameTag)
+	buf = binary.AppendUvarint(buf, uint64(count))
+	for _, stroke := range strokes {
+		if stroke.Clear {
+			continue
+		}
+		buf = binary.AppendUvarint(buf, uint64(max(stroke.Color, 0)))
+		buf = binary.AppendUvarint(buf, uint64(max(stroke.Width, 0)))
+		buf = binary.AppendUvarint(buf, uint64(len(stroke.Points)))
+
+		var px, py int
+		for i, point := range stroke.Points {
+			x, y := quantize(point[0]), quantize(point[1])
+			if i == 0 {
+				buf = binary.AppendUvarint(buf, uint64(x))
+				buf = binary.AppendUvarint(buf, uint64(y))
+			} else {
+				buf = binary.AppendVarint(buf, int64(x-px))
+				buf = binary.AppendVarint(buf, int64(y-py))
+			}
+			px, py = x, y
+		}
+	}
+	return buf
+}
+
+// decodeStrokes unpacks a binary stroke frame; sessions that speak JSON
+// use it to fall back to one "stroke" message per stroke.
+func decodeStrokes(data []byte) ([]TimedStroke, error) {
+	if len(data) == 0 || data[0] != strokeFrameTag {
+		return nil, errStrokeFrame
+	}
+	r := frameReader{data: data[1:]}
+
+	count := r.uvarint()
+	// Every stroke takes at least three bytes; this bounds the
+	// allocation before trusting count.
+	if count > uint64(len(r.data)) {
+		return nil, errStrokeFrame
+	}
+
+	strokes := make([]TimedStroke, 0, count)
+	for range count {
+		color, width, n := r.uvarint(), r.uvarint(), r.uvarint()
+		if n > uint64(len(r.data)) {
+			return nil, errStrokeFrame
+		}
+
+		points := make([][2]float64, 0, n)
+		var x, y int64
+		for i := range n {
+			if i == 0 {
+				x, y = int64(r.uvarint()), int64(r.uvarint())
+			} else {
+				x, y = x+r.varint(), y+r.varint()
+			}
+			points = append(points, [2]float64{float64(x) / strokeGrid, float64(y) / strokeGrid})
+		}
+		strokes = append(strokes, TimedStroke{Points: points, Color: int(color), Width: int(width)})
+	}
+
+	if r.err != nil || len(r.data) != 0 {
+		return nil, errStrokeFrame
+	}
+	return strokes, nil
+}
+
+func quantize(v float64) int {
+	return int(math.Round(clamp01(v) * strokeGrid))
+}
+
+// frameReader reads varints off a frame, remembering the first error
+// so decodeStrokes checks once at the end.
+type frameReader struct {
+	data []byte
+	err  error
+}
+
+func (r *frameReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err, r.data = errStrokeFrame, nil
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *frameReader) varint() int64 {
+	v, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.err, r.data = errStrokeFrame, nil
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
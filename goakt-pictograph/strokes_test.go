@@ -0,0 +1,236 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// onGrid maps grid steps to the normalized coordinates compactPoints
+// returns, so test strokes survive the round trip exactly.
+func onGrid(x, y int) [2]float64 {
+	return [2]float64{float64(x) / strokeGrid, float64(y) / strokeGrid}
+}
+
+func TestStrokeFrameRoundTrip(t *testing.T) {
+	strokes := []TimedStroke{
+		// Deltas of both signs, small and large, to cover zig-zag
+		// encoding and multi-byte varints.
+		{Points: [][2]float64{onGrid(2000, 2000), onGrid(2001, 1999), onGrid(1937, 2064), onGrid(0, 4095), onGrid(4095, 0)}, Color: 3, Width: 2},
+		{Points: nil, Color: 1, Width: 1},
+		{Points: [][2]float64{onGrid(17, 4000)}, Color: 0, Width: 8},
+	}
+
+	got, err := decodeStrokes(encodeStrokes(strokes))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != len(strokes) {
+		t.Fatalf("got %d strokes want %d", len(got), len(strokes))
+	}
+	for i, stroke := range strokes {
+		if got[i].Color != stroke.Color || got[i].Width != stroke.Width {
+			t.Errorf("stroke %d: color/width %d/%d want %d/%d", i, got[i].Color, got[i].Width, stroke.Color, stroke.Width)
+		}
+		if len(got[i].Points) != len(stroke.Points) {
+			t.Errorf("stroke %d: %d points want %d", i, len(got[i].Points), len(stroke.Points))
+			continue
+		}
+		for j := range stroke.Points {
+			if got[i].Points[j] != stroke.Points[j] {
+				t.Errorf("stroke %d point %d: %v want %v", i, j, got[i].Points[j], stroke.Points[j])
+			}
+		}
+	}
+}
+
+func TestStrokeFrameSkipsClears(t *testing.T) {
+	strokes := []TimedStroke{
+		{Points: [][2]float64{onGrid(1, 1)}, Color: 1, Width: 1},
+		{Clear: true},
+		{Points: [][2]float64{onGrid(2, 2)}, Color: 2, Width: 1},
+	}
+
+	got, err := decodeStrokes(encodeStrokes(strokes))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != 2 || got[0].Color != 1 || got[1].Color != 2 {
+		t.Errorf("got %+v want the two non-clear strokes", got)
+	}
+}
+
+func TestStrokeFrameEmpty(t *testing.T) {
+	got, err := decodeStrokes(encodeStrokes(nil))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("got %d strokes want 0", len(got))
+	}
+}
+
+func TestDecodeStrokesRejectsMalformedFrames(t *testing.T) {
+	frame := encodeStrokes([]TimedStroke{
+		{Points: [][2]float64{onGrid(100, 200), onGrid(300, 50), onGrid(4000, 4000)}, Color: 2, Width: 3},
+	})
+
+	cases := map[string][]byte{
+		"empty":          nil,
+		"wrong tag":      append([]byte{0x02}, frame[1:]...),
+		"trailing bytes": append(append([]byte(nil), frame...), 0x00),
+		"huge count":     binary.AppendUvarint([]byte{strokeFrameTag}, 1<<40),
+		"huge points":    binary.AppendUvarint([]byte{strokeFrameTag, 1, 0, 0}, 1<<40),
+		"bad varint":     {strokeFrameTag, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		"garbage":        {strokeFrameTag, 0x05, 0x80},
+	}
+	for i := 1; i < len(frame); i++ {
+		cases[fmt.Sprintf("truncated to %d bytes", i)] = frame[:i]
+	}
+
+	for name, data := range cases {
+		if _, err := decodeStrokes(data); !errors.Is(err, errStrokeFrame) {
+			t.Errorf("%s: err = %v want errStrokeFrame", name, err)
+		}
+	}
+}
+
+func TestCompactPoints(t *testing.T) {
+	if got := compactPoints(nil); got != nil {
+		t.Errorf("no points: got %v want nil", got)
+	}
+
+	one := compactPoints([][2]float64{{0.5, 0.25}})
+	if len(one) != 1 || one[0] != onGrid(2048, 1024) {
+		t.Errorf("one point: got %v", one)
+	}
+
+	// Off-canvas points are clamped; repeats on the same grid step
+	// collapse; the middle of a straight line is dropped but its ends
+	// are kept.
+	line := compactPoints([][2]float64{{-1, 0}, {0.0001, 0}, {0.5, 0.5}, {2, 2}})
+	if want := [][2]float64{onGrid(0, 0), onGrid(4095, 4095)}; !reflect.DeepEqual(line, want) {
+		t.Errorf("line: got %v want %v", line, want)
+	}
+
+	// A corner is farther than strokeTolerance from the chord, so it
+	// stays.
+	corner := compactPoints([][2]float64{{0, 0}, {0.5, 0}, {0.5, 0.5}})
+	if len(corner) != 3 {
+		t.Errorf("corner: got %v want 3 points", corner)
+	}
+}
+
+// browserDecode mirrors decodeStrokeFrame in web/main.ts, which reads
+// the frame with plain JavaScript number arithmetic.
+func browserDecode(frame []byte) [][][2]float64 {
+	pos := 1
+	uvarint := func() float64 {
+		v, mul := 0.0, 1.0
+		for pos < len(frame) {
+			b := frame[pos]
+			pos++
+			v += float64(b&0x7f) * mul
+			if b < 0x80 {
+				return v
+			}
+			mul *= 128
+		}
+		return v
+	}
+	varint := func() float64 {
+		u := uvarint()
+		if int64(u)%2 == 0 {
+			return u / 2
+		}
+		return -(u + 1) / 2
+	}
+
+	var out [][][2]float64
+	count := uvarint()
+	for s := 0.0; s < count && pos < len(frame); s++ {
+		uvarint()
+		uvarint()
+		n := uvarint()
+		var points [][2]float64
+		var x, y float64
+		for i := 0.0; i < n; i++ {
+			if i == 0 {
+				x, y = uvarint(), uvarint()
+			} else {
+				x, y = x+varint(), y+varint()
+			}
+			points = append(points, [2]float64{x / strokeGrid, y / strokeGrid})
+		}
+		out = append(out, points)
+	}
+	return out
+}
+
+// TestJSONAndBinarySessionsDrawTheSameLine follows one stroke from the
+// drawer's input through the room to both kinds of session: a binary
+// session's browser decodes the frame itself, a JSON session gets the
+// points writeStrokes marshals from decodeStrokes.
+func TestJSONAndBinarySessionsDrawTheSameLine(t *testing.T) {
+	input := [][2]float64{
+		{0.1, 0.1}, {0.1003, 0.1001}, {0.12, 0.15}, {0.2, 0.31},
+		{0.21, 0.3}, {0.5, 0.9}, {0.49, 0.91}, {0.7331, 0.1234},
+	}
+	points := compactPoints(input)
+	frame := encodeStrokes([]TimedStroke{{Points: points, Color: 4, Width: 2}})
+
+	decoded, err := decodeStrokes(frame)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	jsonPoints, err := json.Marshal(decoded[0].Points)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	browser := browserDecode(frame)
+	if len(browser) != 1 {
+		t.Fatalf("browser decoded %d strokes want 1", len(browser))
+	}
+	binaryPoints, err := json.Marshal(browser[0])
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	kept, err := json.Marshal(points)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	if string(jsonPoints) != string(binaryPoints) {
+		t.Errorf("JSON session got %s, binary session %s", jsonPoints, binaryPoints)
+	}
+	if string(jsonPoints) != string(kept) {
+		t.Errorf("sessions got %s, room kept %s", jsonPoints, kept)
+	}
+}
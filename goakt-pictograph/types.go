@@ -27,6 +27,10 @@ package main
 // browsers tear down via inactivity. Stroke coordinates travel as
 // normalized [0,1] pairs so the server is resolution-agnostic; the
 // browser scales them to whatever canvas size it renders at.
+//
+// DefaultRounds, DrawSeconds, WordChoices and HintCount are only the
+// defaults: the room creator can change each within the bounds below
+// (see GameSettings).
 const (
 	MinPlayers    = 2
 	MaxPlayers    = 8
@@ -40,6 +44,24 @@ const (
 	DrawerBonus   = 50
 	MinGuessScore = 10
 
+	// HintCount is how many letters of the word are revealed to guessers
+	// over a drawing phase, evenly spaced in time — capped at half the
+	// word's letters. Each revealed letter costs a correct guess
+	// HintPenalty points.
+	HintCount   = 2
+	HintPenalty = 15
+
+	// Bounds for a room's GameSettings.
+	RoundsMax      = 10
+	DrawSecondsMin = 30
+	DrawSecondsMax = 240
+	WordChoicesMax = 5
+	HintCountMax   = 5
+
+	// Teams in team mode; a player's Team is 0 outside it.
+	TeamRed  = 1
+	TeamBlue = 2
+
 	// LobbyActorName is the cluster-singleton name of the LobbyActor.
 	LobbyActorName = "lobby"
 
@@ -56,6 +78,10 @@ const (
 	// browser sees in the URL.
 	RoomTopicPrefix = "room."
 
+	// LobbyTopic is the pub/sub topic every room subscribes to, so a
+	// freshly started lobby can ask them all to report in.
+	LobbyTopic = "pictograph.lobby"
+
 	// GrainPrefix scopes the player-profile grain names so they can't
 	// collide with anything else activated in the system.
 	GrainPrefix = "pictograph.profile."
@@ -85,6 +111,8 @@ const (
 	InTypeGuess    = "guess"
 	InTypePickWord = "pickWord"
 	InTypeRestart  = "restart"
+	InTypeVoteKick = "voteKick"
+	InTypeReport   = "report"
 )
 
 type WSIn struct {
@@ -94,6 +122,7 @@ type WSIn struct {
 	Width  int          `json:"width,omitempty"`
 	Word   string       `json:"word,omitempty"`
 	Text   string       `json:"text,omitempty"`
+	Target string       `json:"target,omitempty"` // voteKick: the player id to remove
 }
 
 // Outbound events (session → browser). Each is its own Go type so
@@ -113,6 +142,8 @@ const (
 	OutTypeSecretWord  = "secretWord"
 	OutTypeRoundOver   = "roundOver"
 	OutTypeGameOver    = "gameOver"
+	OutTypeCloseGuess  = "closeGuess"
+	OutTypeKicked      = "kicked"
 	OutTypeError       = "error"
 )
 
@@ -122,6 +153,7 @@ type PlayerView struct {
 	ID    string `json:"id"`
 	Name  string `json:"name"`
 	Score int    `json:"score"`
+	Team  int    `json:"team,omitempty"`
 }
 
 // ScoreEntry is one row of an end-of-round / end-of-game scoreboard.
@@ -129,6 +161,7 @@ type ScoreEntry struct {
 	PlayerID string `json:"playerID"`
 	Name     string `json:"name"`
 	Score    int    `json:"score"`
+	Team     int    `json:"team,omitempty"`
 }
 
 // LeaderboardEntry is one row of the cluster-wide CRDT leaderboard.
@@ -166,14 +199,18 @@ type StateEvent struct {
 	Players      []PlayerView `json:"players"`
 	DrawerID     string       `json:"drawerID"`
 	WordMask     string       `json:"wordMask"`
+	Language     string       `json:"language"`
+	Settings     GameSettings `json:"settings"`
 	YouAreDrawer bool         `json:"-"` // session fills this in per-recipient
 }
 
+// StrokeEvent carries one or more strokes as a binary stroke frame
+// (see strokes.go), packed once by the room. Sessions forward it as-is
+// to browsers that negotiated StrokeSubprotocol and unpack it to JSON
+// for the rest.
 type StrokeEvent struct {
-	For    string       `json:"-"`
-	Points [][2]float64 `json:"points"`
-	Color  int          `json:"color"`
-	Width  int          `json:"width"`
+	For  string `json:"-"`
+	Data []byte `json:"data"`
 }
 
 type ClearEvent struct {
@@ -215,12 +252,61 @@ type RoundOverEvent struct {
 	Scores []ScoreEntry `json:"scores"`
 }
 
+// GameOverEvent announces the final scores. Drawings lists the rounds
+// of game GameNumber that can be fetched from /drawings/<room>/....
+// In team mode WinnerTeam is the winning team, WinnerID its top scorer
+// and WinnerName the team's name; a tie leaves all three empty.
 type GameOverEvent struct {
 	For         string             `json:"-"`
 	WinnerID    string             `json:"winnerID"`
 	WinnerName  string             `json:"winnerName"`
+	WinnerTeam  int                `json:"winnerTeam,omitempty"`
 	Scores      []ScoreEntry       `json:"scores"`
 	Leaderboard []LeaderboardEntry `json:"leaderboard"`
+	GameNumber  int                `json:"gameNumber"`
+	Drawings    []DrawingView      `json:"drawings"`
+}
+
+// TimedStroke is one entry of a round's drawing timeline. At is the
+// offset in milliseconds from the start of the drawing phase; Clear
+// marks a canvas wipe and carries no points.
+type TimedStroke struct {
+	At     int64
+	Points [][2]float64
+	Color  int
+	Width  int
+	Clear  bool
+}
+
+// RoundDrawing is everything the drawer put on the canvas in one round,
+// in order. It is what the drawingStore keeps and render.go draws.
+type RoundDrawing struct {
+	Game       int
+	Round      int
+	Word       string
+	DrawerName string
+	Strokes    []TimedStroke
+}
+
+// DrawingView is one row of the GameOverEvent's drawing list.
+type DrawingView struct {
+	Round      int    `json:"round"`
+	Word       string `json:"word"`
+	DrawerName string `json:"drawerName"`
+}
+
+// CloseGuessEvent tells one guesser their guess was a letter or two
+// off. The guess is not shown to anyone else: it gives the word away.
+type CloseGuessEvent struct {
+	For   string `json:"-"`
+	Guess string `json:"guess"`
+}
+
+// KickedEvent tells a player they have been removed from the room; the
+// session hangs up on receiving it.
+type KickedEvent struct {
+	For     string `json:"-"`
+	Message string `json:"message"`
 }
 
 type ErrorEvent struct {
@@ -230,11 +316,85 @@ type ErrorEvent struct {
 
 // JoinOrCreate is the gateway's Ask to the LobbyActor singleton: "find
 // or create room <Room> and tell me where it lives." Empty Room means
-// "create a new one with a fresh code."
+// "create a new one with a fresh code." Words only matters when the
+// room is created; joining an existing room ignores it.
+//
+// QuickPlay (with no Room) asks for the fullest joinable public room,
+// in Words.Language if one is given; with none to join, the lobby
+// creates a public room. Public lists a newly created room in the
+// room browser.
 type JoinOrCreate struct {
 	Room       string
 	PlayerID   string
 	PlayerName string
+	Words      WordSettings
+	Game       GameSettings
+	Public     bool
+	QuickPlay  bool
+}
+
+// WordSettings is the room creator's choice of words: the packs of one
+// language (all of them when Packs is empty), optionally narrowed to a
+// difficulty, plus an uploaded list. The gateway resolves an uploaded
+// list's id to its words, since the room may live on another node.
+type WordSettings struct {
+	Language    string
+	Packs       []string
+	Difficulty  string
+	CustomWords []string
+}
+
+// GameSettings are the room creator's rules. The gateway starts from
+// DefaultGameSettings and the room clamps every field into range, so a
+// zero Hints really means no hints. In team mode the players split
+// into two teams that take turns drawing, and only the drawer's
+// opponents can score by guessing.
+type GameSettings struct {
+	Rounds      int  `json:"rounds"`
+	DrawSeconds int  `json:"drawSeconds"`
+	WordChoices int  `json:"wordChoices"`
+	Hints       int  `json:"hints"`
+	Teams       bool `json:"teams"`
+}
+
+// ConfigureRoom is the lobby's first message to a room it has just
+// spawned, carrying the creator's settings.
+type ConfigureRoom struct {
+	Words  WordSettings
+	Game   GameSettings
+	Public bool
+}
+
+// RoomStatus is a room's report to the LobbyActor: sent whenever its
+// phase, player count or settings change, again on RefreshRoomStatus,
+// and with Stopped set from PostStop. The lobby's room index is built
+// from these reports alone, which is what lets a new lobby rebuild it
+// after a singleton failover.
+type RoomStatus struct {
+	Code       string       `json:"code"`
+	Name       string       `json:"name"`
+	Phase      string       `json:"phase"`
+	Players    int          `json:"players"`
+	MaxPlayers int          `json:"maxPlayers"`
+	Language   string       `json:"language"`
+	Settings   GameSettings `json:"settings"`
+	Public     bool         `json:"public"`
+	Joinable   bool         `json:"joinable"`
+	Stopped    bool         `json:"stopped"`
+}
+
+// RefreshRoomStatus is published on LobbyTopic by a starting lobby;
+// every room answers with its RoomStatus.
+type RefreshRoomStatus struct{}
+
+// ListRooms asks the lobby for its index of public rooms, optionally
+// narrowed to one language. The reply is a RoomList.
+type ListRooms struct {
+	Language string
+}
+
+type RoomList struct {
+	Rooms []RoomStatus
 }
 
 // JoinOrCreateResult is the LobbyActor's reply. RoomName is the
@@ -253,6 +413,7 @@ type PlayerHello struct {
 	PlayerID    string
 	Name        string
 	SessionName string // cluster-stable identity used in GoodbyePlayer
+	RemoteAddr  string // client IP the gateway saw; vote-kick bans key on it
 }
 
 // GoodbyePlayer is the fast-path cleanup signal from the gateway on a
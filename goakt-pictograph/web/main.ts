@@ -26,16 +26,22 @@
 // declared in ../types.go — there's no codegen; the protocol is small
 // enough to keep in sync by hand.
 
-interface PlayerView { id: string; name: string; score: number; }
-interface ScoreEntry { playerID: string; name: string; score: number; }
+interface PlayerView { id: string; name: string; score: number; team?: number; }
+interface ScoreEntry { playerID: string; name: string; score: number; team?: number; }
+interface GameSettings { rounds: number; drawSeconds: number; wordChoices: number; hints: number; teams: boolean; }
 interface LeaderboardEntry { playerID: string; name: string; wins: number; }
 interface ProfileView { playerID: string; name: string; gamesPlayed: number; wins: number; totalScore: number; }
+interface DrawingView { round: number; word: string; drawerName: string; }
+interface WordPack { id: string; name: string; language: string; category: string; difficulty: string; }
+interface RoomStatus { code: string; phase: string; players: number; maxPlayers: number; language: string;
+                       settings: GameSettings; joinable: boolean; }
 
 // All event types prefixed `Msg` so they don't collide with DOM globals
 // (ErrorEvent / MessageEvent / etc. — TS would otherwise try to merge them).
 interface MsgJoined       { type: "joined"; room: string; playerID: string; profile: ProfileView; leaderboard: LeaderboardEntry[]; }
 interface MsgState        { type: "state"; phase: string; round: number; maxRounds: number; timeLeft: number;
-                            players: PlayerView[]; drawerID: string; wordMask: string; youAreDrawer: boolean; }
+                            players: PlayerView[]; drawerID: string; wordMask: string; language: string;
+                            settings: GameSettings; youAreDrawer: boolean; }
 interface MsgStroke       { type: "stroke"; points: [number, number][]; color: number; width: number; }
 interface MsgClear        { type: "clear"; }
 interface MsgChat         { type: "chat"; from: string; text: string; }
@@ -44,12 +50,15 @@ interface MsgScore        { type: "score"; playerID: string; delta: number; tota
 interface MsgWordChoices  { type: "wordChoices"; choices: string[]; }
 interface MsgSecretWord   { type: "secretWord"; word: string; }
 interface MsgRoundOver    { type: "roundOver"; word: string; scores: ScoreEntry[]; }
-interface MsgGameOver     { type: "gameOver"; winnerID: string; winnerName: string; scores: ScoreEntry[]; leaderboard: LeaderboardEntry[]; }
+interface MsgGameOver     { type: "gameOver"; winnerID: string; winnerName: string; winnerTeam?: number; scores: ScoreEntry[]; leaderboard: LeaderboardEntry[];
+                            gameNumber: number; drawings: DrawingView[] | null; }
+interface MsgCloseGuess   { type: "closeGuess"; guess: string; }
+interface MsgKicked       { type: "kicked"; message: string; }
 interface MsgError        { type: "error"; message: string; }
 
 type ServerMsg =
   MsgJoined | MsgState | MsgStroke | MsgClear | MsgChat | MsgGuessed
-  | MsgScore | MsgWordChoices | MsgSecretWord | MsgRoundOver | MsgGameOver | MsgError;
+  | MsgScore | MsgWordChoices | MsgSecretWord | MsgRoundOver | MsgGameOver | MsgCloseGuess | MsgKicked | MsgError;
 
 // Palette must mirror the index ↔ color expected by the server (which
 // just round-trips them). Index 0..7 used in StrokeEvent.color.
@@ -77,17 +86,20 @@ const toolbarEl    = document.getElementById("toolbar")!;
 const overlay      = document.getElementById("overlayLayer")!;
 const widthRange   = document.getElementById("widthRange") as HTMLInputElement;
 const clearBtn     = document.getElementById("clearBtn") as HTMLButtonElement;
+const reportBtn    = document.getElementById("reportBtn") as HTMLButtonElement;
 const swatches     = Array.from(toolbarEl.querySelectorAll(".swatch")) as HTMLElement[];
 const roleLabel    = document.getElementById("role")!;
 const toastsEl     = document.getElementById("toasts")!;
 
 let myPlayerID = "";
+let roomCode   = "";
 let isDrawer   = false;
 let secretWord = "";
 let curColor   = 0;
 let curWidth   = parseInt(widthRange.value, 10);
 let strokeBuf: [number, number][] = [];
 let drawing    = false;
+let kicked     = false;
 
 // Round-level state tracked so we can detect transitions and drive
 // the UI accordingly (round-start toast, "you guessed!" lock-out on
@@ -96,10 +108,18 @@ let prevPhase    = "";
 let prevDrawerID = "";
 let prevRound    = 0;
 let iHaveGuessed = false;
+let prevMask     = "";
+// onDrawingTeam is set in team mode while a teammate holds the pen:
+// our guesses don't count, so the input turns into plain chat.
+let onDrawingTeam = false;
+
+// Team numbers mirror TeamRed / TeamBlue in types.go; 0 is no team.
+const TEAM_NAMES = ["", "Red", "Blue"];
 
 // Phase durations the server uses (mirrored from types.go). We use
 // these to scale the progress bar — the wire protocol only sends the
-// remaining seconds, so we need the max to compute a percentage.
+// remaining seconds, so we need the max to compute a percentage. The
+// drawing time is per room and is updated from each StateEvent.
 const PHASE_MAX_SECS: Record<string, number> = {
   choosing:  15,
   drawing:   80,
@@ -134,20 +154,92 @@ function cryptoRandom(): string {
 
 // ─── WebSocket ──────────────────────────────────────────────────────────
 
-const wsURL = new URL("ws", location.href);
-wsURL.protocol = location.protocol === "https:" ? "wss:" : "ws:";
-wsURL.searchParams.set("name", displayName);
-wsURL.searchParams.set("id", userID);
-if (room) wsURL.searchParams.set("room", room);
+let ws: WebSocket | null = null;
+
+// connect opens the game socket. settings only matter when this
+// connection creates the room (no ?room=): they carry the word choice
+// from the create-room form as lang / difficulty / packs.
+function connect(settings: Record<string, string> = {}) {
+  const wsURL = new URL("ws", location.href);
+  wsURL.protocol = location.protocol === "https:" ? "wss:" : "ws:";
+  wsURL.searchParams.set("name", displayName);
+  wsURL.searchParams.set("id", userID);
+  if (room) wsURL.searchParams.set("room", room);
+  for (const [k, v] of Object.entries(settings)) {
+    if (v) wsURL.searchParams.set(k, v);
+  }
+
+  // Offering the stroke subprotocol asks the server for strokes as
+  // binary frames (see decodeStrokeFrame); everything else stays JSON.
+  ws = new WebSocket(wsURL.toString(), [STROKE_SUBPROTOCOL, "pictograph.json"]);
+  ws.binaryType = "arraybuffer";
+  ws.addEventListener("open",    () => setBanner("waiting", "connecting…"));
+  ws.addEventListener("close",   () => { if (!kicked) setBanner("waiting", "disconnected"); });
+  ws.addEventListener("error",   () => setBanner("waiting", "connection error"));
+  ws.addEventListener("message", (e) => {
+    if (e.data instanceof ArrayBuffer) {
+      for (const s of decodeStrokeFrame(e.data)) drawStroke(s.points, s.color, s.width);
+      return;
+    }
+    onMessage(JSON.parse(e.data) as ServerMsg);
+  });
+}
+
+// ─── Binary strokes ─────────────────────────────────────────────────────
+
+// Mirrors strokes.go: a tag byte, then unsigned LEB128 varints — the
+// stroke count, and per stroke color, width and point count, the first
+// point on a STROKE_GRID grid and zig-zag deltas for the rest.
+const STROKE_SUBPROTOCOL = "pictograph.strokes.v1";
+const STROKE_FRAME_TAG   = 0x01;
+const STROKE_GRID        = 4095;
+
+interface DecodedStroke { points: [number, number][]; color: number; width: number; }
+
+function decodeStrokeFrame(buf: ArrayBuffer): DecodedStroke[] {
+  const bytes = new Uint8Array(buf);
+  if (bytes[0] !== STROKE_FRAME_TAG) return [];
+  let pos = 1;
+  const uvarint = (): number => {
+    let v = 0, mul = 1;
+    while (pos < bytes.length) {
+      const b = bytes[pos++];
+      v += (b & 0x7f) * mul;
+      if (b < 0x80) return v;
+      mul *= 128;
+    }
+    return v;
+  };
+  const varint = (): number => {
+    const u = uvarint();
+    return u % 2 === 0 ? u / 2 : -(u + 1) / 2;
+  };
 
-const ws = new WebSocket(wsURL.toString());
-ws.addEventListener("open",    () => setBanner("waiting", "connecting…"));
-ws.addEventListener("close",   () => setBanner("waiting", "disconnected"));
-ws.addEventListener("error",   () => setBanner("waiting", "connection error"));
-ws.addEventListener("message", (e) => onMessage(JSON.parse(e.data) as ServerMsg));
+  const out: DecodedStroke[] = [];
+  const count = uvarint();
+  for (let s = 0; s < count && pos < bytes.length; s++) {
+    const color = uvarint(), width = uvarint(), n = uvarint();
+    const points: [number, number][] = [];
+    let x = 0, y = 0;
+    for (let i = 0; i < n; i++) {
+      if (i === 0) { x = uvarint(); y = uvarint(); }
+      else { x += varint(); y += varint(); }
+      points.push([x / STROKE_GRID, y / STROKE_GRID]);
+    }
+    out.push({ points, color, width });
+  }
+  return out;
+}
 
 function send(payload: object) {
-  if (ws.readyState === WebSocket.OPEN) ws.send(JSON.stringify(payload));
+  if (ws && ws.readyState === WebSocket.OPEN) ws.send(JSON.stringify(payload));
+}
+
+// Joining by code goes straight in; a new room asks for its words first.
+if (room) {
+  connect();
+} else {
+  showCreateRoom();
 }
 
 // ─── Inbound dispatch ───────────────────────────────────────────────────
@@ -164,8 +256,7 @@ function onMessage(ev: ServerMsg) {
       if (ev.playerID === myPlayerID) {
         iHaveGuessed = true;
         toast("✓ You guessed the word!", "good");
-        chatInput.disabled = true;
-        chatInput.placeholder = "you guessed!";
+        chatInput.placeholder = "you guessed! — chat, but don't give it away";
       } else {
         toast(`✓ ${ev.name} guessed the word`, "good");
       }
@@ -179,12 +270,19 @@ function onMessage(ev: ServerMsg) {
     case "secretWord":  secretWord = ev.word; wordHint.textContent = ev.word; break;
     case "roundOver":   showRoundOver(ev); break;
     case "gameOver":    showGameOver(ev); break;
+    case "closeGuess":
+      // Only we see this — the server keeps near misses out of chat.
+      addChat("~", `"${ev.guess}" is close!`, "system warn");
+      toast(`🔥 "${ev.guess}" is close!`, "warn");
+      break;
+    case "kicked":      onKicked(ev); break;
     case "error":       addChat("!", ev.message, "system bad"); break;
   }
 }
 
 function onJoined(ev: MsgJoined) {
   myPlayerID = ev.playerID;
+  roomCode = ev.room;
   roomCodeEl.textContent = ev.room;
   // Persist the room into the URL so a reload returns to the same one,
   // and so the link is shareable.
@@ -195,8 +293,24 @@ function onJoined(ev: MsgJoined) {
   addChat("·", `joined room ${ev.room} as ${displayName}`, "system");
 }
 
+// onKicked is the last frame the server sends before closing the
+// socket; the room also refuses us if we reconnect.
+function onKicked(ev: MsgKicked) {
+  kicked = true;
+  addChat("!", ev.message, "system bad");
+  setBanner("gameover", `🚫 ${ev.message}`);
+  chatInput.disabled = true;
+  chatInput.placeholder = "removed from room";
+  reportBtn.hidden = true;
+  clearOverlay();
+}
+
 function onState(ev: MsgState) {
   isDrawer = ev.youAreDrawer;
+  if (ev.settings) PHASE_MAX_SECS.drawing = ev.settings.drawSeconds;
+  const me     = ev.players.find((p) => p.id === myPlayerID);
+  const drawer = ev.players.find((p) => p.id === ev.drawerID);
+  onDrawingTeam = !!ev.settings?.teams && !isDrawer && !!me && !!drawer && me.team === drawer.team;
 
   // Phase transition: clear any stale overlay (e.g. the gameOver
   // winner reveal after Play Again jumps us back into choosing).
@@ -225,9 +339,17 @@ function onState(ev: MsgState) {
   // (only meaningful during the drawing phase — clear in others).
   if (ev.phase === "drawing") {
     wordHint.textContent = ev.youAreDrawer ? secretWord : ev.wordMask;
+    // A letter turning up in the mask is a hint the server revealed.
+    if (!ev.youAreDrawer && !iHaveGuessed && revealedLetters(ev.wordMask) > revealedLetters(prevMask)
+        && ev.wordMask.length === prevMask.length) {
+      toast("💡 A letter was revealed", "info");
+    }
+    prevMask = ev.wordMask;
   } else {
     wordHint.textContent = "";
+    prevMask = "";
   }
+  roomCodeEl.title = ev.language ? `words: ${ev.language}` : "";
 
   // Round indicator + dots
   roundInfoEl.textContent = ev.round > 0 ? `round ${ev.round}/${ev.maxRounds}` : "round —";
@@ -244,39 +366,79 @@ function onState(ev: MsgState) {
   toolbarEl.classList.toggle("disabled", !canDraw);
   canvas.classList.toggle("spectator", !canDraw);
   roleLabel.textContent = canDraw ? "you are drawing" : (iHaveGuessed ? "you guessed!" : "guessing…");
+  reportBtn.hidden = ev.phase !== "drawing" || isDrawer || kicked;
 
-  // Chat-input mode during drawing: drawer can't guess; once you've
-  // guessed correctly, the input locks until the next round.
+  // Chat-input mode during drawing: the drawer and anyone who has
+  // guessed can still chat, but the server bounces lines that give
+  // the word away.
   if (ev.phase === "drawing") {
-    chatInput.disabled = isDrawer || iHaveGuessed;
     chatInput.placeholder = isDrawer
-      ? "you're the drawer — can't guess"
-      : (iHaveGuessed ? "you guessed!" : "Type your guess and press Enter…");
+      ? "you're drawing — chat, but don't give it away"
+      : onDrawingTeam ? "your team is drawing — the other team guesses"
+      : (iHaveGuessed ? "you guessed! — chat, but don't give it away" : "Type your guess and press Enter…");
   } else {
-    chatInput.disabled = false;
     chatInput.placeholder = "Type a message…";
   }
 
   renderPlayers(ev);
 }
 
+// revealedLetters counts the letters a word mask shows — everything
+// that is neither an underscore nor a space.
+function revealedLetters(mask: string): number {
+  let n = 0;
+  for (const c of mask) {
+    if (c !== "_" && c !== " " && c !== "-") n++;
+  }
+  return n;
+}
+
 function nameOf(id: string, ev: MsgState): string {
   const p = ev.players.find((p) => p.id === id);
   return p ? p.name : "";
 }
 
+// renderPlayers lists the players; in team mode each team gets a
+// heading with its total and its members below it.
 function renderPlayers(ev: MsgState) {
   playersDiv.innerHTML = "";
-  for (const p of ev.players) {
-    const row = document.createElement("div");
-    row.className = "row";
-    if (p.id === ev.drawerID) row.classList.add("drawer");
-    if (p.id === myPlayerID)  row.classList.add("you");
-    row.innerHTML = `<span class="name">${escapeHTML(p.name)}</span><span class="score">${p.score}</span>`;
-    playersDiv.appendChild(row);
+  const teams = ev.settings?.teams;
+  const order = teams ? [1, 2] : [0];
+  for (const team of order) {
+    const members = ev.players.filter((p) => (p.team ?? 0) === team);
+    if (teams) {
+      const total = members.reduce((sum, p) => sum + p.score, 0);
+      const head = document.createElement("div");
+      head.className = `teamHead team${team}`;
+      head.innerHTML = `<span class="name">Team ${TEAM_NAMES[team]}</span><span class="score">${total}</span>`;
+      playersDiv.appendChild(head);
+    }
+    for (const p of members) renderPlayerRow(ev, p);
   }
 }
 
+function renderPlayerRow(ev: MsgState, p: PlayerView) {
+  const row = document.createElement("div");
+  row.className = "row";
+  if (p.team) row.classList.add(`team${p.team}`);
+  if (p.id === ev.drawerID) row.classList.add("drawer");
+  if (p.id === myPlayerID)  row.classList.add("you");
+  row.innerHTML = `<span class="name">${escapeHTML(p.name)}</span><span class="score">${p.score}</span>`;
+  // Anyone but the drawer can vote to kick anyone but themselves;
+  // the room needs a majority of the other voters.
+  if (p.id !== myPlayerID && myPlayerID !== ev.drawerID && !kicked) {
+    const kick = document.createElement("button");
+    kick.className = "kick";
+    kick.title = `vote to kick ${p.name}`;
+    kick.textContent = "🚫";
+    kick.addEventListener("click", () => {
+      if (confirm(`Vote to kick ${p.name}?`)) send({ type: "voteKick", target: p.id });
+    });
+    row.appendChild(kick);
+  }
+  playersDiv.appendChild(row);
+}
+
 // updateTimerAndProgress refreshes both the textual timer and the
 // width-driven progress bar at the foot of the header. Below 10 s it
 // switches to warn (orange), below 5 s to crit (red) — gives a clear
@@ -336,7 +498,11 @@ function updateBanner(ev: MsgState) {
       return;
     case "drawing":
       if (ev.youAreDrawer) {
-        setBanner("drawing", `✏️ You're drawing — others are guessing!`);
+        setBanner("drawing", ev.settings?.teams
+          ? `✏️ You're drawing — the other team is guessing!`
+          : `✏️ You're drawing — others are guessing!`);
+      } else if (onDrawingTeam) {
+        setBanner("drawing", `👀 ${drawerName} is drawing for your team — the other team guesses`);
       } else if (iHaveGuessed) {
         setBanner("drawing", `✓ You guessed it! Waiting for the round to end…`);
       } else {
@@ -485,6 +651,16 @@ swatches.forEach((sw, i) => {
   });
 });
 
+// ─── Report ─────────────────────────────────────────────────────────────
+
+// A majority of guessers reporting the drawing takes it down and ends
+// the round; the server ignores repeat reports from the same player.
+reportBtn.addEventListener("click", () => {
+  if (!confirm("Report this drawing as inappropriate?")) return;
+  send({ type: "report" });
+  reportBtn.hidden = true;
+});
+
 // ─── Chat / guess input ─────────────────────────────────────────────────
 
 chatInput.addEventListener("keydown", (e) => {
@@ -495,6 +671,133 @@ chatInput.addEventListener("keydown", (e) => {
   chatInput.value = "";
 });
 
+// ─── Create-room form (no ?room= in the URL) ─────────────────────────────
+
+const ROOM_BROWSER_POLL_MS = 3000;
+
+// showCreateRoom offers the word packs the server knows — filtered to
+// one language, optionally one difficulty — plus a box for a custom
+// word list. Ticking no pack means "every pack of the language". A
+// custom list is uploaded first; its id rides along with the packs.
+async function showCreateRoom() {
+  setBanner("waiting", "🎨 Set up a new room");
+  let catalogue: { packs: WordPack[]; languages: string[] } = { packs: [], languages: ["en"] };
+  try {
+    catalogue = await (await fetch("packs")).json();
+  } catch {
+    // Fall through with the defaults — the server picks English.
+  }
+
+  clearOverlay();
+  const div = document.createElement("div");
+  div.className = "overlay create";
+  div.innerHTML = `<h3>New room</h3>
+    <label>Language <select id="cfgLang">${catalogue.languages.map((l) => `<option>${escapeHTML(l)}</option>`).join("")}</select></label>
+    <label>Difficulty <select id="cfgDifficulty">
+      <option value="">any</option><option>easy</option><option>medium</option><option>hard</option>
+    </select></label>
+    <div class="packs" id="cfgPacks"></div>
+    <textarea id="cfgCustom" rows="4" placeholder="…or paste your own words, one per line"></textarea>
+    <div class="rules">
+      <label>Rounds <input type="number" id="cfgRounds" min="1" max="10" value="3" /></label>
+      <label>Draw time <select id="cfgDraw">
+        ${[30, 45, 60, 80, 120, 180, 240].map((s) => `<option value="${s}"${s === 80 ? " selected" : ""}>${s} s</option>`).join("")}
+      </select></label>
+      <label>Words to choose from <input type="number" id="cfgChoices" min="1" max="5" value="3" /></label>
+      <label>Hint letters <input type="number" id="cfgHints" min="0" max="5" value="2" /></label>
+      <label><input type="checkbox" id="cfgTeams" /> Team mode — two teams take turns drawing</label>
+      <label><input type="checkbox" id="cfgPublic" checked /> Public — list the room for anyone to join</label>
+    </div>
+    <div class="err" id="cfgErr"></div>
+  const langSel  = div.querySelector("#cfgLang") as HTMLSelectElement;
+  const diffSel  = div.querySelector("#cfgDifficulty") as HTMLSelectElement;
+  const packsDiv = div.querySelector("#cfgPacks") as HTMLElement;
+  const custom   = div.querySelector("#cfgCustom") as HTMLTextAreaElement;
+  const errEl    = div.querySelector("#cfgErr") as HTMLElement;
+
+  // The room browser sits under the form and refreshes until we connect.
+  const browser = document.createElement("div");
+  browser.className = "browser";
+  const refreshRooms = () => void renderRoomBrowser(browser, langSel.value);
+  const poll = setInterval(refreshRooms, ROOM_BROWSER_POLL_MS);
+  const go = (settings: Record<string, string>) => {
+    clearInterval(poll);
+    clearOverlay();
+    connect(settings);
+  };
+
+  const renderPacks = () => {
+    packsDiv.innerHTML = "";
+    for (const p of catalogue.packs) {
+      if (p.language !== langSel.value) continue;
+      if (diffSel.value && p.difficulty !== diffSel.value) continue;
+      const label = document.createElement("label");
+      label.innerHTML = `<input type="checkbox" value="${escapeHTML(p.id)}" /> ${escapeHTML(p.name)} <span class="muted">${escapeHTML(p.difficulty)}</span>`;
+      packsDiv.appendChild(label);
+    }
+  };
+  langSel.onchange = () => { renderPacks(); refreshRooms(); };
+  diffSel.onchange = renderPacks;
+  renderPacks();
+  refreshRooms();
+
+  const quick = document.createElement("button");
+  quick.textContent = "⚡ Quick play";
+  quick.title = "Join the fullest open room in this language, or open a new one";
+  quick.onclick = () => go({ quick: "1", lang: langSel.value });
+
+  const btn = document.createElement("button");
+  btn.textContent = "Create room";
+  btn.onclick = async () => {
+    const picked = Array.from(packsDiv.querySelectorAll("input:checked"), (el) => (el as HTMLInputElement).value);
+    if (custom.value.trim()) {
+      const res = await fetch(`packs?lang=${encodeURIComponent(langSel.value)}`, { method: "POST", body: custom.value });
+      if (!res.ok) {
+        errEl.textContent = (await res.text()).trim();
+        return;
+      }
+      picked.push((await res.json()).id);
+    }
+    const rule = (id: string) => (div.querySelector(id) as HTMLInputElement).value;
+    const ticked = (id: string) => (div.querySelector(id) as HTMLInputElement).checked ? "1" : "";
+    go({
+      lang: langSel.value, difficulty: diffSel.value, packs: picked.join(","),
+      rounds: rule("#cfgRounds"), draw: rule("#cfgDraw"), choices: rule("#cfgChoices"), hints: rule("#cfgHints"),
+      teams: ticked("#cfgTeams"), public: ticked("#cfgPublic"),
+    });
+  };
+  div.appendChild(btn);
+  div.appendChild(quick);
+  div.appendChild(browser);
+  overlay.appendChild(div);
+}
+
+// renderRoomBrowser lists the lobby's public rooms in one language. A
+// joinable room links to ?room=, which joins it like any shared link.
+async function renderRoomBrowser(el: HTMLElement, lang: string) {
+  let rooms: RoomStatus[] = [];
+  try {
+    rooms = (await (await fetch(`rooms?lang=${encodeURIComponent(lang)}`)).json()).rooms || [];
+  } catch {
+    return; // keep the last list; the next poll may reach the lobby
+  }
+
+  el.innerHTML = `<h4>Open rooms</h4>`;
+  if (rooms.length === 0) {
+    el.innerHTML += `<div class="muted">No public rooms yet — create one or try quick play.</div>`;
+    return;
+  }
+  for (const r of rooms) {
+    const row = document.createElement(r.joinable ? "a" : "div");
+    row.className = "room" + (r.joinable ? "" : " full");
+    if (r.joinable) (row as HTMLAnchorElement).href = `?room=${encodeURIComponent(r.code)}`;
+    const rules = `${r.settings.rounds} rounds · ${r.settings.drawSeconds} s${r.settings.teams ? " · teams" : ""}`;
+    row.innerHTML = `<b>${escapeHTML(r.code)}</b> <span>${r.players}/${r.maxPlayers}</span>
+      <span class="muted">${escapeHTML(r.phase)} · ${escapeHTML(rules)}</span>`;
+    el.appendChild(row);
+  }
+}
+
 // ─── Word-choice overlay (drawer only, during choosing) ─────────────────
 
 function showWordChoices(choices: string[]) {
@@ -532,12 +835,24 @@ function showGameOver(ev: MsgGameOver) {
   clearOverlay();
   const div = document.createElement("div");
   div.className = "overlay";
-  const rows = ev.scores
+  // Team mode: team totals first, then the players with their team.
+  const teams = ev.scores.some((s) => s.team);
+  let rows = ev.scores
     .slice()
     .sort((a, b) => b.score - a.score)
-    .map((s) => `<div>${escapeHTML(s.name)}: ${s.score}</div>`)
+    .map((s) => `<div>${escapeHTML(s.name)}${s.team ? ` <span class="muted">(${TEAM_NAMES[s.team]})</span>` : ""}: ${s.score}</div>`)
     .join("");
-  div.innerHTML = `<h3>🏆 ${escapeHTML(ev.winnerName || "(nobody)")} wins!</h3>${rows}`;
+  if (teams) {
+    const totals = [1, 2].map((t) => ev.scores.filter((s) => s.team === t).reduce((sum, s) => sum + s.score, 0));
+    rows = `<div><b>Team Red: ${totals[0]} — Team Blue: ${totals[1]}</b></div>${rows}`;
+  }
+  const title = teams && !ev.winnerTeam
+    ? "🤝 It's a tie!"
+    : `🏆 ${escapeHTML(ev.winnerName || "(nobody)")} wins!`;
+  div.innerHTML = `<h3>${title}</h3>${rows}`;
+  if (ev.drawings && ev.drawings.length > 0) {
+    div.appendChild(renderDrawings(ev.gameNumber, ev.drawings));
+  }
 
   // Play Again button. First click sends the restart message; we
   // disable the button locally so the user gets immediate feedback.
@@ -560,6 +875,41 @@ function showGameOver(ev: MsgGameOver) {
   if (ev.leaderboard) renderLeaderboard(ev.leaderboard);
 }
 
+// renderDrawings lays out the game's archived rounds as thumbnails.
+// The server renders each one: .svg is the finished picture, .gif an
+// animated replay — that is the link "share" hands out.
+function renderDrawings(game: number, drawings: DrawingView[]): HTMLElement {
+  const strip = document.createElement("div");
+  strip.className = "drawings";
+  for (const d of drawings) {
+    const base = new URL(`drawings/${encodeURIComponent(roomCode)}/${game}/${d.round}`, location.href).toString();
+    const card = document.createElement("div");
+    card.className = "drawing";
+    card.innerHTML = `<a href="${base}.gif" target="_blank" rel="noopener"><img src="${base}.svg" alt="${escapeHTML(d.word)}" /></a>
+                      <div class="caption">${escapeHTML(d.word)} · ${escapeHTML(d.drawerName)}</div>`;
+    const share = document.createElement("button");
+    share.textContent = "share";
+    share.onclick = () => shareDrawing(`${base}.gif`, d);
+    card.appendChild(share);
+    strip.appendChild(card);
+  }
+  return strip;
+}
+
+// shareDrawing uses the native share sheet where there is one and falls
+// back to copying the replay link.
+function shareDrawing(url: string, d: DrawingView) {
+  const title = `${d.drawerName} drew "${d.word}" in goakt-pictograph`;
+  if (navigator.share) {
+    navigator.share({ title, url }).catch(() => {});
+    return;
+  }
+  navigator.clipboard.writeText(url).then(
+    () => toast("🔗 Replay link copied", "good"),
+    () => toast("couldn't copy the link", "warn"),
+  );
+}
+
 function clearOverlay() {
   overlay.innerHTML = "";
 }
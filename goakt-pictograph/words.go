@@ -22,61 +22,175 @@
 
 package main
 
-import "math/rand/v2"
-
-// wordBank — short, drawable nouns. Kept inline so the binary stays
-// self-contained; a real deployment would ship a file or a localized
-// list per language.
-var wordBank = []string{
-	// animals
-	"cat", "dog", "elephant", "giraffe", "octopus", "penguin", "whale", "rabbit",
-	"butterfly", "spider", "snake", "kangaroo", "hedgehog", "owl", "dolphin",
-	// food
-	"pineapple", "pizza", "hamburger", "sushi", "donut", "watermelon", "carrot",
-	"cupcake", "popcorn", "sandwich", "spaghetti", "taco", "icecream",
-	// objects
-	"umbrella", "telephone", "guitar", "camera", "bicycle", "clock", "scissors",
-	"backpack", "lamp", "anchor", "binoculars", "compass", "telescope", "balloon",
-	// vehicles / scenes
-	"spaceship", "helicopter", "submarine", "rocket", "lighthouse", "windmill",
-	"castle", "pyramid", "skyscraper", "bridge", "treehouse",
-	// fantasy / fun
-	"dragon", "unicorn", "robot", "mermaid", "wizard", "ghost", "pirate", "alien",
-	"vampire", "snowman", "skeleton",
-	// nature
-	"mountain", "rainbow", "tornado", "volcano", "waterfall", "cactus", "mushroom",
-	"sunflower", "iceberg", "tree",
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// DefaultLanguage is the word language of a room whose creator did not
+// pick one.
+const DefaultLanguage = "en"
+
+// guessFolds maps the letters a language's players may type without
+// their accent to what both the guess and the word fold to before they
+// are compared. Every language strips the common Latin accents; German
+// spells its umlauts out (ö → oe) rather than dropping them, the way a
+// German keyboard-less typist would.
+var guessFolds = map[string]map[rune]string{
+	"de": {'ä': "ae", 'ö': "oe", 'ü': "ue", 'ß': "ss"},
 }
 
-// pickWords returns n distinct random words from the bank. Used by the
-// RoomActor when entering the choosing phase. The bank is small enough
-// (~70 words) that Fisher–Yates on a fresh slice is cheap.
-func pickWords(n int) []string {
-	if n <= 0 {
+var accentFolds = foldAccents(
+	"àáâãäå", "a", "ç", "c", "èéêë", "e", "ìíîï", "i", "ñ", "n",
+	"òóôõö", "o", "ùúûü", "u", "ýÿ", "y", "œ", "oe", "æ", "ae", "ß", "ss",
+)
+
+// pickWords returns n distinct random words from pool that are not in
+// used, and marks them used so a game never offers the same word twice.
+// Once the pool runs dry the game starts over on the full pool.
+func pickWords(pool []string, used map[string]bool, n int) []string {
+	if n <= 0 || len(pool) == 0 {
 		return nil
 	}
-	if n > len(wordBank) {
-		n = len(wordBank)
+
+	fresh := make([]string, 0, len(pool))
+	for _, word := range pool {
+		if !used[word] {
+			fresh = append(fresh, word)
+		}
+	}
+	if len(fresh) < n {
+		clear(used)
+		fresh = append(fresh[:0], pool...)
+	}
+
+	rand.Shuffle(len(fresh), func(i, j int) { fresh[i], fresh[j] = fresh[j], fresh[i] })
+	picked := fresh[:min(n, len(fresh))]
+	for _, word := range picked {
+		used[word] = true
 	}
-	pool := make([]string, len(wordBank))
-	copy(pool, wordBank)
-	rand.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
-	return pool[:n]
+	return picked
 }
 
 // maskWord returns a hint string for guessers: letters → underscores,
-// non-letters (e.g. spaces) preserved. Used as the StateEvent.WordMask
-// so the UI can show "_ _ _ _ _ _ _ _ _" while the round is in progress.
-func maskWord(w string) string {
-	out := make([]byte, len(w))
-	for i := 0; i < len(w); i++ {
-		c := w[i]
-		switch {
-		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
-			out[i] = '_'
-		default:
-			out[i] = c
+// except the rune positions in reveal; non-letters (e.g. spaces)
+// preserved. Used as the StateEvent.WordMask so the UI can show
+// "_ _ a _ _ _ _ _ _" while the round is in progress.
+func maskWord(w string, reveal []int) string {
+	var b strings.Builder
+	for i, r := range []rune(w) {
+		if unicode.IsLetter(r) && !slices.Contains(reveal, i) {
+			b.WriteByte('_')
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// hintOrder returns the rune positions of w's letters in the random
+// order they will be revealed, cut to the number of hints the word
+// gets: at most n, and never more than half its letters.
+func hintOrder(w string, n int) []int {
+	var letters []int
+	for i, r := range []rune(w) {
+		if unicode.IsLetter(r) {
+			letters = append(letters, i)
+		}
+	}
+
+	rand.Shuffle(len(letters), func(i, j int) { letters[i], letters[j] = letters[j], letters[i] })
+	return letters[:max(0, min(n, len(letters)/2))]
+}
+
+// closeGuessDistance is the largest edit distance at which a wrong guess
+// counts as close: one typo for short words, two for longer ones.
+func closeGuessDistance(word string) int {
+	if len([]rune(word)) <= 5 {
+		return 1
+	}
+	return 2
+}
+
+// editDistance is the Levenshtein distance between a and b, in runes.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// normalizeGuess folds s for comparison against the secret word in
+// language: lower-cased, accents folded per guessFolds, and spaces,
+// hyphens and apostrophes dropped so "ice-cream" and "icecream" both
+// match "ice cream".
+func normalizeGuess(language, s string) string {
+	folds := guessFolds[language]
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		if repl, ok := folds[r]; ok {
+			b.WriteString(repl)
+			continue
+		}
+		if repl, ok := accentFolds[r]; ok {
+			b.WriteString(repl)
+			continue
+		}
+		if unicode.IsSpace(r) || r == '-' || r == '\'' || r == '’' {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// cleanWordList trims, de-duplicates and length-checks a word list,
+// dropping blank lines and words longer than maxWordRunes.
+func cleanWordList(words []string) []string {
+	out := make([]string, 0, len(words))
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		word = strings.Join(strings.Fields(word), " ")
+		if word == "" || len([]rune(word)) > maxWordRunes {
+			continue
+		}
+		key := strings.ToLower(word)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, word)
+	}
+	return out
+}
+
+// foldAccents builds a fold table from (accented runes, replacement)
+// pairs.
+func foldAccents(pairs ...string) map[rune]string {
+	folds := make(map[rune]string)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		for _, r := range pairs[i] {
+			folds[r] = pairs[i+1]
 		}
 	}
-	return string(out)
+	return folds
 }
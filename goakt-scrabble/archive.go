@@ -0,0 +1,136 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tochemey/goakt/v4/actor"
+	"github.com/tochemey/goakt/v4/extension"
+)
+
+const (
+	GameArchiveExtensionID = "scrabble_game_archive"
+
+	// maxArchivedGames bounds the archive; the oldest record is evicted
+	// once it is full.
+	maxArchivedGames = 1024
+)
+
+// GameArchive keeps the GCG record of finished games, keyed by room code
+// and game number, for download over HTTP.
+//
+// Like memProfileStore it is process-local. The room saves each record on
+// its own node, and every PlayerSessionActor saves the copy carried on the
+// GameOverEvent, so a player can always fetch the game from the pod their
+// browser is connected to.
+type GameArchive struct {
+	mu      sync.Mutex
+	records map[string][]byte
+	order   []string
+}
+
+var _ extension.Extension = (*GameArchive)(nil)
+
+func NewGameArchive() *GameArchive {
+	return &GameArchive{records: make(map[string][]byte)}
+}
+
+func (a *GameArchive) ID() string { return GameArchiveExtensionID }
+
+// Save stores the record for game number game of room code. Saving the
+// same game twice keeps the first copy.
+func (a *GameArchive) Save(code string, game int, gcg []byte) {
+	if a == nil || len(gcg) == 0 {
+		return
+	}
+
+	key := archiveKey(code, game)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.records[key]; ok {
+		return
+	}
+
+	if len(a.order) >= maxArchivedGames {
+		delete(a.records, a.order[0])
+		a.order = a.order[1:]
+	}
+
+	a.records[key] = gcg
+	a.order = append(a.order, key)
+}
+
+// Get returns the record for game number game of room code.
+func (a *GameArchive) Get(code string, game int) ([]byte, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	gcg, ok := a.records[archiveKey(code, game)]
+
+	return gcg, ok
+}
+
+func gameArchiveFromExtension(system actor.ActorSystem) *GameArchive {
+	for _, ext := range system.Extensions() {
+		if ext.ID() == GameArchiveExtensionID {
+			if archive, ok := ext.(*GameArchive); ok {
+				return archive
+			}
+		}
+	}
+
+	return nil
+}
+
+// gcgHandler serves GET /games/{code}/{game} as a GCG file download.
+func gcgHandler(archive *GameArchive) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		code := strings.ToUpper(strings.TrimSpace(r.PathValue("code")))
+		game, err := strconv.Atoi(strings.TrimSuffix(r.PathValue("game"), ".gcg"))
+		if err != nil || game < 1 {
+			http.Error(w, "bad game number", http.StatusBadRequest)
+			return
+		}
+
+		gcg, ok := archive.Get(code, game)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s-%d.gcg", code, game)))
+		_, _ = w.Write(gcg)
+	}
+}
+
+func archiveKey(code string, game int) string {
+	return code + "#" + strconv.Itoa(game)
+}
@@ -24,7 +24,9 @@ package main
 
 import (
 	"fmt"
+	"math/rand/v2"
 	"strings"
+	"time"
 
 	"github.com/tochemey/goakt/v4/actor"
 
@@ -34,14 +36,17 @@ import (
 // shutdownBot tells a BotActor to stop. Sent by RoomActor.removeBot.
 type shutdownBot struct{}
 
-// BotActor wraps the engine's greedy move generator. The room schedules
-// a YourTurn to it on the bot's turn; the bot picks the best legal move
-// and Tells the room a BotPlay. An empty Placements slice means pass.
+// BotActor wraps the engine's move generator. The room schedules a
+// YourTurn to it on the bot's turn; the bot picks a move for the seat's
+// difficulty level and Tells the room a BotPlay. An empty Placements
+// slice means pass.
 //
 // The actor name encodes the language so PostStart can fetch the right
 // bundle from the system registry: "bot.<lang>.<roomCode>.<botID>".
 type BotActor struct {
 	bundle *LangBundle
+	leaves *scrabble.LeaveTable
+	rng    *rand.Rand
 }
 
 var _ actor.Actor = (*BotActor)(nil)
@@ -75,6 +80,8 @@ func (b *BotActor) Receive(ctx *actor.ReceiveContext) {
 		}
 
 		b.bundle = bundle
+		b.leaves = scrabble.NewLeaveTable(bundle.Lang)
+		b.rng = newRoomRNG()
 
 	case *YourTurn:
 		b.handleTurn(ctx, msg)
@@ -88,7 +95,30 @@ func (b *BotActor) Receive(ctx *actor.ReceiveContext) {
 }
 
 func (b *BotActor) handleTurn(ctx *actor.ReceiveContext, msg *YourTurn) {
-	board, err := wireToBoard(msg.Board, b.bundle.Lang)
+	registry := registryFromExtension(ctx.ActorSystem())
+
+	layout := scrabble.StandardLayout()
+	if msg.Layout != "" {
+		var err error
+		if layout, err = registry.Layout(msg.Layout); err != nil {
+			ctx.Logger().Errorf("bot %s: %v", msg.BotID, err)
+			ctx.Tell(ctx.Self().Parent(), &BotPlay{BotID: msg.BotID})
+			return
+		}
+	}
+
+	// The room moves to a new lexicon only between games; follow it.
+	if msg.Lexicon != "" && msg.Lexicon != b.bundle.Lexicon {
+		bundle, err := registry.Lexicon(ctx.Context(), msg.Lexicon)
+		if err != nil {
+			ctx.Logger().Errorf("bot %s: %v", msg.BotID, err)
+			ctx.Tell(ctx.Self().Parent(), &BotPlay{BotID: msg.BotID})
+			return
+		}
+		b.bundle = bundle
+	}
+
+	board, err := wireToBoard(msg.Board, layout, b.bundle.Lang)
 	if err != nil {
 		ctx.Logger().Errorf("bot %s: parse board: %v", msg.BotID, err)
 		ctx.Tell(ctx.Self().Parent(), &BotPlay{BotID: msg.BotID})
@@ -102,7 +132,12 @@ func (b *BotActor) handleTurn(ctx *actor.ReceiveContext, msg *YourTurn) {
 		return
 	}
 
-	best := scrabble.BestMove(board, rack, b.bundle.Dawg, b.bundle.Lang)
+	level, err := scrabble.ParseLevel(msg.Level)
+	if err != nil {
+		level = defaultBotLevel
+	}
+
+	best := b.chooseMove(board, rack, level, msg)
 
 	if best == nil {
 		ctx.Tell(ctx.Self().Parent(), &BotPlay{BotID: msg.BotID})
@@ -113,10 +148,37 @@ func (b *BotActor) handleTurn(ctx *actor.ReceiveContext, msg *YourTurn) {
 	ctx.Tell(ctx.Self().Parent(), &BotPlay{BotID: msg.BotID, Placements: wires})
 }
 
-// wireToBoard rebuilds an engine.Board from the wire string grid the
-// bot received in YourTurn.
-func wireToBoard(grid [][]string, lang *scrabble.Language) (*scrabble.Board, error) {
-	board := scrabble.NewBoard()
+// chooseMove picks the bot's move. An expert with a thinking budget
+// searches: an exact endgame when the bag is empty and there is a single
+// opponent (whose rack is then exactly the unseen tiles), Monte Carlo
+// simulation otherwise. Other levels pick directly.
+func (b *BotActor) chooseMove(board *scrabble.Board, rack *scrabble.Rack, level scrabble.Level, msg *YourTurn) *scrabble.ScoredMove {
+	lang, dawg := b.bundle.Lang, b.bundle.Dawg
+
+	if level != scrabble.Expert || msg.ThinkMs <= 0 {
+		return scrabble.ChooseMove(board, rack, dawg, lang, level, b.leaves, b.rng)
+	}
+
+	budget := time.Duration(msg.ThinkMs) * time.Millisecond
+	unseen := scrabble.Unseen(board, rack, lang)
+
+	if msg.BagRemaining == 0 && msg.Opponents == 1 {
+		opp := scrabble.NewRack()
+		opp.Add(unseen)
+		return scrabble.SolveEndgame(board, rack, opp, dawg, lang, time.Now().Add(budget)).Move
+	}
+
+	return scrabble.Simulate(board, rack, unseen, dawg, lang, scrabble.SimConfig{
+		Budget: budget,
+		Leaves: b.leaves,
+		Rng:    b.rng,
+	})
+}
+
+// wireToBoard rebuilds an engine.Board on layout from the wire string
+// grid the bot received in YourTurn.
+func wireToBoard(grid [][]string, layout *scrabble.Layout, lang *scrabble.Language) (*scrabble.Board, error) {
+	board := scrabble.NewLayoutBoard(layout)
 
 	for row, rowCells := range grid {
 		for col, cell := range rowCells {
@@ -167,12 +229,7 @@ func wireToTile(raw string, lang *scrabble.Language) (scrabble.Tile, error) {
 		blank = true
 	}
 
-	runes := []rune(strings.ToUpper(letterPart))
-	if len(runes) != 1 {
-		return scrabble.Tile{}, fmt.Errorf("bot: bad wire tile %q", raw)
-	}
-
-	id, ok := lang.ID(runes[0])
+	id, ok := lang.Lookup(letterPart)
 	if !ok {
 		return scrabble.Tile{}, fmt.Errorf("bot: bad wire tile %q", raw)
 	}
@@ -187,7 +244,7 @@ func enginePlacementsToWire(placements []scrabble.Placement, lang *scrabble.Lang
 		out[i] = PlacementWire{
 			Row:    p.Row,
 			Col:    p.Col,
-			Letter: string(lang.Rune(p.Tile.Letter)),
+			Letter: lang.Letter(p.Tile.Letter),
 			Blank:  p.Tile.Blank,
 		}
 	}
@@ -0,0 +1,125 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Command mkdawg compiles Scrabble wordlists into the binary DAWG format
+// the server loads at startup:
+//
+//	go run ./goakt-scrabble/cmd/mkdawg goakt-scrabble/dict/*.txt
+//
+// writes dict/en.dawg next to dict/en.txt, and so on. The language is
+// taken from the file name unless -lang is given.
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tochemey/goakt-examples/v2/goakt-scrabble/scrabble"
+)
+
+var (
+	langCode = flag.String("lang", "", "Language code of the wordlists (defaults to each file's name, e.g. en for en.txt)")
+	output   = flag.String("o", "", "Output file (defaults to the wordlist with a .dawg extension; only with one wordlist)")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: mkdawg [-lang code] [-o out.dawg] wordlist.txt...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 || (*output != "" && flag.NArg() > 1) {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	for _, path := range flag.Args() {
+		if err := compile(path); err != nil {
+			fmt.Fprintf(os.Stderr, "mkdawg: %s: %v\n", path, err)
+			os.Exit(1)
+		}
+	}
+}
+
+func compile(path string) error {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	lang, err := language(cmp.Or(*langCode, base))
+	if err != nil {
+		return err
+	}
+
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	dawg, err := scrabble.BuildDAWG(lang, in)
+	if err != nil {
+		return err
+	}
+
+	out := *output
+	if out == "" {
+		out = strings.TrimSuffix(path, filepath.Ext(path)) + ".dawg"
+	}
+
+	// Write beside the target and rename, so a server reading the
+	// directory never sees half a file.
+	tmp, err := os.CreateTemp(filepath.Dir(out), ".mkdawg-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	size, err := dawg.WriteTo(tmp)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), out); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: %d words, %d bytes (%s)\n", out, dawg.Size(), size, lang.Code)
+
+	return nil
+}
+
+func language(code string) (*scrabble.Language, error) {
+	for _, lang := range scrabble.Languages() {
+		if lang.Code == code {
+			return lang, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown language %q", code)
+}
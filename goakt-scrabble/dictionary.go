@@ -0,0 +1,99 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+
+	"github.com/tochemey/goakt-examples/v2/goakt-scrabble/scrabble"
+)
+
+// dictSource supplies wordlists to the Registry: one word per line, or
+// a DAWG compiled by cmd/mkdawg.
+type dictSource interface {
+	// Wordlist returns the wordlist for a language code at version, or
+	// the newest one when version is empty, along with its version. A
+	// source without it returns an error wrapping fs.ErrNotExist.
+	Wordlist(ctx context.Context, code, version string) (string, []byte, error)
+}
+
+// fsDictSource reads "<code>.txt" wordlists, or "<code>.dawg" compiled
+// ones, from a file system: the bundled dict/ directory, or the one
+// named by --dict-dir. A wordlist's version is a hash of its text, which
+// a compiled DAWG records, so every node names the same list the same
+// way whichever file it loads; only the version currently on disk can
+// be loaded.
+type fsDictSource struct {
+	fsys fs.FS
+}
+
+var _ dictSource = fsDictSource{}
+
+// Wordlist prefers the compiled DAWG, unless a text file beside it has
+// changed since it was compiled.
+func (s fsDictSource) Wordlist(_ context.Context, code, version string) (string, []byte, error) {
+	text, textErr := fs.ReadFile(s.fsys, code+".txt")
+	if textErr != nil && !errors.Is(textErr, fs.ErrNotExist) {
+		return "", nil, textErr
+	}
+
+	words := text
+	compiled, err := fs.ReadFile(s.fsys, code+".dawg")
+	switch {
+	case err == nil && (textErr != nil || wordlistVersion(compiled) == wordlistVersion(text)):
+		words = compiled
+	case err != nil && !errors.Is(err, fs.ErrNotExist):
+		return "", nil, err
+	case textErr != nil:
+		return "", nil, textErr
+	}
+
+	found := wordlistVersion(words)
+	if version != "" && version != found {
+		return "", nil, fs.ErrNotExist
+	}
+
+	return found, words, nil
+}
+
+// wordlistVersion names a wordlist after the first 12 hex digits of the
+// SHA-256 of its text; for a compiled DAWG, of the text it was compiled
+// from.
+func wordlistVersion(words []byte) string {
+	sum, ok := scrabble.DAWGFileSource(words)
+	if !ok {
+		sum = sha256.Sum256(words)
+	}
+
+	return hex.EncodeToString(sum[:6])
+}
+
+// lexiconName is the versioned name of a wordlist, "<code>:<version>",
+// that a room records for each game.
+func lexiconName(code, version string) string {
+	return code + ":" + version
+}
@@ -0,0 +1,103 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// pgDictSource reads wordlists from the lexicons table, one row per
+// language and version. Unlike the file sources it keeps every version
+// it has ever been given, so a game rehydrated on a freshly started pod
+// still finds the lexicon it began with.
+type pgDictSource struct {
+	pool *pgxpool.Pool
+}
+
+var _ dictSource = (*pgDictSource)(nil)
+
+const lexiconSchema = `
+CREATE TABLE IF NOT EXISTS lexicons (
+    language   TEXT        NOT NULL,
+    version    TEXT        NOT NULL,
+    words      TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (language, version)
+);`
+
+// newPgDictSource connects to Postgres and creates the lexicons table
+// if needed. The caller owns lifecycle: call Close on shutdown.
+func newPgDictSource(ctx context.Context, dsn string) (*pgDictSource, error) {
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parse DATABASE_URL: %w", err)
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+
+	if _, err := pool.Exec(ctx, lexiconSchema); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("migrate: %w", err)
+	}
+
+	return &pgDictSource{pool: pool}, nil
+}
+
+func (s *pgDictSource) Close() {
+	if s.pool != nil {
+		s.pool.Close()
+	}
+}
+
+// Wordlist returns the named version, or the most recently inserted one
+// when version is empty.
+func (s *pgDictSource) Wordlist(ctx context.Context, code, version string) (string, []byte, error) {
+	const q = `
+SELECT version, words FROM lexicons
+WHERE language = $1 AND ($2 = '' OR version = $2)
+ORDER BY created_at DESC
+LIMIT 1`
+
+	var (
+		found string
+		words string
+	)
+	err := s.pool.QueryRow(ctx, q, code, version).Scan(&found, &words)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return "", nil, fs.ErrNotExist
+	case err != nil:
+		return "", nil, err
+	}
+
+	return found, []byte(words), nil
+}
@@ -46,12 +46,19 @@ const (
 	defaultLanguageCode   = "en"
 )
 
+// requestRoom asks the lobby for a room. A requested code's snapshot is
+// looked up here, on the caller's goroutine, rather than by the lobby.
 func requestRoom(ctx context.Context, system actor.ActorSystem, msg *JoinOrCreate) (*actor.PID, string, error) {
 	lobby, err := system.ActorOf(ctx, LobbyActorName)
 	if err != nil {
 		return nil, "", fmt.Errorf("locate lobby: %w", err)
 	}
 
+	if msg.Room != "" {
+		snap, saved := loadRoomSnapshot(ctx, system, msg.Room)
+		msg.Saved, msg.SavedLanguage = saved, snap.Language
+	}
+
 	reply, err := actor.Ask(ctx, lobby, msg, lobbyAskTimeout)
 	if err != nil {
 		return nil, "", fmt.Errorf("lobby.JoinOrCreate: %w", err)
@@ -105,6 +112,33 @@ func resolveRoom(ctx context.Context, system actor.ActorSystem, result *JoinOrCr
 	}
 }
 
+// roomsHandler serves GET /rooms: the lobby's public rooms as JSON,
+// optionally narrowed with ?lang=.
+func roomsHandler(system actor.ActorSystem) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lobby, err := system.ActorOf(r.Context(), LobbyActorName)
+		if err != nil {
+			http.Error(w, "lobby unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		reply, err := actor.Ask(r.Context(), lobby, &ListRooms{Language: r.URL.Query().Get("lang")}, lobbyAskTimeout)
+		if err != nil {
+			http.Error(w, "lobby unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		list, ok := reply.(*RoomList)
+		if !ok {
+			http.Error(w, "unexpected lobby reply", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"rooms": list.Rooms})
+	}
+}
+
 func wsHandler(system actor.ActorSystem, leaderboard *Leaderboard, drainCtx context.Context, wg *sync.WaitGroup, logger log.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		wg.Add(1)
@@ -132,16 +166,22 @@ func wsHandler(system actor.ActorSystem, leaderboard *Leaderboard, drainCtx cont
 			language = defaultLanguageCode
 		}
 		room := strings.ToUpper(strings.TrimSpace(q.Get("room")))
+		watch := q.Get("watch") == "1"
 
+		// ?quick=1 without a room code takes a seat in any open public
+		// room of the language; ?public=1 lists a newly created room.
 		roomPID, code, err := requestRoom(r.Context(), system, &JoinOrCreate{
-			Room: room, Language: language, PlayerID: playerID, PlayerName: name,
+			Room: room, Language: language, PlayerID: playerID, PlayerName: name, Watch: watch,
+			Public: q.Get("public") == "1", QuickPlay: q.Get("quick") == "1",
 		})
 		if err != nil {
 			_ = conn.Close(websocket.StatusInternalError, "lobby unavailable")
 			logger.Errorf("ws %v", err)
 			return
 		}
-		leaderboard.RememberName(playerID, name)
+		if !watch {
+			leaderboard.RememberName(playerID, name)
+		}
 
 		sessionName := SessionActorPrefix + uuid.NewString()
 		session := &PlayerSessionActor{
@@ -151,6 +191,7 @@ func wsHandler(system actor.ActorSystem, leaderboard *Leaderboard, drainCtx cont
 			name:      name,
 			roomCode:  code,
 			topicName: RoomTopicPrefix + code,
+			spectator: watch,
 		}
 
 		sessionPID, err := system.Spawn(r.Context(), sessionName, session, actor.WithLongLived())
@@ -165,8 +206,8 @@ func wsHandler(system actor.ActorSystem, leaderboard *Leaderboard, drainCtx cont
 			placement = "remote@" + roomPID.Path().HostPort()
 		}
 
-		logger.Infof("ws connected: name=%q session=%s room=%s lang=%s (%s)",
-			name, sessionName, code, language, placement)
+		logger.Infof("ws connected: name=%q session=%s room=%s lang=%s watch=%t (%s)",
+			name, sessionName, code, language, watch, placement)
 
 		readCtx, cancelRead := context.WithCancel(r.Context())
 		defer cancelRead()
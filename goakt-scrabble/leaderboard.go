@@ -24,7 +24,10 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"math"
 	"sort"
 	"sync"
 	"time"
@@ -32,25 +35,42 @@ import (
 	"github.com/tochemey/goakt/v4/actor"
 	"github.com/tochemey/goakt/v4/crdt"
 	"github.com/tochemey/goakt/v4/extension"
+
+	"github.com/tochemey/goakt-examples/v2/goakt-scrabble/scrabble"
 )
 
 const (
 	leaderboardOpTimeout   = 2 * time.Second
 	LeaderboardExtensionID = "scrabble_leaderboard"
 
-	// playerSetKeyPrefix indexes which player ids hold a counter for
-	// each language: "scrabble.players.en", "scrabble.players.fr", etc.
-	playerSetKeyPrefix = "scrabble.players."
+	// ratingSetKeyPrefix indexes the players' current ratings in each
+	// language: "scrabble.ratings.en", "scrabble.ratings.fr", etc.
+	ratingSetKeyPrefix = "scrabble.ratings."
 )
 
-// Leaderboard tracks per-(playerID, language) win counts via CRDT
-// PNCounters and a per-language ORSet index. Falls back to a process-
-// local map if the cluster's CRDT replicator is unavailable.
+// RatedSeat is one seat of a finished rated game. Bots play at their
+// level's fixed rating and are never rated themselves.
+type RatedSeat struct {
+	PlayerID string
+	Name     string
+	Score    int
+	Bot      bool
+	Level    scrabble.Level
+}
+
+// Leaderboard ranks players per language by their Glicko-2 rating. The
+// ratings live in each player's PlayerProfileGrain, whose single
+// activation serialises every update however many nodes finish games
+// at once. Each new rating is also written, as a JSON-encoded
+// LeaderboardEntry, to a CRDT ORSet per language that replaces the
+// player's previous entry, so a board is read from the set alone
+// without activating any grain. Falls back to a process-local index if
+// the cluster's CRDT replicator is unavailable.
 type Leaderboard struct {
 	system actor.ActorSystem
 
 	mu       sync.Mutex
-	fallback map[string]int64
+	fallback map[string]map[string]LeaderboardEntry
 	names    map[string]string
 }
 
@@ -58,7 +78,7 @@ var _ extension.Extension = (*Leaderboard)(nil)
 
 func NewLeaderboard() *Leaderboard {
 	return &Leaderboard{
-		fallback: make(map[string]int64),
+		fallback: make(map[string]map[string]LeaderboardEntry),
 		names:    make(map[string]string),
 	}
 }
@@ -80,7 +100,7 @@ func leaderboardFromExtension(system actor.ActorSystem) *Leaderboard {
 }
 
 // RememberName records the latest known display name for a player so
-// that Top can return it without contacting the profile grain.
+// that Top has one for players whose profile has none yet.
 func (l *Leaderboard) RememberName(playerID, name string) {
 	if l == nil {
 		return
@@ -91,114 +111,217 @@ func (l *Leaderboard) RememberName(playerID, name string) {
 	l.mu.Unlock()
 }
 
-// RecordWin increments the per-(player, language) PNCounter and adds
-// the player to the per-language ORSet index.
-func (l *Leaderboard) RecordWin(ctx context.Context, language, playerID, name string) error {
-	if l == nil {
+// RecordGame rates a finished game from its final scores. It reads
+// every human's rating as it stood before the game, splits the game
+// into pairwise results with scrabble.GameOpponents, and has each
+// human's profile grain apply its own results, indexing the rating it
+// replies with.
+func (l *Leaderboard) RecordGame(ctx context.Context, language string, seats []RatedSeat) error {
+	if l == nil || l.system == nil {
 		return nil
 	}
 
-	l.RememberName(playerID, name)
+	cctx, cancel := context.WithTimeout(ctx, leaderboardOpTimeout)
+	defer cancel()
 
-	if l.system == nil || l.system.Replicator() == nil {
-		l.mu.Lock()
-		l.fallback[fallbackKey(language, playerID)]++
-		l.mu.Unlock()
-		return nil
+	ratings := make([]scrabble.Rating, len(seats))
+	scores := make([]int, len(seats))
+
+	for i, seat := range seats {
+		scores[i] = seat.Score
+		if seat.Bot {
+			ratings[i] = scrabble.BotRating(seat.Level)
+			continue
+		}
+
+		l.RememberName(seat.PlayerID, seat.Name)
+
+		reply, err := l.askProfile(cctx, seat.PlayerID, &GetRating{Language: language})
+		if err != nil {
+			return fmt.Errorf("read rating of %s: %w", seat.PlayerID, err)
+		}
+		ratings[i] = reply.Rating.Rating
+	}
+
+	opponents := scrabble.GameOpponents(ratings, scores)
+
+	for i, seat := range seats {
+		if seat.Bot {
+			continue
+		}
+
+		reply, err := l.askProfile(cctx, seat.PlayerID, &RateGame{Language: language, Opponents: opponents[i]})
+		if err != nil {
+			return fmt.Errorf("rate %s: %w", seat.PlayerID, err)
+		}
+
+		name := reply.Name
+		if name == "" {
+			name = seat.Name
+		}
+
+		entry := LeaderboardEntry{
+			PlayerID:  seat.PlayerID,
+			Name:      name,
+			Rating:    int(math.Round(reply.Rating.Rating.R)),
+			Deviation: int(math.Round(reply.Rating.Rating.RD)),
+			Games:     reply.Rating.Games,
+		}
+		if err := l.index(cctx, language, entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Top returns the top-n rated players for a language, highest rating
+// first.
+func (l *Leaderboard) Top(ctx context.Context, language string, n int) ([]LeaderboardEntry, error) {
+	if l == nil || l.system == nil {
+		return nil, nil
 	}
 
-	rep := l.system.Replicator()
 	cctx, cancel := context.WithTimeout(ctx, leaderboardOpTimeout)
 	defer cancel()
 
-	incrUpd := &crdt.Update{
-		Key:     crdt.PNCounterKey(counterKey(language, playerID)),
-		Initial: crdt.NewPNCounter(),
-		Modify: func(d crdt.ReplicatedData) crdt.ReplicatedData {
-			return d.(*crdt.PNCounter).Increment(l.nodeID(), 1)
-		},
+	ratings, err := l.ratings(cctx, language)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]LeaderboardEntry, 0, len(ratings))
+	for _, entry := range ratings {
+		if entry.Name == "" {
+			entry.Name = l.nameFor(entry.PlayerID)
+		}
+		entries = append(entries, entry)
 	}
 
-	if _, err := actor.Ask(cctx, rep, incrUpd, leaderboardOpTimeout); err != nil {
-		return fmt.Errorf("crdt increment win: %w", err)
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Rating != entries[j].Rating {
+			return entries[i].Rating > entries[j].Rating
+		}
+		return entries[i].PlayerID < entries[j].PlayerID
+	})
+
+	if n > 0 && len(entries) > n {
+		entries = entries[:n]
 	}
 
-	addUpd := &crdt.Update{
+	return entries, nil
+}
+
+func (l *Leaderboard) askProfile(ctx context.Context, playerID string, msg any) (*RatingReply, error) {
+	ident, err := profileGrain(ctx, l.system, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := l.system.AskGrain(ctx, ident, msg, leaderboardOpTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, ok := resp.(*RatingReply)
+	if !ok {
+		return nil, fmt.Errorf("unexpected profile reply type %T", resp)
+	}
+
+	return reply, nil
+}
+
+// index writes a player's new rating to the language's set, removing
+// the entry it supersedes.
+func (l *Leaderboard) index(ctx context.Context, language string, entry LeaderboardEntry) error {
+	if l.system.Replicator() == nil {
+		l.mu.Lock()
+		if l.fallback[language] == nil {
+			l.fallback[language] = make(map[string]LeaderboardEntry)
+		}
+		if current, ok := l.fallback[language][entry.PlayerID]; !ok || entry.Games >= current.Games {
+			l.fallback[language][entry.PlayerID] = entry
+		}
+		l.mu.Unlock()
+		return nil
+	}
+
+	element, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	upd := &crdt.Update{
 		Key:     crdt.ORSetKey(indexKey(language)),
 		Initial: crdt.NewORSet(),
 		Modify: func(d crdt.ReplicatedData) crdt.ReplicatedData {
-			return d.(*crdt.ORSet).Add(l.nodeID(), playerID)
+			set := d.(*crdt.ORSet)
+			for _, raw := range set.Elements() {
+				if old, ok := raw.(string); ok && entryPlayer(old) == entry.PlayerID {
+					set = set.Remove(old)
+				}
+			}
+			return set.Add(l.nodeID(), string(element))
 		},
 	}
 
-	if _, err := actor.Ask(cctx, rep, addUpd, leaderboardOpTimeout); err != nil {
-		return fmt.Errorf("crdt index player: %w", err)
+	if _, err := actor.Ask(ctx, l.system.Replicator(), upd, leaderboardOpTimeout); err != nil {
+		return fmt.Errorf("crdt index rating: %w", err)
 	}
 
 	return nil
 }
 
-// Top returns the top-n players for a language by win count, descending.
-func (l *Leaderboard) Top(ctx context.Context, language string, n int) ([]LeaderboardEntry, error) {
-	if l == nil {
-		return nil, nil
-	}
+// ratings returns each player's current rating in language, keyed by
+// player id. Two nodes rating the same player at once can leave both
+// entries in the set until the next update; the one with more games is
+// the newer.
+func (l *Leaderboard) ratings(ctx context.Context, language string) (map[string]LeaderboardEntry, error) {
+	if l.system.Replicator() == nil {
+		l.mu.Lock()
+		defer l.mu.Unlock()
 
-	if l.system == nil || l.system.Replicator() == nil {
-		return l.topFromFallback(language, n), nil
+		return maps.Clone(l.fallback[language]), nil
 	}
 
-	rep := l.system.Replicator()
-	cctx, cancel := context.WithTimeout(ctx, leaderboardOpTimeout)
-	defer cancel()
-
-	resp, err := actor.Ask(cctx, rep, &crdt.Get{Key: crdt.ORSetKey(indexKey(language))}, leaderboardOpTimeout)
+	resp, err := actor.Ask(ctx, l.system.Replicator(), &crdt.Get{Key: crdt.ORSetKey(indexKey(language))}, leaderboardOpTimeout)
 	if err != nil {
-		return nil, fmt.Errorf("crdt get player set: %w", err)
+		return nil, fmt.Errorf("crdt get ratings: %w", err)
 	}
 
 	setResp, ok := resp.(*crdt.GetResponse)
 	if !ok || setResp.Data == nil {
 		return nil, nil
 	}
-	set := setResp.Data.(*crdt.ORSet)
-
-	ids := set.Elements()
-	entries := make([]LeaderboardEntry, 0, len(ids))
 
-	for _, raw := range ids {
-		pid, ok := raw.(string)
+	ratings := make(map[string]LeaderboardEntry)
+	for _, raw := range setResp.Data.(*crdt.ORSet).Elements() {
+		element, ok := raw.(string)
 		if !ok {
 			continue
 		}
 
-		cresp, err := actor.Ask(cctx, rep,
-			&crdt.Get{Key: crdt.PNCounterKey(counterKey(language, pid))},
-			leaderboardOpTimeout)
-		if err != nil {
-			continue
+		var entry LeaderboardEntry
+		if err := json.Unmarshal([]byte(element), &entry); err != nil {
+			return nil, fmt.Errorf("decode rating: %w", err)
 		}
 
-		cnt, _ := cresp.(*crdt.GetResponse)
-		var wins int64
-		if cnt != nil && cnt.Data != nil {
-			wins = cnt.Data.(*crdt.PNCounter).Value()
+		if current, ok := ratings[entry.PlayerID]; !ok || entry.Games > current.Games {
+			ratings[entry.PlayerID] = entry
 		}
-
-		entries = append(entries, LeaderboardEntry{
-			PlayerID: pid,
-			Name:     l.nameFor(pid),
-			Wins:     wins,
-		})
 	}
 
-	sort.Slice(entries, func(i, j int) bool { return entries[i].Wins > entries[j].Wins })
+	return ratings, nil
+}
 
-	if n > 0 && len(entries) > n {
-		entries = entries[:n]
+// entryPlayer returns the player id of a JSON-encoded LeaderboardEntry.
+func entryPlayer(element string) string {
+	var entry LeaderboardEntry
+	if err := json.Unmarshal([]byte(element), &entry); err != nil {
+		return ""
 	}
 
-	return entries, nil
+	return entry.PlayerID
 }
 
 func (l *Leaderboard) nameFor(pid string) string {
@@ -212,34 +335,6 @@ func (l *Leaderboard) nameFor(pid string) string {
 	return pid
 }
 
-func (l *Leaderboard) topFromFallback(language string, n int) []LeaderboardEntry {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	prefix := language + "|"
-	entries := make([]LeaderboardEntry, 0, len(l.fallback))
-
-	for key, wins := range l.fallback {
-		if len(key) <= len(prefix) || key[:len(prefix)] != prefix {
-			continue
-		}
-		pid := key[len(prefix):]
-		entries = append(entries, LeaderboardEntry{
-			PlayerID: pid,
-			Name:     l.names[pid],
-			Wins:     wins,
-		})
-	}
-
-	sort.Slice(entries, func(i, j int) bool { return entries[i].Wins > entries[j].Wins })
-
-	if n > 0 && len(entries) > n {
-		entries = entries[:n]
-	}
-
-	return entries
-}
-
 func (l *Leaderboard) nodeID() string {
 	if l.system == nil {
 		return "local"
@@ -248,14 +343,6 @@ func (l *Leaderboard) nodeID() string {
 	return fmt.Sprintf("%s:%d", l.system.Host(), l.system.Port())
 }
 
-func counterKey(language, playerID string) string {
-	return LeaderboardKeyPrefix + language + "." + playerID
-}
-
 func indexKey(language string) string {
-	return playerSetKeyPrefix + language
-}
-
-func fallbackKey(language, playerID string) string {
-	return language + "|" + playerID
+	return ratingSetKeyPrefix + language
 }
@@ -0,0 +1,147 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tochemey/goakt/v4/actor"
+)
+
+// lexiconReloadTimeout bounds loading and building one wordlist on a
+// node that follows a published swap.
+const lexiconReloadTimeout = time.Minute
+
+// LexiconActor runs on every node and applies the lexicon swaps
+// published on LexiconTopic, so a reload requested on one pod reaches
+// the whole cluster. Each node loads the wordlist from its own
+// dictionary sources.
+type LexiconActor struct{}
+
+var _ actor.Actor = (*LexiconActor)(nil)
+
+func (*LexiconActor) PreStart(*actor.Context) error { return nil }
+func (*LexiconActor) PostStop(*actor.Context) error { return nil }
+
+func (l *LexiconActor) Receive(ctx *actor.ReceiveContext) {
+	switch msg := ctx.Message().(type) {
+	case *actor.PostStart:
+		if topic := ctx.ActorSystem().TopicActor(); topic != nil {
+			ctx.Tell(topic, actor.NewSubscribe(LexiconTopic))
+		}
+
+	case *actor.SubscribeAck, *actor.UnsubscribeAck:
+
+	case *ReloadLexicon:
+		l.reload(ctx, msg)
+
+	default:
+		ctx.Unhandled()
+	}
+}
+
+// reload makes msg.Lexicon current on this node. The node that served
+// the admin request has already swapped and skips it.
+func (l *LexiconActor) reload(ctx *actor.ReceiveContext, msg *ReloadLexicon) {
+	registry := registryFromExtension(ctx.ActorSystem())
+	if registry == nil {
+		return
+	}
+
+	current, err := registry.Get(msg.Language)
+	if err != nil || current.Lexicon == msg.Lexicon {
+		return
+	}
+
+	_, version, _ := strings.Cut(msg.Lexicon, ":")
+
+	rctx, cancel := context.WithTimeout(ctx.Context(), lexiconReloadTimeout)
+	defer cancel()
+
+	bundle, previous, err := registry.Reload(rctx, msg.Language, version)
+	if err != nil {
+		ctx.Logger().Errorf("lexicon %s: %v", msg.Lexicon, err)
+		return
+	}
+
+	ctx.Logger().Infof("language %s now uses lexicon %s (%d words), was %s", msg.Language, bundle.Lexicon, bundle.Dawg.Size(), previous)
+}
+
+// lexiconReply answers a reload request.
+type lexiconReply struct {
+	Language string `json:"language"`
+	Lexicon  string `json:"lexicon"`
+	Previous string `json:"previous"`
+	Words    int    `json:"words"`
+}
+
+// lexiconHandler serves POST /admin/lexicons/{lang}. It makes the
+// newest wordlist for the language, or the one named by ?version=, the
+// current lexicon on this node, then publishes the swap so every other
+// node follows. Requests must carry "Authorization: Bearer <token>".
+func lexiconHandler(system actor.ActorSystem, registry *Registry, token string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		auth := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(auth, []byte("Bearer "+token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		code := strings.ToLower(r.PathValue("lang"))
+		if _, err := registry.Get(code); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		bundle, previous, err := registry.Reload(r.Context(), code, strings.TrimSpace(r.URL.Query().Get("version")))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if topic := system.TopicActor(); topic != nil {
+			publish := actor.NewPublish(uuid.NewString(), LexiconTopic, &ReloadLexicon{Language: code, Lexicon: bundle.Lexicon})
+			_ = actor.Tell(r.Context(), topic, publish)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(lexiconReply{
+			Language: code,
+			Lexicon:  bundle.Lexicon,
+			Previous: previous,
+			Words:    bundle.Dawg.Size(),
+		})
+	}
+}
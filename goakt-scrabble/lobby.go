@@ -23,20 +23,43 @@
 package main
 
 import (
+	"cmp"
 	"errors"
 	"math/rand/v2"
 	"slices"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/tochemey/goakt/v4/actor"
 	gerrors "github.com/tochemey/goakt/v4/errors"
 )
 
 // LobbyActor is the cluster-singleton entry point. Owns the code → room
 // name directory; on JoinOrCreate it either returns an existing entry
-// or SpawnOns a new RoomActor on the least-loaded peer.
+// or SpawnOns a new RoomActor on the least-loaded peer. A requested code
+// whose room is gone but which has a snapshot in the roomStore is
+// respawned the same way; the new RoomActor rehydrates the game.
+// Spectators only ever join a room that exists. The lobby never reads
+// the roomStore itself: the gateway sends what it holds for a code
+// along with the request, and new codes are checked against the
+// directory.
+//
+// The lobby also keeps an index of every room's last RoomStatus, which
+// backs the room browser and quick play. Rooms push those reports
+// themselves; a lobby started after a singleton failover publishes
+// RefreshRoomStatus on LobbyTopic and the running rooms answer.
 type LobbyActor struct {
 	rooms map[string]string
+
+	// index is code → the room's last report. The PID is kept only to
+	// match the Terminated of a room whose node died without a
+	// PostStop.
+	index map[string]*indexedRoom
+}
+
+type indexedRoom struct {
+	status RoomStatus
+	pid    *actor.PID
 }
 
 var _ actor.Actor = (*LobbyActor)(nil)
@@ -48,11 +71,31 @@ func (l *LobbyActor) Receive(ctx *actor.ReceiveContext) {
 	switch msg := ctx.Message().(type) {
 	case *actor.PostStart:
 		l.rooms = make(map[string]string)
+		l.index = make(map[string]*indexedRoom)
+		if topic := ctx.ActorSystem().TopicActor(); topic != nil {
+			ctx.Tell(topic, actor.NewPublish(uuid.NewString(), LobbyTopic, &RefreshRoomStatus{}))
+		}
 		ctx.Logger().Infof("lobby singleton ready on %s", ctx.Self().Path().HostPort())
 
 	case *JoinOrCreate:
 		l.handleJoinOrCreate(ctx, msg)
 
+	case *RoomStatus:
+		l.indexRoom(ctx, msg)
+
+	case *actor.Terminated:
+		// A room that stops cleanly reports Stopped first. One whose node
+		// died may have left a snapshot to resume, so its code stays taken
+		// in the directory; only the room browser drops it.
+		for code, room := range l.index {
+			if room.pid != nil && room.pid.Path().Equals(msg.ActorPath()) {
+				delete(l.index, code)
+			}
+		}
+
+	case *ListRooms:
+		ctx.Response(&RoomList{Rooms: l.publicRooms(strings.ToLower(msg.Language))})
+
 	default:
 		ctx.Unhandled()
 	}
@@ -73,14 +116,40 @@ func (l *LobbyActor) handleJoinOrCreate(ctx *actor.ReceiveContext, msg *JoinOrCr
 	}
 
 	code := strings.ToUpper(strings.TrimSpace(msg.Room))
+	if code == "" && msg.QuickPlay && !msg.Watch {
+		code = l.quickPlay(language)
+		// Should that find nothing to join, the room created below is
+		// public, so the next quick play can find it.
+		msg.Public = true
+	}
 
 	if code != "" {
 		if name, ok := l.rooms[code]; ok {
-			ctx.Response(&JoinOrCreateResult{RoomCode: code, RoomName: name})
+			if _, err := ctx.ActorSystem().ActorOf(ctx.Context(), name); err == nil {
+				ctx.Response(&JoinOrCreateResult{RoomCode: code, RoomName: name})
+				return
+			}
+			delete(l.rooms, code)
+		}
+
+		// The game keeps the language it was started in, whatever the
+		// reconnecting player asked for.
+		saved := msg.Saved && code == strings.ToUpper(strings.TrimSpace(msg.Room))
+		if saved && slices.Contains(registry.Codes(), msg.SavedLanguage) {
+			language = msg.SavedLanguage
+		}
+
+		if msg.Watch && !saved {
+			ctx.Response(&JoinOrCreateResult{Err: "no such room: " + code})
 			return
 		}
 	}
 
+	if code == "" && msg.Watch {
+		ctx.Response(&JoinOrCreateResult{Err: "a room code is required to watch"})
+		return
+	}
+
 	if code == "" {
 		code = l.generateCode()
 	}
@@ -108,16 +177,24 @@ func (l *LobbyActor) handleJoinOrCreate(ctx *actor.ReceiveContext, msg *JoinOrCr
 
 	l.rooms[code] = name
 
+	// Queued ahead of the creator's PlayerHello, which waits for the
+	// gateway to resolve the room. A room resumed from a snapshot keeps
+	// its own setting.
+	ctx.Tell(pid, &ConfigureRoom{Public: msg.Public})
+
 	placement := "local"
 	if pid != nil && pid.IsRemote() {
 		placement = "remote@" + pid.Path().HostPort()
 	}
-	ctx.Logger().Infof("lobby: spawned %s (%s) for player %s [%s]", name, placement, msg.PlayerName, language)
+	ctx.Logger().Infof("lobby: spawned %s (%s) for player %s [%s] public=%t",
+		name, placement, msg.PlayerName, language, msg.Public)
 	ctx.Response(&JoinOrCreateResult{RoomCode: code, RoomName: name})
 }
 
 // generateCode returns a 4-letter human-friendly room code. Excludes
 // visually-ambiguous characters so codes are easy to dictate over voice.
+// A code is taken while the directory holds it, which includes rooms
+// lost with their node that may still be resumed from a snapshot.
 func (l *LobbyActor) generateCode() string {
 	const alphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
 
@@ -140,3 +217,73 @@ func (l *LobbyActor) generateCode() string {
 
 	return string(code)
 }
+
+// indexRoom records a room's report. The first report from a room is
+// also when the lobby starts watching it, and puts it back in the
+// directory if this lobby has never seen it (a failover rebuild).
+func (l *LobbyActor) indexRoom(ctx *actor.ReceiveContext, status *RoomStatus) {
+	if status.Stopped {
+		l.forget(status.Code)
+		return
+	}
+
+	room, ok := l.index[status.Code]
+	if !ok {
+		room = &indexedRoom{pid: ctx.Sender()}
+		l.index[status.Code] = room
+		if room.pid != nil {
+			ctx.Watch(room.pid)
+		}
+	}
+	room.status = *status
+	l.rooms[status.Code] = status.Name
+}
+
+func (l *LobbyActor) forget(code string) {
+	delete(l.index, code)
+	delete(l.rooms, code)
+}
+
+// publicRooms lists the browsable rooms, optionally in one language:
+// rooms with a free seat first, fullest first, then by code so the
+// order is stable between polls. Games in progress stay listed for
+// spectators.
+func (l *LobbyActor) publicRooms(language string) []RoomStatus {
+	out := make([]RoomStatus, 0, len(l.index))
+	for _, room := range l.index {
+		if room.status.Public && (language == "" || room.status.Language == language) {
+			out = append(out, room.status)
+		}
+	}
+
+	slices.SortFunc(out, func(a, b RoomStatus) int {
+		if a.Joinable != b.Joinable {
+			if a.Joinable {
+				return -1
+			}
+			return 1
+		}
+		if a.Players != b.Players {
+			return cmp.Compare(b.Players, a.Players)
+		}
+		return cmp.Compare(a.Code, b.Code)
+	})
+	return out
+}
+
+// quickPlay picks the fullest public room in language with a free
+// seat, or "" if there is none. The seat is counted against the room
+// right away, so a burst of quick plays cannot overfill it before the
+// room's next report.
+func (l *LobbyActor) quickPlay(language string) string {
+	for _, status := range l.publicRooms(language) {
+		if !status.Joinable {
+			break
+		}
+		room := l.index[status.Code]
+		room.status.Players++
+		room.status.Joinable = room.status.Players < room.status.MaxPlayers
+		return status.Code
+	}
+	return ""
+}
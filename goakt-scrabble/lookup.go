@@ -0,0 +1,185 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/tochemey/goakt-examples/v2/goakt-scrabble/scrabble"
+)
+
+// Lookup kinds, shared by the HTTP API and the "lookup" WebSocket message.
+const (
+	LookupWord       = "word"
+	LookupAnagram    = "anagram"
+	LookupSubAnagram = "subanagram"
+	LookupPattern    = "pattern"
+	LookupHooks      = "hooks"
+)
+
+const (
+	// defaultLookupLimit and maxLookupLimit cap the words in one reply;
+	// a bare "*" pattern would otherwise return the whole dictionary.
+	defaultLookupLimit = 200
+	maxLookupLimit     = 2000
+
+	maxLookupQuery = 32
+
+	// maxLookupBlanks keeps an anagram search from walking most of the
+	// dictionary.
+	maxLookupBlanks = 2
+)
+
+var errUnknownLookup = errors.New("unknown lookup kind")
+
+// LookupResult answers one word-tool query. Valid is set for word
+// checks; Words holds anagram and pattern matches (blank letters in
+// lowercase) and Front/Back hold hook tiles. Truncated reports that
+// Words was cut at the limit.
+type LookupResult struct {
+	Kind      string   `json:"kind"`
+	Query     string   `json:"query"`
+	Valid     bool     `json:"valid"`
+	Words     []string `json:"words,omitempty"`
+	Front     []string `json:"front,omitempty"`
+	Back      []string `json:"back,omitempty"`
+	Truncated bool     `json:"truncated,omitempty"`
+}
+
+// lookup runs a word-tool query against one language bundle. Every node
+// holds every bundle in its Registry, so lookups never leave the node
+// that receives them.
+func lookup(bundle *LangBundle, kind, query string, limit int) (*LookupResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" || len([]rune(query)) > maxLookupQuery {
+		return nil, errors.New("query must be 1 to 32 characters")
+	}
+
+	if limit <= 0 {
+		limit = defaultLookupLimit
+	}
+	limit = min(limit, maxLookupLimit)
+
+	lang, dawg := bundle.Lang, bundle.Dawg
+	result := &LookupResult{Kind: kind, Query: query}
+
+	switch kind {
+	case LookupWord, LookupHooks:
+		word, err := lang.NormalizeWord(query)
+		if err != nil {
+			return nil, err
+		}
+		result.Query = lang.String(word)
+		result.Valid = dawg.Contains(word)
+		if kind == LookupHooks {
+			front, back := scrabble.Hooks(dawg, word)
+			result.Front = letterFaces(front, lang)
+			result.Back = letterFaces(back, lang)
+		}
+
+	case LookupAnagram, LookupSubAnagram:
+		rack, err := scrabble.ParseRack(lang, query)
+		if err != nil {
+			return nil, err
+		}
+		if blankCount(rack) > maxLookupBlanks {
+			return nil, errors.New("at most 2 blanks per rack")
+		}
+		if kind == LookupAnagram {
+			result.Words = scrabble.Anagrams(dawg, lang, rack)
+		} else {
+			result.Words = scrabble.SubAnagrams(dawg, lang, rack)
+		}
+
+	case LookupPattern:
+		// Ask for one extra match to learn whether the list was cut.
+		words, err := scrabble.MatchPattern(dawg, lang, query, limit+1)
+		if err != nil {
+			return nil, err
+		}
+		result.Words = words
+
+	default:
+		return nil, errUnknownLookup
+	}
+
+	if len(result.Words) > limit {
+		result.Words = result.Words[:limit]
+		result.Truncated = true
+	}
+
+	return result, nil
+}
+
+func blankCount(tiles []scrabble.Tile) int {
+	n := 0
+
+	for _, tile := range tiles {
+		if tile.Blank {
+			n++
+		}
+	}
+
+	return n
+}
+
+func letterFaces(ids []scrabble.LetterID, lang *scrabble.Language) []string {
+	out := make([]string, len(ids))
+
+	for i, id := range ids {
+		out[i] = lang.Letter(id)
+	}
+
+	return out
+}
+
+// lookupHandler serves GET /api/{lang}/{kind}?q=<query>&limit=<n> as
+// JSON, e.g. /api/en/anagram?q=AEINST? or /api/en/pattern?q=?A?E*.
+func lookupHandler(registry *Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bundle, err := registry.Get(strings.ToLower(r.PathValue("lang")))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		result, err := lookup(bundle, r.PathValue("kind"), r.URL.Query().Get("q"), limit)
+		switch {
+		case errors.Is(err, errUnknownLookup):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+	}
+}
@@ -23,7 +23,7 @@
 package main
 
 import (
-	"bytes"
+	"cmp"
 	"context"
 	"embed"
 	"errors"
@@ -33,11 +33,14 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/tochemey/goakt/v4/actor"
 	"github.com/tochemey/goakt/v4/discovery/kubernetes"
 	gerrors "github.com/tochemey/goakt/v4/errors"
@@ -63,7 +66,10 @@ const (
 //go:embed web/index.html web/main.js
 var webFS embed.FS
 
-//go:embed dict/en.txt
+// dict holds the bundled wordlists, and their compiled DAWGs once
+// `make dawg` has run.
+//
+//go:embed dict
 var dictFS embed.FS
 
 var (
@@ -74,7 +80,11 @@ var (
 	peersPort     = flag.Int("peers-port", 9002, "Cluster peer state-sync port")
 	namespace     = flag.String("namespace", "", "Kubernetes namespace this pod runs in (defaults to $POD_NAMESPACE)")
 	appLabel      = flag.String("app-label", "scrabble", "Value of the 'app' pod label used to match cluster peers")
-	databaseURL   = flag.String("database-url", "", "Postgres DSN for the profile store (defaults to $DATABASE_URL; in-memory fallback if unset)")
+	databaseURL   = flag.String("database-url", "", "Postgres DSN for the profile and room stores (defaults to $DATABASE_URL; in-memory fallback if unset)")
+	layoutsDir    = flag.String("layouts", "", "Directory of custom board layouts (*.txt, one per file, named after the file)")
+	dictDir       = flag.String("dict-dir", "", "Directory of <code>.txt wordlists that take precedence over the bundled ones")
+	dictPostgres  = flag.Bool("dict-postgres", false, "Load wordlists from the lexicons table of the --database-url database first")
+	adminToken    = flag.String("admin-token", "", "Bearer token for the /admin endpoints (defaults to $ADMIN_TOKEN; disabled if unset)")
 )
 
 const profileStoreInitTimeout = 10 * time.Second
@@ -84,10 +94,11 @@ func main() {
 	ctx := context.Background()
 	logger := log.DefaultLogger
 
-	registry, err := buildRegistry(logger)
+	registry, closeDict, err := buildRegistry(ctx, logger)
 	if err != nil {
 		logger.Fatal(err)
 	}
+	defer closeDict()
 
 	store, closeStore, err := buildProfileStore(ctx, logger)
 	if err != nil {
@@ -95,9 +106,16 @@ func main() {
 	}
 	defer closeStore()
 
+	rooms, closeRooms, err := buildRoomStore(ctx, logger)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	defer closeRooms()
+
 	leaderboard := NewLeaderboard()
+	archive := NewGameArchive()
 
-	system, err := buildActorSystem(logger, registry, store, leaderboard)
+	system, err := buildActorSystem(logger, registry, store, rooms, leaderboard, archive)
 	if err != nil {
 		logger.Fatal(err)
 	}
@@ -119,6 +137,10 @@ func main() {
 		logger.Info("not the cluster leader; lobby singleton is hosted on another node")
 	}
 
+	if _, err := system.Spawn(ctx, LexiconActorPrefix+uuid.NewString(), new(LexiconActor), actor.WithLongLived()); err != nil {
+		logger.Fatal(err)
+	}
+
 	web, err := fs.Sub(webFS, "web")
 	if err != nil {
 		logger.Fatal(err)
@@ -129,6 +151,12 @@ func main() {
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/ws", wsHandler(system, leaderboard, drainCtx, &wsHandlers, logger))
+	mux.HandleFunc("GET /rooms", roomsHandler(system))
+	mux.HandleFunc("GET /games/{code}/{game}", gcgHandler(archive))
+	mux.HandleFunc("GET /api/{lang}/{kind}", lookupHandler(registry))
+	if token := cmp.Or(strings.TrimSpace(*adminToken), os.Getenv("ADMIN_TOKEN")); token != "" {
+		mux.HandleFunc("POST /admin/lexicons/{lang}", lexiconHandler(system, registry, token))
+	}
 	mux.Handle("/", noStore(http.FileServer(http.FS(web))))
 
 	addr := fmt.Sprintf(":%d", *httpPort)
@@ -175,34 +203,118 @@ func main() {
 	}
 }
 
-// buildRegistry loads each bundled wordlist into a per-language DAWG.
-// New languages are added by dropping `dict/<code>.txt` and registering
-// here.
-func buildRegistry(logger log.Logger) (*Registry, error) {
-	registry := NewRegistry()
+// buildRegistry loads a DAWG per language from the dictionary sources:
+// the lexicons table with --dict-postgres, then --dict-dir, then the
+// bundled `dict/<code>.txt`. Every built-in scrabble.Languages() entry
+// is registered when some source has a wordlist for it; languages
+// without one are skipped so the lobby never offers a game no word
+// could be played in. English is mandatory. The returned func closes
+// the Postgres source, if any.
+func buildRegistry(ctx context.Context, logger log.Logger) (*Registry, func(), error) {
+	var (
+		sources []dictSource
+		closer  = func() {}
+	)
+
+	if *dictPostgres {
+		dsn := databaseDSN()
+		if dsn == "" {
+			return nil, nil, errors.New("--dict-postgres needs --database-url or $DATABASE_URL")
+		}
 
-	english, err := loadBundle(scrabble.English(), "dict/en.txt")
+		initCtx, cancel := context.WithTimeout(ctx, profileStoreInitTimeout)
+		pg, err := newPgDictSource(initCtx, dsn)
+		cancel()
+		if err != nil {
+			return nil, nil, fmt.Errorf("postgres dictionary source: %w", err)
+		}
+
+		sources = append(sources, pg)
+		closer = pg.Close
+	}
+
+	if *dictDir != "" {
+		sources = append(sources, fsDictSource{fsys: os.DirFS(*dictDir)})
+	}
+
+	bundled, err := fs.Sub(dictFS, "dict")
 	if err != nil {
-		return nil, fmt.Errorf("english: %w", err)
+		return nil, nil, err
+	}
+	sources = append(sources, fsDictSource{fsys: bundled})
+
+	registry := NewRegistry(sources...)
+
+	if err := loadLanguages(ctx, registry, logger); err != nil {
+		closer()
+		return nil, nil, err
+	}
+
+	if err := loadLayouts(registry, *layoutsDir, logger); err != nil {
+		closer()
+		return nil, nil, err
 	}
-	registry.Add(english)
-	logger.Infof("loaded language en (%d words)", english.Dawg.Size())
 
-	return registry, nil
+	return registry, closer, nil
 }
 
-func loadBundle(lang *scrabble.Language, path string) (*LangBundle, error) {
-	data, err := dictFS.ReadFile(path)
-	if err != nil {
-		return nil, err
+// loadLanguages registers a bundle for every ruleset in
+// scrabble.Languages that some dictionary source has a wordlist for.
+// Only English ships one, and it is required; any other ruleset without
+// a wordlist cannot be played, which is logged as a warning naming the
+// file that would enable it.
+func loadLanguages(ctx context.Context, registry *Registry, logger log.Logger) error {
+	var missing []string
+
+	for _, lang := range scrabble.Languages() {
+		bundle, err := registry.Load(ctx, lang, "")
+		if errors.Is(err, fs.ErrNotExist) && lang.Code != defaultLanguageCode {
+			logger.Warnf("language %s (%s) is NOT playable: no wordlist in any dictionary source; add %s.txt to --dict-dir or load it with --dict-postgres",
+				lang.Code, lang.Name, lang.Code)
+			missing = append(missing, lang.Code)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", lang.Name, err)
+		}
+
+		registry.Add(bundle)
+		logger.Infof("loaded language %s, lexicon %s (%d words)", lang.Code, bundle.Lexicon, bundle.Dawg.Size())
+	}
+
+	if len(missing) > 0 {
+		playable := registry.Codes()
+		slices.Sort(playable)
+		logger.Warnf("playable languages: %s; rulesets without a wordlist: %s",
+			strings.Join(playable, ", "), strings.Join(missing, ", "))
 	}
 
-	dawg, err := scrabble.BuildDAWG(lang, bytes.NewReader(data))
+	return nil
+}
+
+// loadLayouts registers every *.txt board layout in dir. A layout that
+// fails to parse is a hard error, like a broken wordlist.
+func loadLayouts(registry *Registry, dir string, logger log.Logger) error {
+	if dir == "" {
+		return nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.txt"))
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	for _, path := range paths {
+		layout, err := scrabble.LoadLayout(path)
+		if err != nil {
+			return fmt.Errorf("layout %s: %w", path, err)
+		}
+
+		registry.AddLayout(layout)
+		logger.Infof("loaded board layout %s (%dx%d)", layout.Name, layout.Size(), layout.Size())
 	}
 
-	return &LangBundle{Lang: lang, Dawg: dawg}, nil
+	return nil
 }
 
 // buildProfileStore picks a profile-store backend. If --database-url
@@ -211,11 +323,7 @@ func loadBundle(lang *scrabble.Language, path string) (*LangBundle, error) {
 // Otherwise it returns the in-memory store. A non-empty DSN that fails
 // to connect is a hard error; we don't silently degrade to in-memory.
 func buildProfileStore(ctx context.Context, logger log.Logger) (profileStore, func(), error) {
-	dsn := strings.TrimSpace(*databaseURL)
-	if dsn == "" {
-		dsn = os.Getenv("DATABASE_URL")
-	}
-
+	dsn := databaseDSN()
 	if dsn == "" {
 		logger.Info("profile store: using in-memory backend (set DATABASE_URL for Postgres)")
 		return newMemProfileStore(), func() {}, nil
@@ -234,12 +342,47 @@ func buildProfileStore(ctx context.Context, logger log.Logger) (profileStore, fu
 	return pg, pg.Close, nil
 }
 
-func buildActorSystem(logger log.Logger, registry *Registry, store profileStore, leaderboard *Leaderboard) (actor.ActorSystem, error) {
+// buildRoomStore picks the room-snapshot backend the same way
+// buildProfileStore does. Only Postgres lets a game survive its pod.
+func buildRoomStore(ctx context.Context, logger log.Logger) (roomStore, func(), error) {
+	dsn := databaseDSN()
+	if dsn == "" {
+		logger.Info("room store: using in-memory backend (set DATABASE_URL for Postgres)")
+		return newMemRoomStore(), func() {}, nil
+	}
+
+	initCtx, cancel := context.WithTimeout(ctx, profileStoreInitTimeout)
+	defer cancel()
+
+	pg, err := newPgRoomStore(initCtx, dsn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("postgres room store: %w", err)
+	}
+
+	logger.Info("room store: using Postgres backend")
+
+	return pg, pg.Close, nil
+}
+
+func databaseDSN() string {
+	if dsn := strings.TrimSpace(*databaseURL); dsn != "" {
+		return dsn
+	}
+
+	return os.Getenv("DATABASE_URL")
+}
+
+func buildActorSystem(logger log.Logger, registry *Registry, store profileStore, rooms roomStore, leaderboard *Leaderboard, archive *GameArchive) (actor.ActorSystem, error) {
 	cbor := remote.NewCBORSerializer()
 
 	remoteCfg := remoting.NewConfig(*bindHost, *remotingPort,
 		remote.WithSerializers((*JoinOrCreate)(nil), cbor),
 		remote.WithSerializers((*JoinOrCreateResult)(nil), cbor),
+		remote.WithSerializers((*ConfigureRoom)(nil), cbor),
+		remote.WithSerializers((*RoomStatus)(nil), cbor),
+		remote.WithSerializers((*RefreshRoomStatus)(nil), cbor),
+		remote.WithSerializers((*ListRooms)(nil), cbor),
+		remote.WithSerializers((*RoomList)(nil), cbor),
 		remote.WithSerializers((*PlayerHello)(nil), cbor),
 		remote.WithSerializers((*GoodbyePlayer)(nil), cbor),
 		remote.WithSerializers((*PlayerInput)(nil), cbor),
@@ -248,13 +391,20 @@ func buildActorSystem(logger log.Logger, registry *Registry, store profileStore,
 		remote.WithSerializers((*JoinedEvent)(nil), cbor),
 		remote.WithSerializers((*StateEvent)(nil), cbor),
 		remote.WithSerializers((*MoveEvent)(nil), cbor),
+		remote.WithSerializers((*ChallengeEvent)(nil), cbor),
+		remote.WithSerializers((*HintEvent)(nil), cbor),
 		remote.WithSerializers((*ChatEvent)(nil), cbor),
 		remote.WithSerializers((*ErrorEvent)(nil), cbor),
 		remote.WithSerializers((*GameOverEvent)(nil), cbor),
+		remote.WithSerializers((*AnalysisEvent)(nil), cbor),
 		remote.WithSerializers((*GetProfile)(nil), cbor),
 		remote.WithSerializers((*RecordGame)(nil), cbor),
+		remote.WithSerializers((*GetRating)(nil), cbor),
+		remote.WithSerializers((*RateGame)(nil), cbor),
+		remote.WithSerializers((*RatingReply)(nil), cbor),
 		remote.WithSerializers((*SetName)(nil), cbor),
 		remote.WithSerializers((*ProfileView)(nil), cbor),
+		remote.WithSerializers((*ReloadLexicon)(nil), cbor),
 	)
 
 	ns := strings.TrimSpace(*namespace)
@@ -289,7 +439,7 @@ func buildActorSystem(logger log.Logger, registry *Registry, store profileStore,
 		actor.WithRemote(remoteCfg),
 		actor.WithCluster(clusterCfg),
 		actor.WithPubSub(),
-		actor.WithExtensions(registry, store, leaderboard),
+		actor.WithExtensions(registry, store, rooms, leaderboard, archive),
 	)
 }
 
@@ -24,20 +24,27 @@ package main
 
 import (
 	"context"
+	"maps"
+	"math"
 	"strings"
 	"sync"
 
 	"github.com/tochemey/goakt/v4/actor"
 	"github.com/tochemey/goakt/v4/extension"
+
+	"github.com/tochemey/goakt-examples/v2/goakt-scrabble/scrabble"
 )
 
 const ProfileStoreExtensionID = "scrabble_profile_store"
 
+// profileSnapshot is a player's persisted profile. Ratings is keyed by
+// language code.
 type profileSnapshot struct {
 	Name        string
 	GamesPlayed int
 	Wins        int
 	TotalScore  int
+	Ratings     map[string]PlayerRating
 }
 
 // profileStore is the backing for PlayerProfileGrain. Two implementations
@@ -79,6 +86,8 @@ func (s *memProfileStore) Load(_ context.Context, id string) (profileSnapshot, b
 }
 
 func (s *memProfileStore) Save(_ context.Context, id string, snap profileSnapshot) error {
+	snap.Ratings = maps.Clone(snap.Ratings)
+
 	s.mu.Lock()
 	s.data[id] = snap
 	s.mu.Unlock()
@@ -98,7 +107,20 @@ func profileStoreFromExtension(system actor.ActorSystem) profileStore {
 	return nil
 }
 
-// PlayerProfileGrain is one virtual actor per player id.
+// profileGrain returns the identity of a player's PlayerProfileGrain,
+// activating it on some node of the cluster if need be.
+func profileGrain(ctx context.Context, system actor.ActorSystem, playerID string) (*actor.GrainIdentity, error) {
+	store := profileStoreFromExtension(system)
+
+	return system.GrainIdentity(ctx, GrainPrefix+playerID,
+		func(_ context.Context) (actor.Grain, error) {
+			return &PlayerProfileGrain{store: store}, nil
+		})
+}
+
+// PlayerProfileGrain is one virtual actor per player id. Its single
+// activation is the only writer of the player's ratings, which is what
+// keeps them consistent across nodes.
 type PlayerProfileGrain struct {
 	store profileStore
 	id    string
@@ -132,16 +154,47 @@ func (g *PlayerProfileGrain) OnDeactivate(ctx context.Context, props *actor.Grai
 	return nil
 }
 
+// rating returns the player's rating in language, a new player's if
+// they have never played a rated game in it.
+func (g *PlayerProfileGrain) rating(language string) PlayerRating {
+	if rating, ok := g.state.Ratings[language]; ok {
+		return rating
+	}
+
+	return PlayerRating{Rating: scrabble.NewRating()}
+}
+
 func (g *PlayerProfileGrain) OnReceive(ctx *actor.GrainContext) {
 	switch msg := ctx.Message().(type) {
 	case *GetProfile:
+		ratings := make(map[string]int, len(g.state.Ratings))
+		for language, rating := range g.state.Ratings {
+			ratings[language] = int(math.Round(rating.Rating.R))
+		}
 		ctx.Response(&ProfileView{
 			PlayerID:    g.id,
 			Name:        g.state.Name,
 			GamesPlayed: g.state.GamesPlayed,
 			Wins:        g.state.Wins,
 			TotalScore:  g.state.TotalScore,
+			Ratings:     ratings,
 		})
+	case *GetRating:
+		ctx.Response(&RatingReply{Name: g.state.Name, Rating: g.rating(msg.Language)})
+	case *RateGame:
+		rating := g.rating(msg.Language)
+		rating.Rating = rating.Rating.Update(msg.Opponents)
+		rating.Games++
+		if g.state.Ratings == nil {
+			g.state.Ratings = make(map[string]PlayerRating)
+		}
+		g.state.Ratings[msg.Language] = rating
+		// Save now rather than on deactivation, so a rating survives a
+		// node that goes down before the grain is passivated.
+		if err := g.store.Save(ctx.Context(), g.id, g.state); err != nil {
+			ctx.ActorSystem().Logger().Warnf("profile save failed for %s: %v", g.id, err)
+		}
+		ctx.Response(&RatingReply{Name: g.state.Name, Rating: rating})
 	case *SetName:
 		g.state.Name = msg.Name
 		ctx.NoErr()
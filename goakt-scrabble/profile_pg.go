@@ -24,6 +24,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -48,7 +49,8 @@ CREATE TABLE IF NOT EXISTS player_profiles (
     wins         INTEGER     NOT NULL DEFAULT 0,
     total_score  INTEGER     NOT NULL DEFAULT 0,
     updated_at   TIMESTAMPTZ NOT NULL DEFAULT now()
-);`
+);
+ALTER TABLE player_profiles ADD COLUMN IF NOT EXISTS ratings JSONB NOT NULL DEFAULT '{}';`
 
 // newPgProfileStore connects to Postgres using the libpq-style URL,
 // runs the idempotent schema migration, and returns a store ready
@@ -81,32 +83,48 @@ func (s *pgProfileStore) Close() {
 }
 
 func (s *pgProfileStore) Load(ctx context.Context, id string) (profileSnapshot, bool, error) {
-	const q = `SELECT name, games_played, wins, total_score FROM player_profiles WHERE id = $1`
+	const q = `SELECT name, games_played, wins, total_score, ratings FROM player_profiles WHERE id = $1`
 
-	var snap profileSnapshot
-	err := s.pool.QueryRow(ctx, q, id).Scan(&snap.Name, &snap.GamesPlayed, &snap.Wins, &snap.TotalScore)
+	var (
+		snap    profileSnapshot
+		ratings []byte
+	)
+	err := s.pool.QueryRow(ctx, q, id).Scan(&snap.Name, &snap.GamesPlayed, &snap.Wins, &snap.TotalScore, &ratings)
 	switch {
 	case errors.Is(err, pgx.ErrNoRows):
 		return profileSnapshot{}, false, nil
 	case err != nil:
 		return profileSnapshot{}, false, err
-	default:
-		return snap, true, nil
 	}
+
+	if err := json.Unmarshal(ratings, &snap.Ratings); err != nil {
+		return profileSnapshot{}, false, fmt.Errorf("decode ratings: %w", err)
+	}
+
+	return snap, true, nil
 }
 
 func (s *pgProfileStore) Save(ctx context.Context, id string, snap profileSnapshot) error {
 	const q = `
-INSERT INTO player_profiles (id, name, games_played, wins, total_score, updated_at)
-VALUES ($1, $2, $3, $4, $5, now())
+INSERT INTO player_profiles (id, name, games_played, wins, total_score, ratings, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, now())
 ON CONFLICT (id) DO UPDATE SET
     name         = EXCLUDED.name,
     games_played = EXCLUDED.games_played,
     wins         = EXCLUDED.wins,
     total_score  = EXCLUDED.total_score,
+    ratings      = EXCLUDED.ratings,
     updated_at   = now();`
 
-	_, err := s.pool.Exec(ctx, q, id, snap.Name, snap.GamesPlayed, snap.Wins, snap.TotalScore)
+	ratings, err := json.Marshal(snap.Ratings)
+	if err != nil {
+		return fmt.Errorf("encode ratings: %w", err)
+	}
+	if snap.Ratings == nil {
+		ratings = []byte("{}")
+	}
+
+	_, err = s.pool.Exec(ctx, q, id, snap.Name, snap.GamesPlayed, snap.Wins, snap.TotalScore, ratings)
 
 	return err
 }
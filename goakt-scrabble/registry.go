@@ -23,7 +23,15 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/tochemey/goakt/v4/actor"
 	"github.com/tochemey/goakt/v4/extension"
@@ -33,32 +41,113 @@ import (
 
 const RegistryExtensionID = "scrabble_registry"
 
-// LangBundle pairs a Language with its loaded DAWG.
+// LangBundle pairs a Language with its loaded DAWG. Lexicon names the
+// wordlist version the DAWG was built from, "<code>:<version>".
 type LangBundle struct {
-	Lang *scrabble.Language
-	Dawg *scrabble.DAWG
+	Lang    *scrabble.Language
+	Dawg    *scrabble.DAWG
+	Lexicon string
 }
 
-// Registry holds the loaded language bundles. Cluster-spawned RoomActors
-// fetch their bundle via ctx.Extension(RegistryExtensionID).
+// Registry holds the loaded language bundles and board layouts.
+// Cluster-spawned RoomActors fetch their bundle via
+// ctx.Extension(RegistryExtensionID). Every node must load the same
+// custom layouts, since a room can be rehydrated on any of them.
+//
+// Each language has a current bundle, which Reload swaps atomically
+// for a newer lexicon. A game keeps the lexicon it started with, so a
+// room holds its bundle with Acquire and Release; a superseded bundle
+// stays in lexicons only while some room on this node still plays it.
+// Lexicon fetches a version this node has not loaded, or has dropped,
+// from the dictionary sources, for a game rehydrated here.
 type Registry struct {
-	bundles map[string]*LangBundle
+	mu       sync.RWMutex
+	bundles  map[string]*LangBundle
+	lexicons map[string]*LangBundle
+	users    map[string]int
+	layouts  map[string]*scrabble.Layout
+	sources  []dictSource
 }
 
 var _ extension.Extension = (*Registry)(nil)
 
-func NewRegistry() *Registry {
-	return &Registry{bundles: make(map[string]*LangBundle)}
+// NewRegistry returns a Registry that loads wordlists from sources,
+// trying them in order.
+func NewRegistry(sources ...dictSource) *Registry {
+	registry := &Registry{
+		bundles:  make(map[string]*LangBundle),
+		lexicons: make(map[string]*LangBundle),
+		users:    make(map[string]int),
+		layouts:  make(map[string]*scrabble.Layout),
+		sources:  sources,
+	}
+
+	for _, layout := range scrabble.Layouts() {
+		registry.AddLayout(layout)
+	}
+
+	return registry
 }
 
 func (r *Registry) ID() string { return RegistryExtensionID }
 
+// Add makes bundle the current one for its language.
 func (r *Registry) Add(bundle *LangBundle) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	r.bundles[bundle.Lang.Code] = bundle
+	r.lexicons[bundle.Lexicon] = bundle
+}
+
+// Acquire records that a room is playing bundle, keeping it loaded
+// until the room calls Release, even once a reload supersedes it.
+func (r *Registry) Acquire(bundle *LangBundle) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.users[bundle.Lexicon]++
+	if _, ok := r.lexicons[bundle.Lexicon]; !ok {
+		r.lexicons[bundle.Lexicon] = bundle
+	}
+}
+
+// Release ends a room's use of bundle. A superseded lexicon that no
+// room plays any more is dropped.
+func (r *Registry) Release(bundle *LangBundle) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.users[bundle.Lexicon] > 1 {
+		r.users[bundle.Lexicon]--
+		return
+	}
+
+	delete(r.users, bundle.Lexicon)
+	r.evict(bundle.Lexicon)
+}
+
+// evict drops a lexicon that is neither current for its language nor
+// played by any room. The caller holds mu.
+func (r *Registry) evict(name string) {
+	bundle, ok := r.lexicons[name]
+	if !ok || r.users[name] > 0 {
+		return
+	}
+
+	if current, ok := r.bundles[bundle.Lang.Code]; ok && current.Lexicon == name {
+		return
+	}
+
+	delete(r.lexicons, name)
 }
 
-// Get returns the language bundle for code, or an error if unregistered.
+// Get returns the current language bundle for code, or an error if
+// unregistered.
 func (r *Registry) Get(code string) (*LangBundle, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	bundle, ok := r.bundles[code]
 	if !ok {
 		return nil, fmt.Errorf("scrabble: language %q not registered", code)
@@ -70,6 +159,9 @@ func (r *Registry) Get(code string) (*LangBundle, error) {
 // Codes returns the registered language codes in arbitrary order. Used
 // to validate JoinOrCreate.Language at lobby time.
 func (r *Registry) Codes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	out := make([]string, 0, len(r.bundles))
 
 	for code := range r.bundles {
@@ -79,6 +171,135 @@ func (r *Registry) Codes() []string {
 	return out
 }
 
+// Load builds the bundle for lang from the first source holding it, at
+// version or, when version is empty, the newest one; a compiled DAWG is
+// used as is. An already loaded
+// lexicon is returned as is. Load does not change the current bundle.
+func (r *Registry) Load(ctx context.Context, lang *scrabble.Language, version string) (*LangBundle, error) {
+	if version != "" {
+		r.mu.RLock()
+		bundle, ok := r.lexicons[lexiconName(lang.Code, version)]
+		r.mu.RUnlock()
+		if ok {
+			return bundle, nil
+		}
+	}
+
+	for _, source := range r.sources {
+		found, words, err := source.Wordlist(ctx, lang.Code, version)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name := lexiconName(lang.Code, found)
+
+		r.mu.RLock()
+		bundle, ok := r.lexicons[name]
+		r.mu.RUnlock()
+		if ok {
+			return bundle, nil
+		}
+
+		var dawg *scrabble.DAWG
+		if _, compiled := scrabble.DAWGFileSource(words); compiled {
+			dawg, err = scrabble.DecodeDAWG(lang, words)
+		} else {
+			dawg, err = scrabble.BuildDAWG(lang, bytes.NewReader(words))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("lexicon %s: %w", name, err)
+		}
+
+		bundle = &LangBundle{Lang: lang, Dawg: dawg, Lexicon: name}
+
+		r.mu.Lock()
+		if loaded, ok := r.lexicons[name]; ok {
+			bundle = loaded
+		} else {
+			r.lexicons[name] = bundle
+		}
+		r.mu.Unlock()
+
+		return bundle, nil
+	}
+
+	return nil, fmt.Errorf("scrabble: no %s wordlist %q: %w", lang.Code, version, fs.ErrNotExist)
+}
+
+// Reload loads lang's wordlist at version, or the newest one, and makes
+// it the current bundle. It returns the new bundle and the lexicon it
+// replaced, which is dropped at once if no room plays it. Rooms pick
+// the new bundle up when their next game starts.
+func (r *Registry) Reload(ctx context.Context, code, version string) (*LangBundle, string, error) {
+	current, err := r.Get(code)
+	if err != nil {
+		return nil, "", err
+	}
+
+	bundle, err := r.Load(ctx, current.Lang, version)
+	if err != nil {
+		return nil, "", err
+	}
+
+	r.mu.Lock()
+	previous := r.bundles[code].Lexicon
+	r.bundles[code] = bundle
+	r.evict(previous)
+	r.mu.Unlock()
+
+	return bundle, previous, nil
+}
+
+// Lexicon returns the bundle for a lexicon name, loading it from the
+// dictionary sources if this node has not seen it yet.
+func (r *Registry) Lexicon(ctx context.Context, name string) (*LangBundle, error) {
+	code, version, ok := strings.Cut(name, ":")
+	if !ok || version == "" {
+		return nil, fmt.Errorf("scrabble: malformed lexicon name %q", name)
+	}
+
+	current, err := r.Get(code)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Load(ctx, current.Lang, version)
+}
+
+// AddLayout registers a board layout under its name, replacing any
+// layout already registered under it.
+func (r *Registry) AddLayout(layout *scrabble.Layout) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.layouts[layout.Name] = layout
+}
+
+// Layout returns the board layout called name, or an error if
+// unregistered.
+func (r *Registry) Layout(name string) (*scrabble.Layout, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	layout, ok := r.layouts[name]
+	if !ok {
+		return nil, fmt.Errorf("scrabble: board layout %q not registered", name)
+	}
+
+	return layout, nil
+}
+
+// LayoutNames returns the registered layout names, sorted.
+func (r *Registry) LayoutNames() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(r.layouts))
+}
+
 func registryFromExtension(system actor.ActorSystem) *Registry {
 	for _, ext := range system.Extensions() {
 		if ext.ID() == RegistryExtensionID {
@@ -0,0 +1,129 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"math/rand/v2"
+	"testing"
+	"testing/fstest"
+
+	"github.com/tochemey/goakt-examples/v2/goakt-scrabble/scrabble"
+)
+
+// TestShippedRulesetsArePlayable loads every ruleset from the bundled
+// dict/ the way buildRegistry does, and checks that each one shipped
+// with a wordlist can open a game.
+func TestShippedRulesetsArePlayable(t *testing.T) {
+	bundled, err := fs.Sub(dictFS, "dict")
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := NewRegistry(fsDictSource{fsys: bundled})
+
+	shipped := 0
+	for _, lang := range scrabble.Languages() {
+		bundle, err := registry.Load(context.Background(), lang, "")
+		if errors.Is(err, fs.ErrNotExist) && lang.Code != defaultLanguageCode {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", lang.Code, err)
+		}
+		shipped++
+
+		if bundle.Dawg.Size() == 0 {
+			t.Errorf("%s: empty lexicon", lang.Code)
+		}
+		if !hasOpeningMove(bundle) {
+			t.Errorf("%s: no opening rack has a move", lang.Code)
+		}
+	}
+
+	if shipped == 0 {
+		t.Fatal("no ruleset ships a wordlist")
+	}
+}
+
+// hasOpeningMove reports whether a fresh bag deals an opening rack the
+// bot can play, trying a few seeds since any one rack may have none.
+func hasOpeningMove(bundle *LangBundle) bool {
+	for seed := range uint64(20) {
+		bag := scrabble.NewBag(bundle.Lang, rand.New(rand.NewPCG(seed, 0)))
+		rack := scrabble.NewRack()
+		rack.Refill(bag)
+		if scrabble.BestMove(scrabble.NewBoard(), rack, bundle.Dawg, bundle.Lang) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// TestRegistryDropsSupersededLexicons reloads a language's wordlist
+// while a room plays the old one, and checks that each superseded
+// lexicon is dropped once no room plays it.
+func TestRegistryDropsSupersededLexicons(t *testing.T) {
+	ctx := context.Background()
+	dir := fstest.MapFS{"en.txt": {Data: []byte("HORSE\n")}}
+	registry := NewRegistry(fsDictSource{fsys: dir})
+
+	first, err := registry.Load(ctx, scrabble.English(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry.Add(first)
+	registry.Acquire(first)
+
+	dir["en.txt"] = &fstest.MapFile{Data: []byte("HORSE\nHORSES\n")}
+	second, previous, err := registry.Reload(ctx, "en", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if previous != first.Lexicon {
+		t.Fatalf("reload replaced %s, want %s", previous, first.Lexicon)
+	}
+	if _, ok := registry.lexicons[first.Lexicon]; !ok {
+		t.Fatal("a lexicon still played by a room was dropped")
+	}
+
+	registry.Release(first)
+	if _, ok := registry.lexicons[first.Lexicon]; ok {
+		t.Error("a superseded lexicon no room plays was kept")
+	}
+	if _, ok := registry.lexicons[second.Lexicon]; !ok {
+		t.Error("the current lexicon was dropped")
+	}
+
+	dir["en.txt"] = &fstest.MapFile{Data: []byte("HORSE\nHORSES\nSHORE\n")}
+	if _, _, err := registry.Reload(ctx, "en", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := registry.lexicons[second.Lexicon]; ok {
+		t.Error("a lexicon no room played was kept after the reload that superseded it")
+	}
+	if len(registry.lexicons) != 1 {
+		t.Errorf("%d lexicons loaded, want only the current one", len(registry.lexicons))
+	}
+}
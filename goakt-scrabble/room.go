@@ -23,6 +23,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/rand/v2"
@@ -36,9 +37,13 @@ import (
 )
 
 const (
-	schedRefTurn     = "turn."
-	schedRefShutdown = "shutdown."
-	schedRefBotMove  = "botmove."
+	schedRefTurn      = "turn."
+	schedRefChallenge = "challenge."
+	schedRefShutdown  = "shutdown."
+	schedRefBotMove   = "botmove."
+
+	// defaultBotLevel is the difficulty of a bot added without one.
+	defaultBotLevel = scrabble.Expert
 )
 
 // roomPlayer is the per-seat state the room tracks.
@@ -51,6 +56,28 @@ type roomPlayer struct {
 	rack        *scrabble.Rack
 	bot         bool
 	botPID      *actor.PID
+	botLevel    scrabble.Level
+	clock       scrabble.Clock
+}
+
+// roomSpectator is a session watching the room without a seat.
+type roomSpectator struct {
+	id         string
+	name       string
+	sessionPID *actor.PID
+}
+
+// pendingPlay is a play accepted provisionally under the double-challenge
+// rule. The mover's rack is not refilled until the window closes, so a
+// withdrawn play only has to hand back the tiles it took.
+type pendingPlay struct {
+	playerID       string
+	move           scrabble.Move
+	rack           []scrabble.Tile
+	taken          []scrabble.Tile
+	result         *scrabble.MoveResult
+	scorelessTurns int
+	accepted       map[string]struct{}
 }
 
 // RoomActor owns one Scrabble game. The FSM has three behaviors
@@ -69,6 +96,10 @@ type RoomActor struct {
 	players []*roomPlayer
 	ownerID string
 
+	// spectators are keyed by session name. They get the room's events
+	// but no rack, and may only chat.
+	spectators map[string]*roomSpectator
+
 	bag   *scrabble.Bag
 	board *scrabble.Board
 
@@ -80,6 +111,52 @@ type RoomActor struct {
 	// the turn-timer balance held over for the eventual resume.
 	pausedRemaining time.Duration
 
+	// timeControl, chosen by the owner while waiting, gives each player
+	// a chess clock; the zero value keeps the per-turn timer instead.
+	// turnStarted is when the current player's clock started running,
+	// and zero while it is stopped.
+	timeControl scrabble.TimeControl
+	turnStarted time.Time
+
+	// challengeRule is ChallengeVoid or ChallengeDouble, chosen by the
+	// owner while waiting. Under double-challenge, pending holds the play
+	// whose challenge window is open and lostTurn marks players whose
+	// failed challenge costs them their next turn.
+	challengeRule     string
+	pending           *pendingPlay
+	challengeDeadline time.Time
+	lostTurn          map[string]struct{}
+
+	// casual rooms, chosen by the owner while waiting, offer hints and
+	// leave profiles and the leaderboard alone.
+	casual bool
+
+	// public lists the room in the lobby's room browser: set by the
+	// lobby for a room created public, and by the owner while waiting.
+	// reported is the last RoomStatus sent, so unchanged ones (most
+	// moves) are not re-sent.
+	public   bool
+	reported RoomStatus
+
+	// layout and variant are the board and the word rule, also chosen by
+	// the owner while waiting.
+	layout  *scrabble.Layout
+	variant string
+
+	// gameNumber counts the games played in this room; record is the GCG
+	// record of the current game and recordSeat maps player ids to its
+	// player indices, since seats shift when players leave mid-game.
+	gameNumber int
+	record     *scrabble.GCGGame
+	recordSeat map[string]int
+
+	// analysis is the finished game's analysis, kept for anyone joining
+	// during gameOver.
+	analysis *AnalysisEvent
+
+	// snapshotSeq numbers the snapshots saved to the roomStore.
+	snapshotSeq int64
+
 	schedSuffix string
 	activeRefs  map[string]struct{}
 	topic       string
@@ -95,6 +172,11 @@ func (r *RoomActor) PostStop(ctx *actor.Context) error {
 	for ref := range r.activeRefs {
 		_ = ctx.ActorSystem().CancelSchedule(ref)
 	}
+	r.reportStopped(ctx)
+
+	if registry := registryFromExtension(ctx.ActorSystem()); registry != nil && r.bundle != nil {
+		registry.Release(r.bundle)
+	}
 
 	return nil
 }
@@ -114,6 +196,7 @@ func (r *RoomActor) Receive(ctx *actor.ReceiveContext) {
 		r.language = language
 		r.code = strings.ToUpper(code)
 		r.activeRefs = make(map[string]struct{})
+		r.spectators = make(map[string]*roomSpectator)
 		r.topic = RoomTopicPrefix + r.code
 		r.schedSuffix = "." + ctx.Self().Name()
 
@@ -131,8 +214,30 @@ func (r *RoomActor) Receive(ctx *actor.ReceiveContext) {
 			return
 		}
 
-		r.bundle = bundle
+		r.useBundle(registry, bundle)
 		r.leaderboard = leaderboardFromExtension(ctx.ActorSystem())
+		r.challengeRule = ChallengeVoid
+		r.layout = scrabble.StandardLayout()
+		r.variant = VariantClassic
+
+		// A restarted lobby asks every room to report in on this topic.
+		if topic := ctx.ActorSystem().TopicActor(); topic != nil {
+			ctx.Tell(topic, actor.NewSubscribe(LobbyTopic))
+		}
+
+		// A snapshot under this code means the room died mid-game and the
+		// lobby has respawned it: resume paused so players can reconnect.
+		if snap, ok := loadRoomSnapshot(ctx.Context(), ctx.ActorSystem(), r.code); ok {
+			if err := r.restore(ctx, snap); err != nil {
+				ctx.Logger().Errorf("room %s: restore snapshot: %v", r.code, err)
+			} else {
+				ctx.Logger().Infof("room %s: resumed game %d from snapshot", r.code, r.gameNumber)
+				r.schedule(ctx, &shutdownRoom{}, rejoinWindow, schedRefShutdown)
+				r.reportStatus(ctx, PhasePaused)
+				ctx.Become(r.pauseBehavior)
+				return
+			}
+		}
 
 		ctx.Become(r.waitingBehavior)
 
@@ -144,24 +249,27 @@ func (r *RoomActor) Receive(ctx *actor.ReceiveContext) {
 func (r *RoomActor) waitingBehavior(ctx *actor.ReceiveContext) {
 	switch msg := ctx.Message().(type) {
 	case *PlayerHello:
-		if r.addPlayer(ctx, msg, ctx.Sender()) {
+		if r.addPlayer(ctx, msg, ctx.Sender(), true) {
 			r.sendJoined(ctx, msg)
 			r.broadcastState(ctx, PhaseWaiting)
 		}
 
 	case *GoodbyePlayer:
-		if r.removePlayerByName(msg.SessionName) {
+		if r.removePlayerByName(msg.SessionName) || r.removeSpectatorByName(msg.SessionName) {
 			r.broadcastState(ctx, PhaseWaiting)
 		}
 		r.maybeShutdown(ctx)
 
 	case *actor.Terminated:
-		if _, ok := r.removePlayerByPath(msg.ActorPath()); ok {
+		if _, ok := r.removePlayerByPath(msg.ActorPath()); ok || r.removeSpectatorByPath(msg.ActorPath()) {
 			r.broadcastState(ctx, PhaseWaiting)
 		}
 		r.maybeShutdown(ctx)
 
 	case *PlayerInput:
+		if r.spectatorInput(ctx, msg) {
+			return
+		}
 		switch msg.In.Type {
 		case InTypeStart:
 			if msg.PlayerID != r.ownerID || len(r.players) < MinPlayers {
@@ -172,31 +280,159 @@ func (r *RoomActor) waitingBehavior(ctx *actor.ReceiveContext) {
 			if msg.PlayerID != r.ownerID {
 				return
 			}
-			r.addBot(ctx)
+			r.addBot(ctx, msg.PlayerID, msg.In.Level)
 		case InTypeRemoveBot:
 			if msg.PlayerID != r.ownerID {
 				return
 			}
 			r.removeBot(ctx, msg.In.Seat)
+		case InTypeSetRule:
+			if msg.PlayerID != r.ownerID {
+				return
+			}
+			r.setChallengeRule(ctx, msg.PlayerID, msg.In.Rule)
+		case InTypeSetCasual:
+			if msg.PlayerID != r.ownerID {
+				return
+			}
+			r.casual = msg.In.Casual
+			r.broadcastState(ctx, PhaseWaiting)
+		case InTypeSetPublic:
+			if msg.PlayerID != r.ownerID {
+				return
+			}
+			r.public = msg.In.Public
+			r.broadcastState(ctx, PhaseWaiting)
+		case InTypeSetClock:
+			if msg.PlayerID != r.ownerID {
+				return
+			}
+			r.setTimeControl(ctx, msg.PlayerID, msg.In.Clock)
+		case InTypeSetVariant:
+			if msg.PlayerID != r.ownerID {
+				return
+			}
+			r.setVariant(ctx, msg.PlayerID, msg.In.Board, msg.In.Variant)
 		case InTypeChat:
 			r.publish(ctx, &ChatEvent{From: r.nameFor(msg.PlayerID), Text: msg.In.Text})
 		}
 
+	case *ConfigureRoom:
+		r.public = msg.Public
+		r.broadcastState(ctx, PhaseWaiting)
+
 	default:
-		ctx.Unhandled()
+		r.unhandled(ctx)
 	}
 }
 
+func (r *RoomActor) setChallengeRule(ctx *actor.ReceiveContext, playerID, rule string) {
+	switch rule {
+	case ChallengeVoid, ChallengeDouble:
+	default:
+		r.tellError(ctx, playerID, "unknown challenge rule: "+rule)
+		return
+	}
+
+	r.challengeRule = rule
+	r.broadcastState(ctx, PhaseWaiting)
+}
+
+func (r *RoomActor) setTimeControl(ctx *actor.ReceiveContext, playerID, value string) {
+	tc, err := scrabble.ParseTimeControl(value)
+	if err != nil {
+		r.tellError(ctx, playerID, "unknown time control: "+value)
+		return
+	}
+
+	r.timeControl = tc
+	r.broadcastState(ctx, PhaseWaiting)
+}
+
+// setVariant picks the board layout, from the registry, and the word
+// variant. An empty field keeps the current choice.
+func (r *RoomActor) setVariant(ctx *actor.ReceiveContext, playerID, board, variant string) {
+	layout := r.layout
+	if board != "" {
+		registry := registryFromExtension(ctx.ActorSystem())
+		if registry == nil {
+			return
+		}
+		var err error
+		if layout, err = registry.Layout(board); err != nil {
+			r.tellError(ctx, playerID, "unknown board: "+board)
+			return
+		}
+	}
+
+	switch variant {
+	case "":
+		variant = r.variant
+	case VariantClassic, VariantClabbers:
+	default:
+		r.tellError(ctx, playerID, "unknown variant: "+variant)
+		return
+	}
+
+	r.layout = layout
+	r.variant = variant
+	r.broadcastState(ctx, PhaseWaiting)
+}
+
+// dictionary is what plays are judged against: the language's DAWG, or
+// under Clabbers every anagram of its words.
+func (r *RoomActor) dictionary() scrabble.Dictionary {
+	if r.variant == VariantClabbers {
+		return scrabble.NewClabbers(r.bundle.Dawg)
+	}
+
+	return r.bundle.Dawg
+}
+
+// useBundle switches the room to bundle, holding it in the registry
+// for as long as the room plays it.
+func (r *RoomActor) useBundle(registry *Registry, bundle *LangBundle) {
+	if r.bundle == bundle {
+		return
+	}
+
+	registry.Acquire(bundle)
+	if r.bundle != nil {
+		registry.Release(r.bundle)
+	}
+	r.bundle = bundle
+}
+
 func (r *RoomActor) startGame(ctx *actor.ReceiveContext) {
-	r.bag = scrabble.NewBag(r.bundle.Lang, newRoomRNG())
-	r.board = scrabble.NewBoard()
+	// Each game plays the language's current lexicon and keeps it to
+	// the end, whatever an admin swaps in meanwhile.
+	if registry := registryFromExtension(ctx.ActorSystem()); registry != nil {
+		if bundle, err := registry.Get(r.language); err == nil {
+			r.useBundle(registry, bundle)
+		}
+	}
+
+	r.bag = scrabble.NewLayoutBag(r.bundle.Lang, r.layout, newRoomRNG())
+	r.board = scrabble.NewLayoutBoard(r.layout)
 	r.currentIdx = 0
 	r.scorelessTurns = 0
+	r.pending = nil
+	r.lostTurn = make(map[string]struct{})
+
+	r.gameNumber++
+	r.record = &scrabble.GCGGame{
+		Title:   fmt.Sprintf("Room %s game %d", r.code, r.gameNumber),
+		Lexicon: r.bundle.Lexicon,
+		Layout:  r.layout,
+	}
+	r.recordSeat = make(map[string]int, len(r.players))
 
 	for _, player := range r.players {
 		player.rack = scrabble.NewRack()
 		player.rack.Refill(r.bag)
 		player.score = 0
+		player.clock = scrabble.NewClock(r.timeControl)
+		r.recordSeat[player.id] = r.record.AddPlayer(player.name)
 	}
 
 	ctx.Logger().Infof("room %s: starting game with %d players", r.code, len(r.players))
@@ -208,21 +444,33 @@ func (r *RoomActor) startGame(ctx *actor.ReceiveContext) {
 func (r *RoomActor) playingBehavior(ctx *actor.ReceiveContext) {
 	switch msg := ctx.Message().(type) {
 	case *PlayerHello:
-		// Late-joiner: spectator for now (no engine support for mid-game seat).
-		if r.addPlayer(ctx, msg, ctx.Sender()) {
+		// Seated players reconnect; anyone else watches, since the engine
+		// cannot seat a player mid-game.
+		if r.addPlayer(ctx, msg, ctx.Sender(), false) {
 			r.sendJoined(ctx, msg)
 			r.broadcastState(ctx, PhasePlaying)
 		}
 
 	case *GoodbyePlayer:
-		r.removePlayerByName(msg.SessionName)
+		if r.removeSpectatorByName(msg.SessionName) {
+			r.broadcastState(ctx, PhasePlaying)
+			return
+		}
+		r.detachPlayerByName(msg.SessionName)
 		r.maybeShutdown(ctx)
 
 	case *actor.Terminated:
-		r.removePlayerByPath(msg.ActorPath())
+		if r.removeSpectatorByPath(msg.ActorPath()) {
+			r.broadcastState(ctx, PhasePlaying)
+			return
+		}
+		r.detachPlayerByPath(msg.ActorPath())
 		r.maybeShutdown(ctx)
 
 	case *PlayerInput:
+		if r.spectatorInput(ctx, msg) {
+			return
+		}
 		r.handlePlayingInput(ctx, msg)
 
 	case *BotPlay:
@@ -230,14 +478,37 @@ func (r *RoomActor) playingBehavior(ctx *actor.ReceiveContext) {
 
 	case *turnTimeout:
 		// Treat as pass.
-		r.applyPass(ctx, r.currentPlayer().id, true)
+		if r.pending == nil {
+			current := r.currentPlayer()
+			if r.timeControl.Timed() {
+				// A turn that runs out earns no increment.
+				r.stopClock(false)
+				if current.clock.Flagged() {
+					r.flagFall(ctx, current)
+					return
+				}
+				r.publish(ctx, &ChatEvent{
+					From: "⏱",
+					Text: fmt.Sprintf("%s is out of time", current.name),
+				})
+			}
+			r.applyPass(ctx, current.id, true)
+		}
+
+	case *challengeTimeout:
+		r.closeChallengeWindow(ctx)
 
 	default:
-		ctx.Unhandled()
+		r.unhandled(ctx)
 	}
 }
 
 func (r *RoomActor) handlePlayingInput(ctx *actor.ReceiveContext, msg *PlayerInput) {
+	if r.pending != nil {
+		r.handleChallengeWindowInput(ctx, msg)
+		return
+	}
+
 	switch msg.In.Type {
 	case InTypePlace:
 		r.applyPlace(ctx, msg.PlayerID, msg.In.Placements)
@@ -247,19 +518,52 @@ func (r *RoomActor) handlePlayingInput(ctx *actor.ReceiveContext, msg *PlayerInp
 		r.applyPass(ctx, msg.PlayerID, false)
 	case InTypePause:
 		r.pauseGame(ctx, msg.PlayerID)
+	case InTypeHint:
+		r.sendHint(ctx, msg.PlayerID)
 	case InTypeChat:
 		r.publish(ctx, &ChatEvent{From: r.nameFor(msg.PlayerID), Text: msg.In.Text})
 	}
 }
 
+// handleChallengeWindowInput handles input while a provisional play waits
+// for challenges: opponents may challenge or accept it, and nobody may
+// move until the window closes.
+func (r *RoomActor) handleChallengeWindowInput(ctx *actor.ReceiveContext, msg *PlayerInput) {
+	switch msg.In.Type {
+	case InTypeChallenge:
+		if msg.PlayerID == r.pending.playerID || r.playerByID(msg.PlayerID) == nil {
+			r.tellError(ctx, msg.PlayerID, "you cannot challenge this play")
+			return
+		}
+		r.resolveChallenge(ctx, msg.PlayerID)
+	case InTypeAccept:
+		if msg.PlayerID == r.pending.playerID {
+			return
+		}
+		r.pending.accepted[msg.PlayerID] = struct{}{}
+		if r.allOpponentsAccepted() {
+			r.closeChallengeWindow(ctx)
+		}
+	case InTypePause:
+		r.tellError(ctx, msg.PlayerID, "cannot pause during a challenge window")
+	case InTypeChat:
+		r.publish(ctx, &ChatEvent{From: r.nameFor(msg.PlayerID), Text: msg.In.Text})
+	default:
+		r.tellError(ctx, msg.PlayerID, "waiting for challenges")
+	}
+}
+
 // pauseGame freezes the turn timer and any pending bot move and switches
-// into pauseBehavior. Any player at the table may pause.
+// into pauseBehavior. Any player at the table may pause, except while a
+// challenge window is open (see handleChallengeWindowInput).
 func (r *RoomActor) pauseGame(ctx *actor.ReceiveContext, playerID string) {
 	r.cancelSchedule(ctx, schedRefTurn)
 	r.cancelBotMove(ctx)
+	r.stopClock(false)
 
 	r.pausedRemaining = max(time.Until(r.turnDeadline), time.Second)
 	r.turnDeadline = time.Time{}
+	r.saveSnapshot(ctx)
 
 	r.publish(ctx, &ChatEvent{
 		From: "⏸",
@@ -273,20 +577,31 @@ func (r *RoomActor) pauseGame(ctx *actor.ReceiveContext, playerID string) {
 func (r *RoomActor) pauseBehavior(ctx *actor.ReceiveContext) {
 	switch msg := ctx.Message().(type) {
 	case *PlayerHello:
-		if r.addPlayer(ctx, msg, ctx.Sender()) {
+		if r.addPlayer(ctx, msg, ctx.Sender(), false) {
 			r.sendJoined(ctx, msg)
 			r.broadcastState(ctx, PhasePaused)
 		}
 
 	case *GoodbyePlayer:
-		r.removePlayerByName(msg.SessionName)
+		if r.removeSpectatorByName(msg.SessionName) {
+			r.broadcastState(ctx, PhasePaused)
+			return
+		}
+		r.detachPlayerByName(msg.SessionName)
 		r.maybeShutdown(ctx)
 
 	case *actor.Terminated:
-		r.removePlayerByPath(msg.ActorPath())
+		if r.removeSpectatorByPath(msg.ActorPath()) {
+			r.broadcastState(ctx, PhasePaused)
+			return
+		}
+		r.detachPlayerByPath(msg.ActorPath())
 		r.maybeShutdown(ctx)
 
 	case *PlayerInput:
+		if r.spectatorInput(ctx, msg) {
+			return
+		}
 		switch msg.In.Type {
 		case InTypeResume:
 			r.resumeGame(ctx, msg.PlayerID)
@@ -297,20 +612,28 @@ func (r *RoomActor) pauseBehavior(ctx *actor.ReceiveContext) {
 	case *BotPlay:
 		// A bot move scheduled before pause may still arrive; drop it.
 
+	case *shutdownRoom:
+		// The rejoin window of a rehydrated room has run out.
+		r.maybeShutdown(ctx)
+
 	default:
-		ctx.Unhandled()
+		r.unhandled(ctx)
 	}
 }
 
-// resumeGame restores the turn timer to its frozen remaining duration and
-// returns to playingBehavior; if it's a bot's turn, kicks the bot again.
+// resumeGame restores the turn timer to its frozen remaining duration,
+// restarts the current player's clock and returns to playingBehavior; if
+// it's a bot's turn, kicks the bot again.
 func (r *RoomActor) resumeGame(ctx *actor.ReceiveContext, playerID string) {
+	r.cancelSchedule(ctx, schedRefShutdown)
+
 	remaining := r.pausedRemaining
 	if remaining <= 0 {
 		remaining = turnDuration
 	}
 	r.pausedRemaining = 0
-	r.turnDeadline = time.Now().Add(remaining)
+	r.turnStarted = time.Now()
+	r.turnDeadline = r.turnStarted.Add(remaining)
 	r.schedule(ctx, &turnTimeout{}, remaining, schedRefTurn)
 
 	r.publish(ctx, &ChatEvent{
@@ -341,6 +664,45 @@ func (r *RoomActor) cancelBotMove(ctx *actor.ReceiveContext) {
 	delete(r.activeRefs, ref)
 }
 
+// sendHint tells the current player the top-scoring play for their rack
+// under the room's dictionary. The search runs in a PipeTo goroutine on
+// copies of the board and rack and replies to the session directly: a
+// Clabbers search can take most of a second.
+func (r *RoomActor) sendHint(ctx *actor.ReceiveContext, playerID string) {
+	if !r.casual {
+		r.tellError(ctx, playerID, "hints are only available in casual rooms")
+		return
+	}
+
+	current := r.currentPlayer()
+	if current == nil || current.id != playerID {
+		r.tellError(ctx, playerID, "not your turn")
+		return
+	}
+
+	if current.sessionPID == nil {
+		return
+	}
+
+	lang, dict, board := r.bundle.Lang, r.dictionary(), r.board.Clone()
+	rack := scrabble.NewRack()
+	rack.Add(current.rack.Tiles())
+
+	ctx.PipeTo(current.sessionPID, func() (any, error) {
+		best := scrabble.BestMove(board, rack, dict, lang)
+		if best == nil {
+			return &ErrorEvent{For: playerID, Message: "no play found: exchange or pass"}, nil
+		}
+
+		return &HintEvent{
+			For:        playerID,
+			Placements: enginePlacementsToWire(best.Move.Placements, lang),
+			Words:      formedWords(best.Result),
+			Score:      best.Result.Score,
+		}, nil
+	})
+}
+
 func (r *RoomActor) applyPlace(ctx *actor.ReceiveContext, playerID string, wires []PlacementWire) {
 	current := r.currentPlayer()
 	if current == nil || current.id != playerID {
@@ -354,6 +716,7 @@ func (r *RoomActor) applyPlace(ctx *actor.ReceiveContext, playerID string, wires
 		return
 	}
 
+	rackBefore := current.rack.Tiles()
 	required := tilesUsed(placements)
 	taken, err := current.rack.Remove(required)
 	if err != nil {
@@ -362,7 +725,7 @@ func (r *RoomActor) applyPlace(ctx *actor.ReceiveContext, playerID string, wires
 	}
 
 	move := scrabble.Move{Placements: placements}
-	result, err := move.Validate(r.board, r.bundle.Dawg, r.bundle.Lang)
+	result, err := r.validateMove(move)
 	if err != nil {
 		current.rack.Add(taken)
 		r.tellError(ctx, playerID, err.Error())
@@ -375,20 +738,62 @@ func (r *RoomActor) applyPlace(ctx *actor.ReceiveContext, playerID string, wires
 		return
 	}
 
+	provisional := r.challengeRule == ChallengeDouble
+	scorelessBefore := r.scorelessTurns
+
 	current.score += result.Score
-	current.rack.Refill(r.bag)
 	r.scorelessTurns = 0
 
+	r.recordEvent(current.id, current.score, scrabble.GCGEvent{
+		Kind:  scrabble.GCGPlay,
+		Rack:  rackBefore,
+		Move:  move,
+		Score: result.Score,
+	})
+
 	r.publish(ctx, &MoveEvent{
-		PlayerID:   current.id,
-		Name:       current.name,
-		Placements: wires,
-		Words:      formedWords(result),
-		Score:      result.Score,
-		NewTotal:   current.score,
-		Bingo:      result.Bingo,
+		PlayerID:    current.id,
+		Name:        current.name,
+		Placements:  wires,
+		Words:       formedWords(result),
+		Score:       result.Score,
+		NewTotal:    current.score,
+		Bingo:       result.Bingo,
+		Provisional: provisional,
 	})
 
+	if provisional {
+		r.openChallengeWindow(ctx, &pendingPlay{
+			playerID:       current.id,
+			move:           move,
+			rack:           rackBefore,
+			taken:          taken,
+			result:         result,
+			scorelessTurns: scorelessBefore,
+			accepted:       make(map[string]struct{}),
+		})
+		return
+	}
+
+	r.finishPlay(ctx, current)
+}
+
+// validateMove applies the room's challenge rule: void checks every
+// formed word against the dictionary, double only checks geometry and
+// leaves the words to a challenge.
+func (r *RoomActor) validateMove(move scrabble.Move) (*scrabble.MoveResult, error) {
+	if r.challengeRule == ChallengeDouble {
+		return move.ValidateLenient(r.board, r.bundle.Lang)
+	}
+
+	return move.Validate(r.board, r.dictionary(), r.bundle.Lang)
+}
+
+// finishPlay refills the mover's rack once their play is final and moves
+// the game on.
+func (r *RoomActor) finishPlay(ctx *actor.ReceiveContext, mover *roomPlayer) {
+	mover.rack.Refill(r.bag)
+
 	if r.checkGameOver(ctx) {
 		return
 	}
@@ -396,6 +801,136 @@ func (r *RoomActor) applyPlace(ctx *actor.ReceiveContext, playerID string, wires
 	r.advanceTurn(ctx)
 }
 
+// openChallengeWindow holds the turn on a provisional play. Bots know the
+// dictionary, so a bot opponent challenges a phony at once; otherwise the
+// window stays open for the human opponents until it times out or they
+// all accept.
+func (r *RoomActor) openChallengeWindow(ctx *actor.ReceiveContext, play *pendingPlay) {
+	r.cancelSchedule(ctx, schedRefTurn)
+	r.stopClock(true)
+	r.turnDeadline = time.Time{}
+	r.pending = play
+
+	if len(play.result.Phonies(r.dictionary())) > 0 {
+		for _, player := range r.players {
+			if player.bot && player.id != play.playerID {
+				r.resolveChallenge(ctx, player.id)
+				return
+			}
+		}
+	}
+
+	if r.allOpponentsAccepted() {
+		r.closeChallengeWindow(ctx)
+		return
+	}
+
+	r.challengeDeadline = time.Now().Add(challengeWindow)
+	r.schedule(ctx, &challengeTimeout{}, challengeWindow, schedRefChallenge)
+	r.broadcastState(ctx, PhasePlaying)
+}
+
+// allOpponentsAccepted reports whether every human opponent of the
+// pending play has waived their challenge. Bots never wait the window out.
+func (r *RoomActor) allOpponentsAccepted() bool {
+	for _, player := range r.players {
+		if player.bot || player.id == r.pending.playerID {
+			continue
+		}
+		if _, ok := r.pending.accepted[player.id]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// closeChallengeWindow lets the pending play stand unchallenged.
+func (r *RoomActor) closeChallengeWindow(ctx *actor.ReceiveContext) {
+	play := r.pending
+	if play == nil {
+		return
+	}
+
+	r.clearPending(ctx)
+
+	mover := r.playerByID(play.playerID)
+	if mover == nil {
+		r.advanceTurn(ctx)
+		return
+	}
+
+	r.finishPlay(ctx, mover)
+}
+
+// resolveChallenge rules on the pending play. A phony is withdrawn: its
+// tiles go back to the mover's rack, its score is taken back and the turn
+// counts as scoreless. A valid play stands and the challenger loses their
+// next turn.
+func (r *RoomActor) resolveChallenge(ctx *actor.ReceiveContext, challengerID string) {
+	play := r.pending
+	r.clearPending(ctx)
+
+	mover := r.playerByID(play.playerID)
+	phonies := play.result.Phonies(r.dictionary())
+
+	evt := &ChallengeEvent{
+		ChallengerID:   challengerID,
+		ChallengerName: r.nameFor(challengerID),
+		PlayerID:       play.playerID,
+		Name:           r.nameFor(play.playerID),
+		Phonies:        phonies,
+	}
+
+	if len(phonies) == 0 {
+		r.lostTurn[challengerID] = struct{}{}
+		if mover != nil {
+			evt.NewTotal = mover.score
+		}
+		r.publish(ctx, evt)
+
+		if mover == nil {
+			r.advanceTurn(ctx)
+			return
+		}
+		r.finishPlay(ctx, mover)
+		return
+	}
+
+	if _, err := play.move.Withdraw(r.board); err != nil {
+		ctx.Logger().Errorf("room %s: withdraw challenged play: %v", r.code, err)
+	}
+
+	r.scorelessTurns = play.scorelessTurns + 1
+	evt.Withdrawn = true
+	evt.Score = play.result.Score
+
+	if mover != nil {
+		mover.rack.Add(play.taken)
+		mover.score -= play.result.Score
+		evt.NewTotal = mover.score
+		r.recordEvent(mover.id, mover.score, scrabble.GCGEvent{
+			Kind:  scrabble.GCGWithdraw,
+			Rack:  play.rack,
+			Score: -play.result.Score,
+		})
+	}
+
+	r.publish(ctx, evt)
+
+	if r.checkGameOver(ctx) {
+		return
+	}
+
+	r.advanceTurn(ctx)
+}
+
+func (r *RoomActor) clearPending(ctx *actor.ReceiveContext) {
+	r.cancelSchedule(ctx, schedRefChallenge)
+	r.pending = nil
+	r.challengeDeadline = time.Time{}
+}
+
 func (r *RoomActor) applyExchange(ctx *actor.ReceiveContext, playerID string, indices []int) {
 	current := r.currentPlayer()
 	if current == nil || current.id != playerID {
@@ -403,11 +938,18 @@ func (r *RoomActor) applyExchange(ctx *actor.ReceiveContext, playerID string, in
 		return
 	}
 
+	rackBefore := current.rack.Tiles()
 	if err := current.rack.Exchange(indices, r.bag); err != nil {
 		r.tellError(ctx, playerID, err.Error())
 		return
 	}
 
+	exchanged := make([]scrabble.Tile, len(indices))
+	for i, idx := range indices {
+		exchanged[i] = rackBefore[idx]
+	}
+	r.recordEvent(current.id, current.score, scrabble.GCGEvent{Kind: scrabble.GCGExchange, Rack: rackBefore, Tiles: exchanged})
+
 	r.scorelessTurns++
 
 	if r.checkGameOver(ctx) {
@@ -426,6 +968,7 @@ func (r *RoomActor) applyPass(ctx *actor.ReceiveContext, playerID string, timedO
 		return
 	}
 
+	r.recordEvent(current.id, current.score, scrabble.GCGEvent{Kind: scrabble.GCGPass, Rack: current.rack.Tiles()})
 	r.scorelessTurns++
 
 	if r.checkGameOver(ctx) {
@@ -437,31 +980,110 @@ func (r *RoomActor) applyPass(ctx *actor.ReceiveContext, playerID string, timedO
 
 func (r *RoomActor) advanceTurn(ctx *actor.ReceiveContext) {
 	r.cancelSchedule(ctx, schedRefTurn)
+	r.stopClock(true)
 	r.currentIdx = (r.currentIdx + 1) % len(r.players)
+
+	// A failed challenge forfeits the challenger's next turn, which
+	// counts as a scoreless turn like a pass.
+	for range len(r.players) {
+		next := r.currentPlayer()
+		if _, lost := r.lostTurn[next.id]; !lost {
+			break
+		}
+		delete(r.lostTurn, next.id)
+		r.recordEvent(next.id, next.score, scrabble.GCGEvent{Kind: scrabble.GCGPass, Rack: next.rack.Tiles()})
+		r.scorelessTurns++
+		r.publish(ctx, &ChatEvent{
+			From: "⚖",
+			Text: fmt.Sprintf("%s loses this turn (failed challenge)", next.name),
+		})
+		if r.checkGameOver(ctx) {
+			return
+		}
+		r.currentIdx = (r.currentIdx + 1) % len(r.players)
+	}
+
 	r.beginTurn(ctx)
 }
 
+// beginTurn starts the current player's turn. In a timed game the turn
+// runs until the player's clock is MaxOvertime past zero, when the game
+// ends (see flagFall); an untimed turn that runs out is passed.
 func (r *RoomActor) beginTurn(ctx *actor.ReceiveContext) {
-	r.turnDeadline = time.Now().Add(turnDuration)
-	r.schedule(ctx, &turnTimeout{}, turnDuration, schedRefTurn)
+	current := r.currentPlayer()
+
+	budget := turnDuration
+	if r.timeControl.Timed() && current != nil {
+		budget = max(current.clock.Remaining+scrabble.MaxOvertime, time.Second)
+	}
+
+	r.turnStarted = time.Now()
+	r.turnDeadline = r.turnStarted.Add(budget)
+	r.schedule(ctx, &turnTimeout{}, budget, schedRefTurn)
+	r.saveSnapshot(ctx)
 	r.broadcastState(ctx, PhasePlaying)
 
-	current := r.currentPlayer()
 	if current != nil && current.bot && current.botPID != nil {
 		r.scheduleBotMove(ctx, current)
 	}
 }
 
+// flagFall ends a timed game whose current player has run
+// scrabble.MaxOvertime past their bank. The game is scored as it
+// stands, with that player charged the full overtime penalty.
+func (r *RoomActor) flagFall(ctx *actor.ReceiveContext, player *roomPlayer) {
+	r.publish(ctx, &ChatEvent{
+		From: "⏱",
+		Text: fmt.Sprintf("%s is %d minutes over time; the game is over", player.name, int(scrabble.MaxOvertime/time.Minute)),
+	})
+	r.recordEvent(player.id, player.score, scrabble.GCGEvent{Kind: scrabble.GCGPass, Rack: player.rack.Tiles()})
+	r.enterGameOver(ctx)
+}
+
+// stopClock charges the current player for the time since their clock
+// started. turnOver adds the increment; a pause or the end of the game
+// does not earn one.
+func (r *RoomActor) stopClock(turnOver bool) {
+	if r.turnStarted.IsZero() {
+		return
+	}
+
+	if current := r.currentPlayer(); current != nil && r.timeControl.Timed() {
+		current.clock.Spend(time.Since(r.turnStarted), r.timeControl, turnOver)
+	}
+	r.turnStarted = time.Time{}
+}
+
+// clockMs is player's clock in milliseconds, running if it is their turn.
+func (r *RoomActor) clockMs(player *roomPlayer) int {
+	remaining := player.clock.Remaining
+	if !r.turnStarted.IsZero() && player == r.currentPlayer() {
+		remaining -= time.Since(r.turnStarted)
+	}
+
+	return int(remaining / time.Millisecond)
+}
+
 func (r *RoomActor) scheduleBotMove(ctx *actor.ReceiveContext, bot *roomPlayer) {
 	turn := &YourTurn{
-		BotID: bot.id,
-		Board: boardToWire(r.board, r.bundle.Lang),
-		Rack:  rackToWire(bot.rack, r.bundle.Lang),
+		BotID:        bot.id,
+		Level:        bot.botLevel.String(),
+		Board:        boardToWire(r.board, r.bundle.Lang),
+		Rack:         rackToWire(bot.rack, r.bundle.Lang),
+		Layout:       r.layout.Name,
+		Lexicon:      r.bundle.Lexicon,
+		BagRemaining: r.bag.Remaining(),
+		Opponents:    len(r.players) - 1,
 	}
 
 	// Brief "thinking" delay so the bot's move doesn't appear instantly.
+	// An expert spends part of it actually thinking.
 	ref := schedRefBotMove + r.schedSuffix + "." + bot.id
 	delay := time.Duration(BotMoveDelayMs) * time.Millisecond
+	if bot.botLevel == scrabble.Expert {
+		turn.ThinkMs = BotThinkMs
+		delay -= time.Duration(BotThinkMs) * time.Millisecond
+	}
 	if err := ctx.ActorSystem().ScheduleOnce(ctx.Context(), turn, bot.botPID, delay, actor.WithReference(ref)); err != nil {
 		ctx.Logger().Errorf("room %s schedule bot move(%s): %v", r.code, bot.id, err)
 		return
@@ -471,7 +1093,7 @@ func (r *RoomActor) scheduleBotMove(ctx *actor.ReceiveContext, bot *roomPlayer)
 
 func (r *RoomActor) handleBotPlay(ctx *actor.ReceiveContext, play *BotPlay) {
 	current := r.currentPlayer()
-	if current == nil || current.id != play.BotID {
+	if current == nil || current.id != play.BotID || r.pending != nil {
 		return
 	}
 
@@ -501,16 +1123,29 @@ func (r *RoomActor) checkGameOver(ctx *actor.ReceiveContext) bool {
 
 func (r *RoomActor) enterGameOver(ctx *actor.ReceiveContext) {
 	r.cancelSchedule(ctx, schedRefTurn)
+	r.stopClock(false)
+	r.clearPending(ctx)
 
 	scores := make([]int, len(r.players))
 	racks := make([]*scrabble.Rack, len(r.players))
 
+	var overtime []time.Duration
+	if r.timeControl.Timed() {
+		overtime = make([]time.Duration, len(r.players))
+	}
+
 	for i, player := range r.players {
 		scores[i] = player.score
 		racks[i] = player.rack
+		if overtime != nil {
+			overtime[i] = player.clock.Overtime()
+		}
 	}
 
-	final := scrabble.FinalScores(scores, racks, r.bundle.Lang)
+	r.recordEndgame(racks)
+
+	final := scrabble.FinalScores(scores, racks, overtime, r.bundle.Lang)
+	r.recordTimePenalties(racks, overtime, final)
 	for i, player := range r.players {
 		player.score = final[i]
 	}
@@ -521,15 +1156,159 @@ func (r *RoomActor) enterGameOver(ctx *actor.ReceiveContext) {
 		WinnerID:   winnerID,
 		WinnerName: winnerName,
 		Scores:     r.scoreEntries(),
+		GameNumber: r.gameNumber,
+		Record:     r.archiveRecord(ctx),
 	})
 
-	r.recordResults(ctx, winnerID)
+	if !r.casual {
+		r.recordResults(ctx, winnerID)
+	}
+	r.analyzeRecord(ctx)
+	r.dropSnapshot(ctx)
 	r.broadcastState(ctx, PhaseGameOver)
 	r.schedule(ctx, &shutdownRoom{}, GameOverSecs*time.Second, schedRefShutdown)
 
 	ctx.Become(r.gameOverBehavior)
 }
 
+// recordEvent appends a move line for a player to the game record,
+// filling in their seat and running total.
+func (r *RoomActor) recordEvent(playerID string, total int, evt scrabble.GCGEvent) {
+	seat, ok := r.recordSeat[playerID]
+	if !ok || r.record == nil {
+		return
+	}
+
+	evt.Player = seat
+	evt.Total = total
+	r.record.Events = append(r.record.Events, evt)
+}
+
+// recordEndgame records the rack adjustments FinalScores is about to
+// make: the player who went out is credited each opponent's leftover
+// tiles, and everyone holding tiles is charged for them. It must run
+// before the scores are updated.
+func (r *RoomActor) recordEndgame(racks []*scrabble.Rack) {
+	lang := r.bundle.Lang
+	outIdx := scrabble.FirstEmptyRack(racks)
+
+	if outIdx >= 0 {
+		goer := r.players[outIdx]
+		total := goer.score
+		for i, rack := range racks {
+			if i == outIdx || rack.Size() == 0 {
+				continue
+			}
+			total += rack.Value(lang)
+			r.recordEvent(goer.id, total, scrabble.GCGEvent{
+				Kind:  scrabble.GCGEndRack,
+				Tiles: rack.Tiles(),
+				Score: rack.Value(lang),
+			})
+		}
+	}
+
+	for i, player := range r.players {
+		if racks[i].Size() == 0 {
+			continue
+		}
+		value := racks[i].Value(lang)
+		r.recordEvent(player.id, player.score-value, scrabble.GCGEvent{
+			Kind:  scrabble.GCGRackPenalty,
+			Rack:  racks[i].Tiles(),
+			Tiles: racks[i].Tiles(),
+			Score: -value,
+		})
+	}
+}
+
+// recordTimePenalties records the overtime penalties FinalScores has
+// charged. They are its last adjustment, so each line totals to the
+// player's final score.
+func (r *RoomActor) recordTimePenalties(racks []*scrabble.Rack, overtime []time.Duration, final []int) {
+	for i, over := range overtime {
+		penalty := scrabble.OvertimePenalty(over)
+		if penalty == 0 {
+			continue
+		}
+		r.recordEvent(r.players[i].id, final[i], scrabble.GCGEvent{
+			Kind:  scrabble.GCGTimePenalty,
+			Rack:  racks[i].Tiles(),
+			Score: -penalty,
+		})
+	}
+}
+
+// archiveRecord renders the finished game as GCG and saves it in this
+// node's GameArchive. The text also rides on the GameOverEvent so the
+// sessions can archive it on their own nodes.
+func (r *RoomActor) archiveRecord(ctx *actor.ReceiveContext) string {
+	if r.record == nil {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	if err := r.record.Write(&buf, r.bundle.Lang); err != nil {
+		ctx.Logger().Errorf("room %s: write game record: %v", r.code, err)
+		return ""
+	}
+
+	gameArchiveFromExtension(ctx.ActorSystem()).Save(r.code, r.gameNumber, buf.Bytes())
+
+	return buf.String()
+}
+
+// analyzeRecord runs the post-game analysis of the finished game in a
+// PipeTo goroutine; the AnalysisEvent comes back to gameOverBehavior.
+// The move generator runs once per turn, too slow for the mailbox.
+func (r *RoomActor) analyzeRecord(ctx *actor.ReceiveContext) {
+	if r.record == nil {
+		return
+	}
+
+	code, record, lang, dict, gameNumber := r.code, r.record, r.bundle.Lang, r.dictionary(), r.gameNumber
+	seatIDs := make([]string, len(record.Players))
+	for id, seat := range r.recordSeat {
+		seatIDs[seat] = id
+	}
+
+	ctx.PipeTo(ctx.Self(), func() (any, error) {
+		turns, err := scrabble.Analyze(record, dict, lang)
+		if err != nil {
+			return nil, fmt.Errorf("room %s: analyze game %d: %w", code, gameNumber, err)
+		}
+
+		evt := &AnalysisEvent{
+			GameNumber: gameNumber,
+			Turns:      make([]TurnAnalysisWire, 0, len(turns)),
+			Lost:       make([]ScoreEntry, len(record.Players)),
+		}
+
+		for seat, player := range record.Players {
+			evt.Lost[seat] = ScoreEntry{PlayerID: seatIDs[seat], Name: player.Name}
+		}
+
+		for i, turn := range turns {
+			wire := TurnAnalysisWire{
+				Turn:     i + 1,
+				PlayerID: seatIDs[turn.Player],
+				Name:     record.Players[turn.Player].Name,
+				Played:   turn.Played,
+				Score:    turn.Score,
+				Best:     turn.BestPlay,
+				Lost:     turn.Lost,
+			}
+			if turn.Best != nil {
+				wire.BestScore = turn.Best.Result.Score
+			}
+			evt.Turns = append(evt.Turns, wire)
+			evt.Lost[turn.Player].Score += turn.Lost
+		}
+
+		return evt, nil
+	})
+}
+
 func (r *RoomActor) gameOverBehavior(ctx *actor.ReceiveContext) {
 	switch msg := ctx.Message().(type) {
 	case []LeaderboardEntry:
@@ -539,21 +1318,41 @@ func (r *RoomActor) gameOverBehavior(ctx *actor.ReceiveContext) {
 			WinnerName:  winnerName,
 			Scores:      r.scoreEntries(),
 			Leaderboard: msg,
+			GameNumber:  r.gameNumber,
 		})
 
+	case *AnalysisEvent:
+		r.analysis = msg
+		r.publish(ctx, msg)
+
 	case *PlayerHello:
-		if r.addPlayer(ctx, msg, ctx.Sender()) {
+		if r.addPlayer(ctx, msg, ctx.Sender(), true) {
 			r.sendJoined(ctx, msg)
 			r.broadcastState(ctx, PhaseGameOver)
+			if r.analysis != nil && ctx.Sender() != nil {
+				ctx.Tell(ctx.Sender(), &AnalysisEvent{
+					For:        msg.PlayerID,
+					GameNumber: r.analysis.GameNumber,
+					Turns:      r.analysis.Turns,
+					Lost:       r.analysis.Lost,
+				})
+			}
 		}
 
 	case *GoodbyePlayer:
-		r.removePlayerByName(msg.SessionName)
+		if r.removeSpectatorByName(msg.SessionName) || r.removePlayerByName(msg.SessionName) {
+			r.broadcastState(ctx, PhaseGameOver)
+		}
 
 	case *actor.Terminated:
-		r.removePlayerByPath(msg.ActorPath())
+		if _, ok := r.removePlayerByPath(msg.ActorPath()); ok || r.removeSpectatorByPath(msg.ActorPath()) {
+			r.broadcastState(ctx, PhaseGameOver)
+		}
 
 	case *PlayerInput:
+		if r.spectatorInput(ctx, msg) {
+			return
+		}
 		switch msg.In.Type {
 		case InTypePlayAgain:
 			r.restartGame(ctx, msg.PlayerID)
@@ -565,12 +1364,13 @@ func (r *RoomActor) gameOverBehavior(ctx *actor.ReceiveContext) {
 		ctx.Shutdown()
 
 	default:
-		ctx.Unhandled()
+		r.unhandled(ctx)
 	}
 }
 
 func (r *RoomActor) restartGame(ctx *actor.ReceiveContext, byPlayerID string) {
 	r.cancelSchedule(ctx, schedRefShutdown)
+	r.analysis = nil
 
 	r.publish(ctx, &ChatEvent{
 		From: "📣",
@@ -586,10 +1386,24 @@ func (r *RoomActor) restartGame(ctx *actor.ReceiveContext, byPlayerID string) {
 	r.startGame(ctx)
 }
 
-func (r *RoomActor) addPlayer(ctx *actor.ReceiveContext, msg *PlayerHello, sender *actor.PID) bool {
+// addPlayer seats a joining session, or reattaches it to its seat. A
+// session asking to watch, or joining a full room or a game that is not
+// seatable, becomes a spectator.
+func (r *RoomActor) addPlayer(ctx *actor.ReceiveContext, msg *PlayerHello, sender *actor.PID, seatable bool) bool {
+	existing := r.playerByID(msg.PlayerID)
+
+	if msg.Spectator || (existing == nil && (!seatable || len(r.players) >= MaxPlayers)) {
+		r.spectators[msg.SessionName] = &roomSpectator{id: msg.PlayerID, name: msg.Name, sessionPID: sender}
+		r.hadPlayer = true
+		if sender != nil {
+			ctx.Watch(sender)
+		}
+		return true
+	}
+
 	r.leaderboard.RememberName(msg.PlayerID, msg.Name)
 
-	if existing := r.playerByID(msg.PlayerID); existing != nil {
+	if existing != nil {
 		existing.sessionName = msg.SessionName
 		existing.sessionPID = sender
 		existing.name = msg.Name
@@ -599,11 +1413,6 @@ func (r *RoomActor) addPlayer(ctx *actor.ReceiveContext, msg *PlayerHello, sende
 		return true
 	}
 
-	if len(r.players) >= MaxPlayers {
-		ctx.Tell(sender, &ErrorEvent{For: msg.PlayerID, Message: "room is full"})
-		return false
-	}
-
 	r.players = append(r.players, &roomPlayer{
 		id:          msg.PlayerID,
 		name:        msg.Name,
@@ -624,31 +1433,53 @@ func (r *RoomActor) addPlayer(ctx *actor.ReceiveContext, msg *PlayerHello, sende
 	return true
 }
 
-func (r *RoomActor) addBot(ctx *actor.ReceiveContext) {
+// addBot seats a bot at the requested difficulty; an empty level means
+// defaultBotLevel.
+func (r *RoomActor) addBot(ctx *actor.ReceiveContext, playerID, levelName string) {
 	if len(r.players) >= MaxPlayers {
 		return
 	}
 
+	level := defaultBotLevel
+	if levelName != "" {
+		parsed, err := scrabble.ParseLevel(levelName)
+		if err != nil {
+			r.tellError(ctx, playerID, err.Error())
+			return
+		}
+		level = parsed
+	}
+
 	botID := "bot-" + shortID()
 	botName := "Bot " + strings.ToUpper(botID[len(botID)-3:])
 
-	pid := ctx.Spawn(BotActorPrefix+r.language+"."+r.code+"."+botID, new(BotActor),
-		actor.WithLongLived())
+	pid := r.spawnBot(ctx, botID)
 	if pid == nil {
-		ctx.Logger().Errorf("room %s: spawn bot failed", r.code)
 		return
 	}
 
 	r.players = append(r.players, &roomPlayer{
-		id:     botID,
-		name:   botName,
-		bot:    true,
-		botPID: pid,
+		id:       botID,
+		name:     botName,
+		bot:      true,
+		botPID:   pid,
+		botLevel: level,
 	})
 
 	r.broadcastState(ctx, PhaseWaiting)
 }
 
+// spawnBot starts the BotActor for a bot seat as a child of the room.
+func (r *RoomActor) spawnBot(ctx *actor.ReceiveContext, botID string) *actor.PID {
+	pid := ctx.Spawn(BotActorPrefix+r.language+"."+r.code+"."+botID, new(BotActor),
+		actor.WithLongLived())
+	if pid == nil {
+		ctx.Logger().Errorf("room %s: spawn bot failed", r.code)
+	}
+
+	return pid
+}
+
 func (r *RoomActor) removeBot(ctx *actor.ReceiveContext, seat int) {
 	if seat < 0 || seat >= len(r.players) {
 		return
@@ -691,6 +1522,63 @@ func (r *RoomActor) removePlayerByPath(path actor.Path) (*roomPlayer, bool) {
 	return nil, false
 }
 
+// detachPlayerByName drops a player's session but keeps their seat, so
+// once a game has started a player who disconnects can reconnect into it.
+func (r *RoomActor) detachPlayerByName(name string) {
+	for _, player := range r.players {
+		if player.sessionName == name {
+			player.sessionName = ""
+			player.sessionPID = nil
+			return
+		}
+	}
+}
+
+func (r *RoomActor) detachPlayerByPath(path actor.Path) {
+	for _, player := range r.players {
+		if player.sessionPID != nil && player.sessionPID.Path().Equals(path) {
+			player.sessionName = ""
+			player.sessionPID = nil
+			return
+		}
+	}
+}
+
+func (r *RoomActor) removeSpectatorByName(name string) bool {
+	if _, ok := r.spectators[name]; !ok {
+		return false
+	}
+
+	delete(r.spectators, name)
+
+	return true
+}
+
+func (r *RoomActor) removeSpectatorByPath(path actor.Path) bool {
+	for name, spectator := range r.spectators {
+		if spectator.sessionPID != nil && spectator.sessionPID.Path().Equals(path) {
+			delete(r.spectators, name)
+			return true
+		}
+	}
+
+	return false
+}
+
+// spectatorInput handles input from a player without a seat, who may
+// only chat. It reports whether msg came from one.
+func (r *RoomActor) spectatorInput(ctx *actor.ReceiveContext, msg *PlayerInput) bool {
+	if r.playerByID(msg.PlayerID) != nil {
+		return false
+	}
+
+	if msg.In.Type == InTypeChat {
+		r.publish(ctx, &ChatEvent{From: r.nameFor(msg.PlayerID), Text: msg.In.Text})
+	}
+
+	return true
+}
+
 func (r *RoomActor) currentPlayer() *roomPlayer {
 	if r.currentIdx < 0 || r.currentIdx >= len(r.players) {
 		return nil
@@ -714,6 +1602,12 @@ func (r *RoomActor) nameFor(id string) string {
 		return player.name
 	}
 
+	for _, spectator := range r.spectators {
+		if spectator.id == id {
+			return spectator.name
+		}
+	}
+
 	return id
 }
 
@@ -750,13 +1644,20 @@ func (r *RoomActor) playerViews() []PlayerView {
 		if player.rack != nil {
 			rackSize = player.rack.Size()
 		}
-		out = append(out, PlayerView{
+		view := PlayerView{
 			ID:       player.id,
 			Name:     player.name,
 			Score:    player.score,
 			RackSize: rackSize,
 			Bot:      player.bot,
-		})
+		}
+		if player.bot {
+			view.Level = player.botLevel.String()
+		}
+		if r.timeControl.Timed() {
+			view.ClockMs = r.clockMs(player)
+		}
+		out = append(out, view)
 	}
 
 	return out
@@ -772,7 +1673,7 @@ func (r *RoomActor) broadcastState(ctx *actor.ReceiveContext, phase string) {
 	if r.board != nil {
 		board = boardToWire(r.board, r.bundle.Lang)
 	} else {
-		board = emptyBoardWire()
+		board = emptyBoardWire(r.layout.Size())
 	}
 
 	if r.bag != nil {
@@ -792,6 +1693,11 @@ func (r *RoomActor) broadcastState(ctx *actor.ReceiveContext, phase string) {
 		}
 	}
 
+	challengeMs := 0
+	if r.pending != nil && phase == PhasePlaying {
+		challengeMs = max(int(time.Until(r.challengeDeadline)/time.Millisecond), 0)
+	}
+
 	perRack = make(map[string][]string, len(r.players))
 	for _, player := range r.players {
 		if player.rack != nil {
@@ -800,15 +1706,27 @@ func (r *RoomActor) broadcastState(ctx *actor.ReceiveContext, phase string) {
 	}
 
 	r.publish(ctx, &StateEvent{
-		Phase:        phase,
-		Board:        board,
-		Players:      r.playerViews(),
-		CurrentID:    currentID,
-		OwnerID:      r.ownerID,
-		BagRemaining: bagLeft,
-		TimerMs:      timerMs,
-		PerRack:      perRack,
+		Phase:         phase,
+		Board:         board,
+		Players:       r.playerViews(),
+		CurrentID:     currentID,
+		OwnerID:       r.ownerID,
+		BagRemaining:  bagLeft,
+		TimerMs:       timerMs,
+		ChallengeRule: r.challengeRule,
+		ChallengeMs:   challengeMs,
+		Casual:        r.casual,
+		Spectators:    len(r.spectators),
+		TimeControl:   r.timeControl.String(),
+		Layout:        r.layout.Name,
+		Premiums:      r.layout.Rows(),
+		BagScale:      r.layout.BagScale,
+		Variant:       r.variant,
+		Lexicon:       r.bundle.Lexicon,
+		PerRack:       perRack,
+		Public:        r.public,
 	})
+	r.reportStatus(ctx, phase)
 }
 
 func (r *RoomActor) publish(ctx *actor.ReceiveContext, evt any) {
@@ -834,13 +1752,23 @@ func (r *RoomActor) sendJoined(ctx *actor.ReceiveContext, msg *PlayerHello) {
 		return
 	}
 
+	_, watching := r.spectators[msg.SessionName]
+
+	var layouts []string
+	if registry := registryFromExtension(ctx.ActorSystem()); registry != nil {
+		layouts = registry.LayoutNames()
+	}
+
 	ctx.Tell(sender, &JoinedEvent{
-		For:      msg.PlayerID,
-		Room:     r.code,
-		Language: r.language,
-		PlayerID: msg.PlayerID,
-		Owner:    msg.PlayerID == r.ownerID,
-		Profile:  ProfileView{PlayerID: msg.PlayerID, Name: msg.Name},
+		For:       msg.PlayerID,
+		Room:      r.code,
+		Language:  r.language,
+		PlayerID:  msg.PlayerID,
+		Owner:     msg.PlayerID == r.ownerID && !watching,
+		Spectator: watching,
+		Profile:   ProfileView{PlayerID: msg.PlayerID, Name: msg.Name},
+		Alphabet:  alphabetToWire(r.bundle.Lang),
+		Layouts:   layouts,
 	})
 }
 
@@ -862,10 +1790,12 @@ func (r *RoomActor) cancelSchedule(ctx *actor.ReceiveContext, refPrefix string)
 	delete(r.activeRefs, ref)
 }
 
+// maybeShutdown stops the room once no human is connected. A game in
+// progress keeps its snapshot, so the lobby can bring it back later.
 func (r *RoomActor) maybeShutdown(ctx *actor.ReceiveContext) {
 	humans := 0
 	for _, player := range r.players {
-		if !player.bot {
+		if !player.bot && player.sessionName != "" {
 			humans++
 		}
 	}
@@ -878,7 +1808,6 @@ func (r *RoomActor) maybeShutdown(ctx *actor.ReceiveContext) {
 
 func (r *RoomActor) recordResults(ctx *actor.ReceiveContext, winnerID string) {
 	system := ctx.ActorSystem()
-	store := profileStoreFromExtension(system)
 	leaderboard := r.leaderboard
 	language := r.language
 
@@ -892,11 +1821,7 @@ func (r *RoomActor) recordResults(ctx *actor.ReceiveContext, winnerID string) {
 		ctx.PipeTo(ctx.Self(), func() (any, error) {
 			bg := context.Background()
 
-			ident, err := system.GrainIdentity(bg,
-				GrainPrefix+pid,
-				func(_ context.Context) (actor.Grain, error) {
-					return &PlayerProfileGrain{store: store}, nil
-				})
+			ident, err := profileGrain(bg, system, pid)
 			if err != nil {
 				return nil, err
 			}
@@ -913,15 +1838,21 @@ func (r *RoomActor) recordResults(ctx *actor.ReceiveContext, winnerID string) {
 		return
 	}
 
-	winnerName := ""
-	if player := r.playerByID(winnerID); player != nil {
-		winnerName = player.name
+	seats := make([]RatedSeat, len(r.players))
+	for i, player := range r.players {
+		seats[i] = RatedSeat{
+			PlayerID: player.id,
+			Name:     player.name,
+			Score:    player.score,
+			Bot:      player.bot,
+			Level:    player.botLevel,
+		}
 	}
 
 	ctx.PipeTo(ctx.Self(), func() (any, error) {
 		bg := context.Background()
 
-		if err := leaderboard.RecordWin(bg, language, winnerID, winnerName); err != nil {
+		if err := leaderboard.RecordGame(bg, language, seats); err != nil {
 			return nil, err
 		}
 
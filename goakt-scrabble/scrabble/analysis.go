@@ -0,0 +1,127 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import "fmt"
+
+// TurnAnalysis compares one turn of a recorded game with the
+// top-scoring play the move generator finds for the same rack on the
+// same board.
+type TurnAnalysis struct {
+	// Event is the turn's index in GCGGame.Events.
+	Event  int
+	Player int
+	// Kind is GCGPlay, GCGExchange or GCGPass.
+	Kind GCGKind
+	Rack []Tile
+	// Move is the play made; empty for an exchange or a pass. Played
+	// and BestPlay label the play and Best as "8H HORSE" (blanks in
+	// lowercase).
+	Move     Move
+	Played   string
+	BestPlay string
+	// Score is what the turn kept: a withdrawn phony scores zero.
+	Score int
+	// Best is nil when the rack had no legal play.
+	Best *ScoredMove
+	// Lost is the best play's score minus Score, never negative: the
+	// points left on the table.
+	Lost int
+}
+
+// Analyze walks a finished game's record and scores every play,
+// exchange and pass against BestMove for the mover's rack. Turns
+// recorded without a rack are skipped. dict is the game's dictionary,
+// a *DAWG or, for a Clabbers game, a *Clabbers. It fails with
+// ErrGCGMismatch if a play cannot be made on the board the record
+// builds up.
+func Analyze(g *GCGGame, dict Dictionary, lang *Language) ([]TurnAnalysis, error) {
+	board := g.newBoard()
+	turns := make([]TurnAnalysis, 0, len(g.Events))
+	lastPlay := make(map[int]int)
+
+	for i, evt := range g.Events {
+		switch evt.Kind {
+		case GCGPlay, GCGExchange, GCGPass:
+			if len(evt.Rack) > 0 {
+				rack := &Rack{tiles: append([]Tile(nil), evt.Rack...)}
+				turn := TurnAnalysis{
+					Event:  i,
+					Player: evt.Player,
+					Kind:   evt.Kind,
+					Rack:   evt.Rack,
+					Score:  evt.Score,
+					Best:   BestMove(board, rack, dict, lang),
+				}
+				if evt.Kind == GCGPlay {
+					turn.Move = evt.Move
+					turn.Played = moveLabel(board, evt.Move, lang)
+					lastPlay[evt.Player] = len(turns)
+				}
+				if turn.Best != nil {
+					turn.BestPlay = moveLabel(board, turn.Best.Move, lang)
+				}
+				turn.Lost = lost(turn)
+				turns = append(turns, turn)
+			}
+
+			if evt.Kind != GCGPlay {
+				continue
+			}
+			if err := evt.Move.Apply(board); err != nil {
+				return nil, fmt.Errorf("%w: event %d: %w", ErrGCGMismatch, i+1, err)
+			}
+
+		case GCGWithdraw:
+			idx, ok := lastPlay[evt.Player]
+			if !ok {
+				return nil, fmt.Errorf("%w: event %d withdraws no play", ErrGCGMismatch, i+1)
+			}
+			if _, err := turns[idx].Move.Withdraw(board); err != nil {
+				return nil, fmt.Errorf("%w: event %d: %w", ErrGCGMismatch, i+1, err)
+			}
+			turns[idx].Score = 0
+			turns[idx].Lost = lost(turns[idx])
+			delete(lastPlay, evt.Player)
+		}
+	}
+
+	return turns, nil
+}
+
+func moveLabel(board *Board, move Move, lang *Language) string {
+	pos, word, err := playNotation(board, move, lang, true)
+	if err != nil {
+		return ""
+	}
+
+	return pos + " " + word
+}
+
+func lost(turn TurnAnalysis) int {
+	if turn.Best == nil {
+		return 0
+	}
+
+	return max(turn.Best.Result.Score-turn.Score, 0)
+}
@@ -0,0 +1,122 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import "testing"
+
+func TestAnalyzeScoresTurnsAgainstBestMove(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+
+	horse := rackFromWord(t, lang, "HORSE").Tiles()
+	hot := rackFromWord(t, lang, "HOTZ").Tiles()
+
+	game := &GCGGame{
+		Players: []GCGPlayer{{Nick: "a"}, {Nick: "b"}},
+		Events: []GCGEvent{
+			{Player: 0, Kind: GCGPlay, Rack: horse, Move: Move{Placements: placementsFor(t, lang, "HO", 7, 7, Horizontal)}, Score: 10, Total: 10},
+			{Player: 1, Kind: GCGPass, Rack: hot, Total: 0},
+			{Player: 0, Kind: GCGPass, Total: 10},
+		},
+	}
+
+	turns, err := Analyze(game, dawg, lang)
+	if err != nil {
+		t.Fatalf("analyze: %v", err)
+	}
+
+	if len(turns) != 2 {
+		t.Fatalf("expected 2 analysed turns (the rackless pass is skipped), got %d", len(turns))
+	}
+
+	if turns[0].Best == nil || turns[0].Best.Result.Score != 24 || turns[0].Lost != 14 {
+		t.Errorf("HO instead of HORSE: best %+v, lost %d; want best 24, lost 14", turns[0].Best, turns[0].Lost)
+	}
+
+	if turns[0].Played != "8H HO" || turns[0].BestPlay == "" {
+		t.Errorf("labels: played %q best %q", turns[0].Played, turns[0].BestPlay)
+	}
+
+	if turns[1].Best == nil || turns[1].Lost != turns[1].Best.Result.Score || turns[1].Lost == 0 {
+		t.Errorf("pass with a playable rack should lose the best score, got lost %d", turns[1].Lost)
+	}
+}
+
+func TestAnalyzeWithdrawnPlayScoresZero(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+
+	rack := rackFromWord(t, lang, "HORSE").Tiles()
+	play := Move{Placements: placementsFor(t, lang, "HORSE", 7, 7, Horizontal)}
+
+	game := &GCGGame{
+		Players: []GCGPlayer{{Nick: "a"}, {Nick: "b"}},
+		Events: []GCGEvent{
+			{Player: 0, Kind: GCGPlay, Rack: rack, Move: play, Score: 24, Total: 24},
+			{Player: 0, Kind: GCGWithdraw, Rack: rack, Score: -24, Total: 0},
+			{Player: 1, Kind: GCGPass, Rack: rack, Total: 0},
+		},
+	}
+
+	turns, err := Analyze(game, dawg, lang)
+	if err != nil {
+		t.Fatalf("analyze: %v", err)
+	}
+
+	if turns[0].Score != 0 || turns[0].Lost != 24 {
+		t.Errorf("withdrawn play: score %d lost %d, want 0 and 24", turns[0].Score, turns[0].Lost)
+	}
+
+	// The withdrawal clears the board, so the next player can open with HORSE.
+	if turns[1].Best == nil || turns[1].Best.Result.Score != 24 {
+		t.Errorf("after withdrawal the board should be empty again, best %+v", turns[1].Best)
+	}
+}
+
+func TestAnalyzeClabbers(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+
+	horse := rackFromWord(t, lang, "HORSE").Tiles()
+	s := rackFromWord(t, lang, "S").Tiles()
+
+	game := &GCGGame{
+		Players: []GCGPlayer{{Nick: "a"}, {Nick: "b"}},
+		Events: []GCGEvent{
+			{Player: 0, Kind: GCGPlay, Rack: horse, Move: Move{Placements: placementsFor(t, lang, "EHORS", 7, 7, Horizontal)}, Score: 18, Total: 18},
+			{Player: 1, Kind: GCGPass, Rack: s, Total: 0},
+		},
+	}
+
+	turns, err := Analyze(game, NewClabbers(dawg), lang)
+	if err != nil {
+		t.Fatalf("analyze: %v", err)
+	}
+
+	if turns[0].Best == nil || turns[0].Best.Result.Score != 24 || turns[0].Lost != 6 {
+		t.Errorf("EHORS: best %+v, lost %d; want best 24, lost 6", turns[0].Best, turns[0].Lost)
+	}
+
+	// Only Clabbers can hook the S onto EHORS; the standard dictionary
+	// finds nothing better than SO for 2.
+	if turns[1].Best == nil || turns[1].Lost != 9 {
+		t.Errorf("pass holding S: best %+v, lost %d; want SEHORS for 9", turns[1].Best, turns[1].Lost)
+	}
+}
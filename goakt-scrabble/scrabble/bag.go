@@ -34,15 +34,22 @@ type Bag struct {
 // NewBag builds a full starting bag for lang and shuffles it. Pass a
 // deterministic rng for reproducible tests.
 func NewBag(lang *Language, rng *rand.Rand) *Bag {
-	tiles := make([]Tile, 0, lang.TotalTiles())
+	return NewLayoutBag(lang, StandardLayout(), rng)
+}
+
+// NewLayoutBag builds the starting bag for a game on layout: its
+// BagScale sets of lang's tiles.
+func NewLayoutBag(lang *Language, layout *Layout, rng *rand.Rand) *Bag {
+	scale := max(layout.BagScale, 1)
+	tiles := make([]Tile, 0, lang.TotalTiles()*scale)
 
 	for id, count := range lang.Distribution {
-		for range count {
+		for range count * scale {
 			tiles = append(tiles, Tile{Letter: LetterID(id)})
 		}
 	}
 
-	for range lang.Blanks {
+	for range lang.Blanks * scale {
 		tiles = append(tiles, BlankTile)
 	}
 
@@ -52,6 +59,17 @@ func NewBag(lang *Language, rng *rand.Rand) *Bag {
 	return bag
 }
 
+// RestoreBag rebuilds a bag from tiles saved with Tiles, keeping their
+// order so the restored game draws exactly what the original would have.
+func RestoreBag(lang *Language, tiles []Tile, rng *rand.Rand) *Bag {
+	return &Bag{lang: lang, tiles: append([]Tile(nil), tiles...), rng: rng}
+}
+
+// Tiles returns a copy of the tiles left in the bag, last drawn first.
+func (b *Bag) Tiles() []Tile {
+	return append([]Tile(nil), b.tiles...)
+}
+
 // Remaining returns the number of tiles still in the bag.
 func (b *Bag) Remaining() int {
 	return len(b.tiles)
@@ -95,3 +95,20 @@ func TestBagDeterministicWithSeed(t *testing.T) {
 		}
 	}
 }
+
+func TestRestoreBagDrawsSameTiles(t *testing.T) {
+	bag := newTestBag(t)
+	_ = bag.Draw(14)
+
+	restored := RestoreBag(English(), bag.Tiles(), rand.New(rand.NewPCG(3, 4)))
+	if restored.Remaining() != bag.Remaining() {
+		t.Fatalf("restored bag has %d tiles, want %d", restored.Remaining(), bag.Remaining())
+	}
+
+	for range bag.Remaining() {
+		want := bag.Draw(1)[0]
+		if got := restored.Draw(1)[0]; got != want {
+			t.Fatalf("restored bag drew %+v, original drew %+v", got, want)
+		}
+	}
+}
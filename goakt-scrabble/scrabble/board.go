@@ -22,8 +22,12 @@
 
 package scrabble
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+)
 
+// BoardSize and the center square are those of the standard layout.
 const (
 	BoardSize = 15
 	CenterRow = 7
@@ -40,8 +44,38 @@ const (
 	PremiumTripleLetter
 	PremiumDoubleWord
 	PremiumTripleWord
+	PremiumQuadLetter
+	PremiumQuadWord
 )
 
+// LetterMultiplier is what the square multiplies a new tile's points by.
+func (p Premium) LetterMultiplier() int {
+	switch p {
+	case PremiumDoubleLetter:
+		return 2
+	case PremiumTripleLetter:
+		return 3
+	case PremiumQuadLetter:
+		return 4
+	}
+
+	return 1
+}
+
+// WordMultiplier is what the square multiplies a new word's score by.
+func (p Premium) WordMultiplier() int {
+	switch p {
+	case PremiumDoubleWord:
+		return 2
+	case PremiumTripleWord:
+		return 3
+	case PremiumQuadWord:
+		return 4
+	}
+
+	return 1
+}
+
 // Square is one cell of the board. Premium is fixed at construction; Tile
 // is the zero value while Filled is false.
 type Square struct {
@@ -50,29 +84,54 @@ type Square struct {
 	Filled  bool
 }
 
-// Board is a 15x15 array of Squares (row-major). The premium-square layout
-// is the canonical Hasbro Scrabble layout, identical across EN/FR/ES.
+// Board is a square grid of Squares (row-major) laid out by a Layout.
+// NewBoard uses the canonical Hasbro Scrabble layout, identical across
+// EN/FR/ES.
 type Board struct {
-	squares [BoardSize][BoardSize]Square
+	layout  *Layout
+	squares []Square
 	tiles   int
 }
 
-// NewBoard returns an empty board with premium squares laid out.
+// NewBoard returns an empty standard board with premium squares laid out.
 func NewBoard() *Board {
-	b := &Board{}
+	return NewLayoutBoard(StandardLayout())
+}
+
+// NewLayoutBoard returns an empty board laid out by layout.
+func NewLayoutBoard(layout *Layout) *Board {
+	b := &Board{layout: layout, squares: make([]Square, layout.size*layout.size)}
 
-	for row := range BoardSize {
-		for col := range BoardSize {
-			b.squares[row][col].Premium = standardPremium(row, col)
-		}
+	for i := range b.squares {
+		b.squares[i].Premium = layout.premiums[i]
 	}
 
 	return b
 }
 
+// Layout returns the layout the board was built with.
+func (b *Board) Layout() *Layout {
+	return b.layout
+}
+
+// Size is the number of rows, and of columns.
+func (b *Board) Size() int {
+	return b.layout.size
+}
+
+// Center returns the start square.
+func (b *Board) Center() (int, int) {
+	return b.layout.Center()
+}
+
+// InBounds reports whether (row, col) is on the board.
+func (b *Board) InBounds(row, col int) bool {
+	return b.layout.InBounds(row, col)
+}
+
 // At returns the square at (row, col).
 func (b *Board) At(row, col int) *Square {
-	return &b.squares[row][col]
+	return &b.squares[row*b.layout.size+col]
 }
 
 // IsEmptyBoard reports whether the board has no tiles at all.
@@ -82,7 +141,7 @@ func (b *Board) IsEmptyBoard() bool {
 
 // Place puts a tile on an empty square.
 func (b *Board) Place(row, col int, t Tile) error {
-	sq := &b.squares[row][col]
+	sq := b.At(row, col)
 
 	if sq.Filled {
 		return fmt.Errorf("scrabble: square (%d,%d) is already filled", row, col)
@@ -95,18 +154,30 @@ func (b *Board) Place(row, col int, t Tile) error {
 	return nil
 }
 
+// Lift removes the tile from a filled square and returns it. Used to
+// withdraw a play struck down by a challenge.
+func (b *Board) Lift(row, col int) (Tile, error) {
+	sq := b.At(row, col)
+
+	if !sq.Filled {
+		return Tile{}, fmt.Errorf("scrabble: square (%d,%d) is empty", row, col)
+	}
+
+	tile := sq.Tile
+	sq.Tile = Tile{}
+	sq.Filled = false
+	b.tiles--
+
+	return tile, nil
+}
+
 // Clone returns a deep copy of the board.
 func (b *Board) Clone() *Board {
-	clone := &Board{squares: b.squares, tiles: b.tiles}
+	clone := &Board{layout: b.layout, squares: slices.Clone(b.squares), tiles: b.tiles}
 
 	return clone
 }
 
-// InBounds reports whether (row, col) is a valid coordinate.
-func InBounds(row, col int) bool {
-	return row >= 0 && row < BoardSize && col >= 0 && col < BoardSize
-}
-
 // standardPremium returns the premium type at (row, col) using the D4
 // symmetry of the standard Scrabble board: normalize (row, col) into the
 // 0 <= r <= c <= 7 octant and enumerate only that octant.
@@ -22,10 +22,6 @@
 
 package scrabble
 
-// maxAlphabetForBitset is the largest alphabet size that fits in the uint32
-// cross-check bitsets used by the move generator.
-const maxAlphabetForBitset = 32
-
 // ScoredMove pairs a legal Move with its MoveResult.
 type ScoredMove struct {
 	Move   Move
@@ -42,15 +38,16 @@ func GenerateMoves(board *Board, rack *Rack, dawg *DAWG, lang *Language) []Score
 	gen.crossV = computeCrossChecks(board, dawg, lang, Horizontal)
 
 	if board.IsEmptyBoard() {
-		gen.runFromAnchor(CenterRow, CenterCol, Horizontal)
-		gen.runFromAnchor(CenterRow, CenterCol, Vertical)
+		row, col := board.Center()
+		gen.runFromAnchor(row, col, Horizontal)
+		gen.runFromAnchor(row, col, Vertical)
 		return gen.scored()
 	}
 
 	gen.anchors = buildAnchorGrid(board)
 
-	for row := range BoardSize {
-		for col := range BoardSize {
+	for row := range board.Size() {
+		for col := range board.Size() {
 			if !gen.anchors[row][col] {
 				continue
 			}
@@ -62,10 +59,18 @@ func GenerateMoves(board *Board, rack *Rack, dawg *DAWG, lang *Language) []Score
 	return gen.scored()
 }
 
-// BestMove returns the highest-scoring legal move for the rack, or nil
-// if no legal move exists.
-func BestMove(board *Board, rack *Rack, dawg *DAWG, lang *Language) *ScoredMove {
-	moves := GenerateMoves(board, rack, dawg, lang)
+// BestMove returns the highest-scoring legal move for the rack under
+// dict, a *DAWG, *GADDAG or *Clabbers, or nil if no legal move exists.
+func BestMove(board *Board, rack *Rack, dict Dictionary, lang *Language) *ScoredMove {
+	var moves []ScoredMove
+	switch d := dict.(type) {
+	case *DAWG:
+		moves = GenerateMoves(board, rack, d, lang)
+	case *GADDAG:
+		moves = d.GenerateMoves(board, rack, lang)
+	case *Clabbers:
+		moves = d.GenerateMoves(board, rack, lang)
+	}
 
 	if len(moves) == 0 {
 		return nil
@@ -82,15 +87,28 @@ func BestMove(board *Board, rack *Rack, dawg *DAWG, lang *Language) *ScoredMove
 	return best
 }
 
+// letterSet is a bitset over LetterIDs. It spans the whole uint8 range so
+// alphabets larger than 32 letters (German with umlauts, Spanish with
+// digraphs, or anything a future language needs) fit without truncation.
+type letterSet [4]uint64
+
+func (s *letterSet) add(letter LetterID) {
+	s[letter>>6] |= 1 << (letter & 63)
+}
+
+func (s *letterSet) has(letter LetterID) bool {
+	return s[letter>>6]&(1<<(letter&63)) != 0
+}
+
 // crossChecks holds, for each board position, the bitset of letters that
 // would form a legal perpendicular cross-word at that square. A bit at
 // index LetterID i means "letter i is valid here".
 type crossChecks struct {
-	valid [BoardSize][BoardSize]uint32
+	valid [][]letterSet
 }
 
 func (c *crossChecks) allows(row, col int, letter LetterID) bool {
-	return c.valid[row][col]&(1<<letter) != 0
+	return c.valid[row][col].has(letter)
 }
 
 // genState bundles inputs and scratch state for one GenerateMoves call.
@@ -102,7 +120,7 @@ type genState struct {
 	lang      *Language
 	crossH    *crossChecks // cross-checks for HORIZONTAL moves (cross-words vertical)
 	crossV    *crossChecks // cross-checks for VERTICAL moves (cross-words horizontal)
-	anchors   [BoardSize][BoardSize]bool
+	anchors   [][]bool
 	emitted   []Move
 }
 
@@ -112,6 +130,7 @@ func newGenState(board *Board, rack *Rack, dawg *DAWG, lang *Language) *genState
 		dawg:      dawg,
 		lang:      lang,
 		rackCount: make([]int, lang.AlphabetSize()),
+		anchors:   newGrid[bool](board.Size()),
 	}
 
 	for _, tile := range rack.tiles {
@@ -126,10 +145,16 @@ func newGenState(board *Board, rack *Rack, dawg *DAWG, lang *Language) *genState
 }
 
 func (g *genState) scored() []ScoredMove {
-	out := make([]ScoredMove, 0, len(g.emitted))
+	return scoreMoves(g.board, g.emitted, g.dawg, g.lang)
+}
 
-	for _, move := range g.emitted {
-		result, err := move.Validate(g.board, g.dawg, g.lang)
+// scoreMoves validates generated moves against dict, dropping any it
+// rejects.
+func scoreMoves(board *Board, moves []Move, dict Dictionary, lang *Language) []ScoredMove {
+	out := make([]ScoredMove, 0, len(moves))
+
+	for _, move := range moves {
+		result, err := move.Validate(board, dict, lang)
 		if err != nil {
 			continue
 		}
@@ -156,17 +181,17 @@ func (g *genState) runFromAnchor(row, col int, dir Direction) {
 	g.extendLeft(g.dawg.Root(), nil, row, col, dir, leftLimit)
 }
 
-func (g *genState) walkLeftExisting(row, col int, dir Direction) (*DAWGNode, bool) {
+func (g *genState) walkLeftExisting(row, col int, dir Direction) (DAWGNode, bool) {
 	dRow, dCol := stepBack(dir)
 	r, c := row+dRow, col+dCol
 
-	if !InBounds(r, c) || !g.board.At(r, c).Filled {
+	if !g.board.InBounds(r, c) || !g.board.At(r, c).Filled {
 		return g.dawg.Root(), true
 	}
 
 	for {
 		prev := [2]int{r + dRow, c + dCol}
-		if !InBounds(prev[0], prev[1]) || !g.board.At(prev[0], prev[1]).Filled {
+		if !g.board.InBounds(prev[0], prev[1]) || !g.board.At(prev[0], prev[1]).Filled {
 			break
 		}
 		r, c = prev[0], prev[1]
@@ -178,7 +203,7 @@ func (g *genState) walkLeftExisting(row, col int, dir Direction) (*DAWGNode, boo
 	for {
 		next, ok := node.Edge(g.board.At(r, c).Tile.Letter)
 		if !ok {
-			return nil, false
+			return DAWGNode{}, false
 		}
 		node = next
 		nr, nc := r+fwdR, c+fwdC
@@ -196,7 +221,7 @@ func (g *genState) computeLeftLimit(row, col int, dir Direction) int {
 	r := row + dRow
 	c := col + dCol
 
-	for InBounds(r, c) && !g.board.At(r, c).Filled && !g.anchors[r][c] {
+	for g.board.InBounds(r, c) && !g.board.At(r, c).Filled && !g.anchors[r][c] {
 		limit++
 		r += dRow
 		c += dCol
@@ -215,7 +240,7 @@ type partLetter struct {
 
 // extendLeft enumerates left parts of length 0..leftLimit and triggers
 // extendRight at every depth.
-func (g *genState) extendLeft(node *DAWGNode, leftPart []partLetter, anchorRow, anchorCol int, dir Direction, leftLimit int) {
+func (g *genState) extendLeft(node DAWGNode, leftPart []partLetter, anchorRow, anchorCol int, dir Direction, leftLimit int) {
 	leftPlacements := buildLeftPlacements(leftPart, anchorRow, anchorCol, dir)
 	g.extendRight(node, leftPlacements, anchorRow, anchorCol, dir, false)
 
@@ -223,7 +248,7 @@ func (g *genState) extendLeft(node *DAWGNode, leftPart []partLetter, anchorRow,
 		return
 	}
 
-	node.Each(func(letter LetterID, next *DAWGNode) bool {
+	node.Each(func(letter LetterID, next DAWGNode) bool {
 		if g.rackCount[letter] > 0 {
 			g.rackCount[letter]--
 			extended := growLeftPart(leftPart, partLetter{Letter: letter})
@@ -270,8 +295,8 @@ func buildLeftPlacements(leftPart []partLetter, anchorRow, anchorCol int, dir Di
 
 // extendRight walks rightward from the current square. A move is recorded
 // only when the DAWG node is terminal AND we have placed at or past the anchor.
-func (g *genState) extendRight(node *DAWGNode, placements []Placement, row, col int, dir Direction, placedAtAnchor bool) {
-	if !InBounds(row, col) {
+func (g *genState) extendRight(node DAWGNode, placements []Placement, row, col int, dir Direction, placedAtAnchor bool) {
+	if !g.board.InBounds(row, col) {
 		if node.Terminal() && placedAtAnchor {
 			g.record(placements)
 		}
@@ -288,7 +313,7 @@ func (g *genState) extendRight(node *DAWGNode, placements []Placement, row, col
 
 		cross := g.crossFor(dir)
 
-		node.Each(func(letter LetterID, next *DAWGNode) bool {
+		node.Each(func(letter LetterID, next DAWGNode) bool {
 			if !cross.allows(row, col, letter) {
 				return true
 			}
@@ -338,13 +363,13 @@ func (g *genState) record(placements []Placement) {
 
 // computeCrossChecks builds the per-square cross-check bitset. perpDir is
 // the direction of the cross-word formed by a placement.
-func computeCrossChecks(board *Board, dawg *DAWG, lang *Language, perpDir Direction) *crossChecks {
-	cs := &crossChecks{}
+func computeCrossChecks(board *Board, dict Dictionary, lang *Language, perpDir Direction) *crossChecks {
+	cs := &crossChecks{valid: newGrid[letterSet](board.Size())}
 	all := allLettersMask(lang)
 	dRow, dCol := step(perpDir)
 
-	for row := range BoardSize {
-		for col := range BoardSize {
+	for row := range board.Size() {
+		for col := range board.Size() {
 			if board.At(row, col).Filled {
 				continue
 			}
@@ -358,15 +383,15 @@ func computeCrossChecks(board *Board, dawg *DAWG, lang *Language, perpDir Direct
 				continue
 			}
 
-			var bits uint32
+			var bits letterSet
 
 			for id := range lang.AlphabetSize() {
 				candidate := make([]LetterID, 0, len(prefix)+1+len(suffix))
 				candidate = append(candidate, prefix...)
 				candidate = append(candidate, LetterID(id))
 				candidate = append(candidate, suffix...)
-				if dawg.Contains(candidate) {
-					bits |= 1 << id
+				if dict.Contains(candidate) {
+					bits.add(LetterID(id))
 				}
 			}
 
@@ -380,7 +405,7 @@ func computeCrossChecks(board *Board, dawg *DAWG, lang *Language, perpDir Direct
 func walkExisting(board *Board, row, col, dRow, dCol int) []LetterID {
 	var out []LetterID
 
-	for InBounds(row, col) && board.At(row, col).Filled {
+	for board.InBounds(row, col) && board.At(row, col).Filled {
 		out = append(out, board.At(row, col).Tile.Letter)
 		row += dRow
 		col += dCol
@@ -395,19 +420,21 @@ func reverseInPlace(s []LetterID) {
 	}
 }
 
-func allLettersMask(lang *Language) uint32 {
-	if lang.AlphabetSize() >= maxAlphabetForBitset {
-		return ^uint32(0)
+func allLettersMask(lang *Language) letterSet {
+	var all letterSet
+
+	for id := range lang.AlphabetSize() {
+		all.add(LetterID(id))
 	}
 
-	return (uint32(1) << lang.AlphabetSize()) - 1
+	return all
 }
 
-func buildAnchorGrid(board *Board) [BoardSize][BoardSize]bool {
-	var grid [BoardSize][BoardSize]bool
+func buildAnchorGrid(board *Board) [][]bool {
+	grid := newGrid[bool](board.Size())
 
-	for row := range BoardSize {
-		for col := range BoardSize {
+	for row := range board.Size() {
+		for col := range board.Size() {
 			if board.At(row, col).Filled {
 				continue
 			}
@@ -420,11 +447,23 @@ func buildAnchorGrid(board *Board) [BoardSize][BoardSize]bool {
 	return grid
 }
 
+// newGrid allocates a size x size grid backed by one slice.
+func newGrid[T any](size int) [][]T {
+	cells := make([]T, size*size)
+	grid := make([][]T, size)
+
+	for row := range grid {
+		grid[row] = cells[row*size : (row+1)*size : (row+1)*size]
+	}
+
+	return grid
+}
+
 func hasFilledNeighbor(board *Board, row, col int) bool {
 	neighbors := [4][2]int{{row - 1, col}, {row + 1, col}, {row, col - 1}, {row, col + 1}}
 
 	for _, n := range neighbors {
-		if InBounds(n[0], n[1]) && board.At(n[0], n[1]).Filled {
+		if board.InBounds(n[0], n[1]) && board.At(n[0], n[1]).Filled {
 			return true
 		}
 	}
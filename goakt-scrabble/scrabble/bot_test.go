@@ -23,6 +23,7 @@
 package scrabble
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -98,3 +99,54 @@ func TestBotUsesBlankToFormWord(t *testing.T) {
 		t.Errorf("expected blank tile in placement, got %+v", best.Move.Placements[0].Tile)
 	}
 }
+
+func TestCrossChecksBeyond32Letters(t *testing.T) {
+	faces := make([]string, 0, 40)
+	for r := 'A'; r <= 'Z'; r++ {
+		faces = append(faces, string(r))
+	}
+	for r := 'Α'; len(faces) < 40; r++ {
+		faces = append(faces, string(r))
+	}
+
+	points := make([]int, len(faces))
+	dist := make([]int, len(faces))
+	for i := range faces {
+		points[i] = 1
+		dist[i] = 1
+	}
+
+	lang := newLanguage("Test", "xx", faces, points, dist, 0, nil)
+
+	all := allLettersMask(lang)
+	last := LetterID(len(faces) - 1)
+	if !all.has(last) || all.has(last+1) {
+		t.Fatalf("allLettersMask: letter %d must be set, %d must not", last, last+1)
+	}
+
+	// Ξ sits past index 32; the move generator must still offer it.
+	xi, ok := lang.ID('Ξ')
+	if !ok || xi < 32 {
+		t.Fatalf("expected Ξ beyond index 32, got (%d, %v)", xi, ok)
+	}
+
+	dawg, err := BuildDAWG(lang, strings.NewReader("AΞ\nΞA\n"))
+	if err != nil {
+		t.Fatalf("build dawg: %v", err)
+	}
+
+	board := NewBoard()
+	placeWord(t, board, lang, "AΞ", 7, 7, Horizontal)
+
+	rack := NewRack()
+	rack.tiles = []Tile{{Letter: xi}}
+
+	best := BestMove(board, rack, dawg, lang)
+	if best == nil {
+		t.Fatal("expected ΞA to be playable through the A")
+	}
+
+	if best.Move.Placements[0].Tile.Letter != xi {
+		t.Errorf("expected Ξ placement, got %+v", best.Move.Placements[0])
+	}
+}
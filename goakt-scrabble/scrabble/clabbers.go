@@ -0,0 +1,365 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import "slices"
+
+// Clabbers is the dictionary of the Clabbers variant: a word stands if
+// its letters can be rearranged into a word the DAWG contains, so QAT,
+// TAQ and AQT are all good. It satisfies Dictionary, so Validate and
+// Phonies play by Clabbers rules when handed one in place of the DAWG,
+// and BestMove and Analyze search its own move generator.
+type Clabbers struct {
+	dawg *DAWG
+}
+
+var _ Dictionary = (*Clabbers)(nil)
+
+// NewClabbers wraps dawg for the Clabbers variant.
+func NewClabbers(dawg *DAWG) *Clabbers {
+	return &Clabbers{dawg: dawg}
+}
+
+// Contains reports whether some anagram of word is in the DAWG.
+func (c *Clabbers) Contains(word []LetterID) bool {
+	if len(word) < MinWordLength {
+		return false
+	}
+
+	var top LetterID
+	for _, id := range word {
+		top = max(top, id)
+	}
+
+	counts := make([]int, int(top)+1)
+	for _, id := range word {
+		counts[id]++
+	}
+
+	return hasAnagram(c.dawg.Root(), counts, len(word))
+}
+
+// hasAnagram walks the DAWG spending counts, the letters still to place,
+// until remaining reaches zero on a terminal node.
+func hasAnagram(node DAWGNode, counts []int, remaining int) bool {
+	if remaining == 0 {
+		return node.Terminal()
+	}
+
+	found := false
+
+	node.Each(func(letter LetterID, next DAWGNode) bool {
+		if int(letter) >= len(counts) || counts[letter] == 0 {
+			return true
+		}
+
+		counts[letter]--
+		found = hasAnagram(next, counts, remaining-1)
+		counts[letter]++
+
+		return !found
+	})
+
+	return found
+}
+
+// GenerateMoves returns the legal Clabbers moves available to the rack
+// on the board. The DAWG generator cannot find them: it spells words
+// in order, while a Clabbers word only has to hold the letters of one.
+// Instead every run of squares a play could fill is matched by its
+// letters alone, the board's plus those drawn from the rack, and each
+// set of rack tiles that completes a word is laid out in every order
+// the cross-words allow. Where a letter could come from a tile or a
+// blank, only the tile is tried.
+func (c *Clabbers) GenerateMoves(board *Board, rack *Rack, lang *Language) []ScoredMove {
+	gen := &clabbersGen{
+		clabbers:  c,
+		board:     board,
+		rackCount: make([]int, lang.AlphabetSize()),
+		tiles:     len(rack.tiles),
+		draws:     make(map[string][][]tileCount),
+	}
+
+	for _, tile := range rack.tiles {
+		if tile.Blank {
+			gen.blanks++
+		} else {
+			gen.rackCount[tile.Letter]++
+		}
+	}
+
+	// Every candidate is validated again to score it, asking about the
+	// same few letter sets over and over.
+	dict := &clabbersMemo{clabbers: c, known: make(map[string]bool)}
+
+	gen.crossH = computeCrossChecks(board, dict, lang, Vertical)
+	gen.crossV = computeCrossChecks(board, dict, lang, Horizontal)
+
+	if board.IsEmptyBoard() {
+		gen.anchors = newGrid[bool](board.Size())
+		row, col := board.Center()
+		gen.anchors[row][col] = true
+	} else {
+		gen.anchors = buildAnchorGrid(board)
+	}
+
+	for line := range board.Size() {
+		gen.spans(line, Horizontal)
+		gen.spans(line, Vertical)
+	}
+
+	return scoreMoves(board, gen.emitted, dict, lang)
+}
+
+// clabbersMemo remembers Clabbers.Contains by the word's letters.
+type clabbersMemo struct {
+	clabbers *Clabbers
+	known    map[string]bool
+}
+
+func (m *clabbersMemo) Contains(word []LetterID) bool {
+	key := make([]byte, len(word))
+	for i, letter := range word {
+		key[i] = byte(letter)
+	}
+	slices.Sort(key)
+
+	found, ok := m.known[string(key)]
+	if !ok {
+		found = m.clabbers.Contains(word)
+		m.known[string(key)] = found
+	}
+
+	return found
+}
+
+// tileCount is one kind of tile in a draw and how many of it to place.
+type tileCount struct {
+	tile Tile
+	n    int
+}
+
+// clabbersGen bundles inputs and scratch state for one
+// Clabbers.GenerateMoves call.
+type clabbersGen struct {
+	clabbers  *Clabbers
+	board     *Board
+	rackCount []int // index = LetterID, value = how many of that tile remain in rack
+	blanks    int
+	tiles     int
+	crossH    *crossChecks
+	crossV    *crossChecks
+	anchors   [][]bool
+	// draws caches drawsFor by the run's board letters and length.
+	draws   map[string][][]tileCount
+	emitted []Move
+}
+
+// spans tries every run of squares along one line that a play could
+// fill: bounded by the board edge or an empty square at both ends,
+// covering an anchor, and with no more empty squares than the rack
+// has tiles.
+func (g *clabbersGen) spans(line int, dir Direction) {
+	size := g.board.Size()
+	at := func(i int) (int, int) {
+		if dir == Horizontal {
+			return line, i
+		}
+		return i, line
+	}
+
+	for start := range size {
+		if start > 0 && g.board.At(at(start-1)).Filled {
+			continue
+		}
+
+		var fixed []LetterID
+		var empties [][2]int
+		anchored := false
+
+		for end := start; end < size; end++ {
+			row, col := at(end)
+			if sq := g.board.At(row, col); sq.Filled {
+				fixed = append(fixed, sq.Tile.Letter)
+			} else {
+				if len(empties) == g.tiles {
+					break
+				}
+				empties = append(empties, [2]int{row, col})
+				anchored = anchored || g.anchors[row][col]
+			}
+
+			if end+1 < size && g.board.At(at(end+1)).Filled {
+				continue
+			}
+			if end > start && anchored {
+				g.fill(fixed, empties, dir)
+			}
+		}
+	}
+}
+
+// fill lays out every draw that turns the run's board letters into a
+// word on the run's empty squares.
+func (g *clabbersGen) fill(fixed []LetterID, empties [][2]int, dir Direction) {
+	cross := g.crossV
+	if dir == Horizontal {
+		cross = g.crossH
+	}
+
+	placed := make([]Placement, 0, len(empties))
+	for _, draw := range g.drawsFor(fixed, len(empties)) {
+		g.layOut(draw, empties, cross, placed)
+	}
+}
+
+// layOut places the draw's tiles on the empty squares in each distinct
+// order the cross-checks allow.
+func (g *clabbersGen) layOut(draw []tileCount, empties [][2]int, cross *crossChecks, placed []Placement) {
+	if len(placed) == len(empties) {
+		g.emitted = append(g.emitted, Move{Placements: slices.Clone(placed)})
+		return
+	}
+
+	row, col := empties[len(placed)][0], empties[len(placed)][1]
+
+	for i := range draw {
+		if draw[i].n == 0 || !cross.allows(row, col, draw[i].tile.Letter) {
+			continue
+		}
+
+		draw[i].n--
+		g.layOut(draw, empties, cross, append(placed, Placement{Row: row, Col: col, Tile: draw[i].tile}))
+		draw[i].n++
+	}
+}
+
+// drawsFor returns each set of k rack tiles that, together with the
+// board letters fixed, holds the letters of a word in the DAWG.
+func (g *clabbersGen) drawsFor(fixed []LetterID, k int) [][]tileCount {
+	key := make([]byte, 0, len(fixed)+1)
+	key = append(key, byte(k))
+	for _, letter := range fixed {
+		key = append(key, byte(letter))
+	}
+	slices.Sort(key[1:])
+
+	if draws, ok := g.draws[string(key)]; ok {
+		return draws
+	}
+
+	counts := make([]int, len(g.rackCount))
+	for _, letter := range fixed {
+		counts[letter]++
+	}
+
+	var draws [][]tileCount
+	seen := make(map[string]bool)
+	drawn := make([]Tile, 0, k)
+	fixedLeft := len(fixed)
+
+	var walk func(node DAWGNode, remaining int)
+	walk = func(node DAWGNode, remaining int) {
+		if remaining == 0 {
+			if node.Terminal() && len(drawn) == k {
+				if draw, id := countTiles(drawn); !seen[id] {
+					seen[id] = true
+					draws = append(draws, draw)
+				}
+			}
+			return
+		}
+
+		if fixedLeft > remaining {
+			return
+		}
+
+		node.Each(func(letter LetterID, next DAWGNode) bool {
+			switch {
+			case int(letter) >= len(counts):
+			case counts[letter] > 0:
+				counts[letter]--
+				fixedLeft--
+				walk(next, remaining-1)
+				fixedLeft++
+				counts[letter]++
+			case len(drawn) == k:
+			case g.rackCount[letter] > 0:
+				g.rackCount[letter]--
+				drawn = append(drawn, Tile{Letter: letter})
+				walk(next, remaining-1)
+				drawn = drawn[:len(drawn)-1]
+				g.rackCount[letter]++
+			case g.blanks > 0:
+				g.blanks--
+				drawn = append(drawn, Tile{Letter: letter, Blank: true})
+				walk(next, remaining-1)
+				drawn = drawn[:len(drawn)-1]
+				g.blanks++
+			}
+			return true
+		})
+	}
+
+	walk(g.clabbers.dawg.Root(), len(fixed)+k)
+	g.draws[string(key)] = draws
+
+	return draws
+}
+
+// countTiles groups tiles by kind, returning the groups and a key that
+// is the same for any ordering of the same tiles.
+func countTiles(tiles []Tile) ([]tileCount, string) {
+	sorted := slices.Clone(tiles)
+	slices.SortFunc(sorted, func(a, b Tile) int {
+		if a.Letter != b.Letter {
+			return int(a.Letter) - int(b.Letter)
+		}
+		if a.Blank == b.Blank {
+			return 0
+		}
+		if b.Blank {
+			return -1
+		}
+		return 1
+	})
+
+	var groups []tileCount
+	key := make([]byte, 0, 2*len(sorted))
+
+	for _, tile := range sorted {
+		if n := len(groups); n > 0 && groups[n-1].tile == tile {
+			groups[n-1].n++
+		} else {
+			groups = append(groups, tileCount{tile: tile, n: 1})
+		}
+
+		flag := byte(0)
+		if tile.Blank {
+			flag = 1
+		}
+		key = append(key, byte(tile.Letter), flag)
+	}
+
+	return groups, string(key)
+}
@@ -0,0 +1,106 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClabbersContains(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+	clabbers := NewClabbers(dawg)
+
+	for _, word := range []string{"HORSE", "SHORE", "EHORS", "IQ", "AZ"} {
+		ids, _ := lang.NormalizeWord(word)
+		if !clabbers.Contains(ids) {
+			t.Errorf("%s: expected an anagram in the dictionary", word)
+		}
+	}
+
+	for _, word := range []string{"HORSEX", "HORS", "Q"} {
+		ids, _ := lang.NormalizeWord(word)
+		if clabbers.Contains(ids) {
+			t.Errorf("%s: expected no anagram in the dictionary", word)
+		}
+	}
+}
+
+func TestClabbersValidate(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+	board := NewBoard()
+	move := Move{Placements: placementsFor(t, lang, "EHORS", 7, 7, Horizontal)}
+
+	var invalid *InvalidWordError
+	if _, err := move.Validate(board, dawg, lang); !errors.As(err, &invalid) {
+		t.Fatalf("expected EHORS rejected under standard rules, got %v", err)
+	}
+
+	result, err := move.Validate(board, NewClabbers(dawg), lang)
+	if err != nil {
+		t.Fatalf("expected EHORS accepted under Clabbers: %v", err)
+	}
+
+	if result.Score != 18 {
+		t.Errorf("score: got %d want 18", result.Score)
+	}
+}
+
+func TestClabbersGenerateMoves(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+	clabbers := NewClabbers(dawg)
+
+	if best := BestMove(NewBoard(), rackFromWord(t, lang, "HORSE"), clabbers, lang); best == nil || best.Result.Score != 24 {
+		t.Fatalf("opening: expected a 24-point anagram of HORSE, got %+v", best)
+	}
+
+	// EHORS is no word, so the DAWG generator cannot build on it; under
+	// Clabbers an S makes SEHORS, an anagram of HORSES.
+	board := NewBoard()
+	if err := (Move{Placements: placementsFor(t, lang, "EHORS", 7, 7, Horizontal)}).Apply(board); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	best := BestMove(board, rackFromWord(t, lang, "S"), clabbers, lang)
+	if best == nil || best.Result.Score != 9 {
+		t.Fatalf("hook: expected SEHORS for 9, got %+v", best)
+	}
+
+	for _, rack := range []string{"S", "HOTZ", "QI", "BITE", "JOY"} {
+		var want int
+		if best := BestMove(board, rackFromWord(t, lang, rack), dawg, lang); best != nil {
+			want = best.Result.Score
+		}
+
+		for _, move := range clabbers.GenerateMoves(board, rackFromWord(t, lang, rack), lang) {
+			if _, err := move.Move.Validate(board, clabbers, lang); err != nil {
+				t.Errorf("%s: generated move fails validation: %v", rack, err)
+			}
+		}
+
+		// Every word is a valid Clabbers play, so Clabbers can do no worse.
+		if best := BestMove(board, rackFromWord(t, lang, rack), clabbers, lang); best == nil || best.Result.Score < want {
+			t.Errorf("%s: Clabbers best %+v scores below the standard best %d", rack, best, want)
+		}
+	}
+}
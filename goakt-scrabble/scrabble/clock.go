@@ -0,0 +1,141 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// OvertimePenaltyPerMinute is the tournament penalty for running
+	// over time: 10 points for each minute, or part of one, past the
+	// end of a player's bank.
+	OvertimePenaltyPerMinute = 10
+
+	// MaxOvertime is how far a player may run over. A player who
+	// reaches it ends the game, so no overtime penalty is larger than
+	// OvertimePenalty(MaxOvertime).
+	MaxOvertime = 10 * time.Minute
+
+	maxBankMinutes      = 120
+	maxIncrementSeconds = 60
+)
+
+// ErrBadTimeControl is returned by ParseTimeControl.
+var ErrBadTimeControl = errors.New("scrabble: bad time control")
+
+// TimeControl is a tournament time control: the bank each player starts
+// with and the increment added to it after each of their turns. The
+// zero value is an untimed game.
+type TimeControl struct {
+	Bank      time.Duration
+	Increment time.Duration
+}
+
+// ParseTimeControl parses "minutes+seconds" ("25+0", "20+5"), the
+// inverse of TimeControl.String. The empty string is an untimed game.
+func ParseTimeControl(s string) (TimeControl, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return TimeControl{}, nil
+	}
+
+	bank, inc, ok := strings.Cut(s, "+")
+	if !ok {
+		inc = "0"
+	}
+
+	minutes, err := strconv.Atoi(bank)
+	if err != nil || minutes < 1 || minutes > maxBankMinutes {
+		return TimeControl{}, fmt.Errorf("%w: %q", ErrBadTimeControl, s)
+	}
+
+	seconds, err := strconv.Atoi(inc)
+	if err != nil || seconds < 0 || seconds > maxIncrementSeconds {
+		return TimeControl{}, fmt.Errorf("%w: %q", ErrBadTimeControl, s)
+	}
+
+	return TimeControl{
+		Bank:      time.Duration(minutes) * time.Minute,
+		Increment: time.Duration(seconds) * time.Second,
+	}, nil
+}
+
+// Timed reports whether tc is a timed game.
+func (tc TimeControl) Timed() bool {
+	return tc.Bank > 0
+}
+
+func (tc TimeControl) String() string {
+	if !tc.Timed() {
+		return ""
+	}
+
+	return fmt.Sprintf("%d+%d", int(tc.Bank/time.Minute), int(tc.Increment/time.Second))
+}
+
+// Clock is one player's chess clock. Remaining goes negative once the
+// player is in overtime.
+type Clock struct {
+	Remaining time.Duration
+}
+
+// NewClock returns a clock holding the full bank of tc.
+func NewClock(tc TimeControl) Clock {
+	return Clock{Remaining: tc.Bank}
+}
+
+// Spend charges a turn that took elapsed, then adds tc's increment
+// if the turn is over. A pause charges the time so far without it.
+func (c *Clock) Spend(elapsed time.Duration, tc TimeControl, turnOver bool) {
+	c.Remaining -= elapsed
+	if turnOver {
+		c.Remaining += tc.Increment
+	}
+}
+
+// Overtime is how far the clock has run past zero, at most
+// MaxOvertime.
+func (c Clock) Overtime() time.Duration {
+	return min(max(-c.Remaining, 0), MaxOvertime)
+}
+
+// Flagged reports whether the player has used up MaxOvertime too.
+func (c Clock) Flagged() bool {
+	return c.Overtime() >= MaxOvertime
+}
+
+// OvertimePenalty is the score penalty for running over by overtime.
+func OvertimePenalty(overtime time.Duration) int {
+	if overtime <= 0 {
+		return 0
+	}
+
+	minutes := (overtime + time.Minute - 1) / time.Minute
+
+	return int(minutes) * OvertimePenaltyPerMinute
+}
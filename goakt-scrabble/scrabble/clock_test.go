@@ -0,0 +1,107 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseTimeControl(t *testing.T) {
+	cases := []struct {
+		in   string
+		want TimeControl
+	}{
+		{"", TimeControl{}},
+		{"25", TimeControl{Bank: 25 * time.Minute}},
+		{"25+0", TimeControl{Bank: 25 * time.Minute}},
+		{"20+5", TimeControl{Bank: 20 * time.Minute, Increment: 5 * time.Second}},
+	}
+
+	for _, tc := range cases {
+		got, err := ParseTimeControl(tc.in)
+		if err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%q: got %+v want %+v", tc.in, got, tc.want)
+		}
+	}
+
+	for _, bad := range []string{"0", "121+0", "25+61", "x+5", "25+-1"} {
+		if _, err := ParseTimeControl(bad); !errors.Is(err, ErrBadTimeControl) {
+			t.Errorf("%q: expected ErrBadTimeControl, got %v", bad, err)
+		}
+	}
+
+	if s := (TimeControl{Bank: 20 * time.Minute, Increment: 5 * time.Second}).String(); s != "20+5" {
+		t.Errorf("String: got %q want %q", s, "20+5")
+	}
+}
+
+func TestClockSpend(t *testing.T) {
+	tc := TimeControl{Bank: time.Minute, Increment: 5 * time.Second}
+	clock := NewClock(tc)
+
+	clock.Spend(20*time.Second, tc, false)
+	clock.Spend(10*time.Second, tc, true)
+	if clock.Remaining != 35*time.Second {
+		t.Errorf("remaining: got %v want 35s", clock.Remaining)
+	}
+
+	clock.Spend(2*time.Minute, tc, true)
+	if clock.Overtime() != 80*time.Second {
+		t.Errorf("overtime: got %v want 80s", clock.Overtime())
+	}
+
+	if clock.Flagged() {
+		t.Error("80s over should not flag")
+	}
+
+	clock.Spend(MaxOvertime, tc, false)
+	if !clock.Flagged() {
+		t.Error("past MaxOvertime should flag")
+	}
+	if clock.Overtime() != MaxOvertime {
+		t.Errorf("overtime past the cap: got %v want %v", clock.Overtime(), MaxOvertime)
+	}
+}
+
+func TestOvertimePenalty(t *testing.T) {
+	cases := map[time.Duration]int{
+		0:                0,
+		-time.Minute:     0,
+		time.Second:      10,
+		time.Minute:      10,
+		61 * time.Second: 20,
+		5 * time.Minute:  50,
+	}
+
+	for over, want := range cases {
+		if got := OvertimePenalty(over); got != want {
+			t.Errorf("OvertimePenalty(%v): got %d want %d", over, got, want)
+		}
+	}
+}
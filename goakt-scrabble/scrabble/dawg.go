@@ -24,6 +24,9 @@ package scrabble
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"sort"
@@ -35,32 +38,87 @@ const (
 	scannerMaxBuffer     = 1024 * 1024
 )
 
-// DAWG is a trie-shaped lookup structure over a language's words. It
-// implements Dictionary and exposes edge traversal for the move generator.
+// Packed node layout. A node is a header word, holding its edge count
+// and terminal flag, followed by one word per edge, sorted by letter,
+// holding the letter in the top byte and the target node's offset in
+// the rest.
+const (
+	dawgEdgeCountMask = 1<<9 - 1
+	dawgTerminalBit   = 1 << 9
+	dawgLetterShift   = 24
+	dawgTargetMask    = 1<<dawgLetterShift - 1
+)
+
+// ErrDAWGTooLarge is returned by BuildDAWG when the minimized graph does
+// not fit the 24-bit node offsets of the packed layout.
+var ErrDAWGTooLarge = errors.New("scrabble: word graph too large to pack")
+
+// DAWG is a minimized word graph over a language's words: a trie whose
+// identical suffix subtrees are stored once. It implements Dictionary
+// and exposes edge traversal for the move generator.
+//
+// The graph lives in a single []uint32, children before parents, which
+// is also its file format (see WriteTo and DecodeDAWG); there are no
+// per-node allocations for the garbage collector to walk.
 type DAWG struct {
-	lang *Language
-	root *DAWGNode
-	size int
+	lang   *Language
+	nodes  []uint32
+	root   uint32
+	size   int
+	source [sha256.Size]byte
 }
 
-// DAWGNode is one node in the DAWG. Edges are kept sorted by letter so
-// callers can iterate alphabetically without re-sorting.
+// DAWGNode is a position in the DAWG. It is a small value, cheap to
+// copy; only nodes obtained from Root, Edge or Each are valid.
 type DAWGNode struct {
-	edges    []dawgEdge
+	nodes []uint32
+	off   uint32
+}
+
+// trieNode is the mutable trie BuildDAWG inserts into before packing.
+type trieNode struct {
+	edges    []trieEdge
 	terminal bool
 }
 
-type dawgEdge struct {
+type trieEdge struct {
 	letter LetterID
-	target *DAWGNode
+	target *trieNode
 }
 
 // BuildDAWG reads one word per line from r. Comments (#), blank lines,
 // words shorter than MinWordLength, and words with characters not in the
-// alphabet are skipped silently.
+// alphabet are skipped silently. Length is counted in tiles, so a digraph
+// such as Spanish LL counts as one letter.
 func BuildDAWG(lang *Language, r io.Reader) (*DAWG, error) {
-	dawg := &DAWG{lang: lang, root: &DAWGNode{}}
+	dawg := &DAWG{lang: lang}
+	root := &trieNode{}
+
+	hash := sha256.New()
+	err := scanWords(lang, io.TeeReader(r, hash), func(ids []LetterID) {
+		if root.insert(ids) {
+			dawg.size++
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, rootOff, err := pack(root)
+	if err != nil {
+		return nil, err
+	}
+
+	dawg.nodes = nodes
+	dawg.root = rootOff
+	hash.Sum(dawg.source[:0])
+
+	return dawg, nil
+}
 
+// scanWords calls add with each playable word of a wordlist, as
+// BuildDAWG describes.
+func scanWords(lang *Language, r io.Reader, add func(ids []LetterID)) error {
 	scanner := bufio.NewScanner(r)
 	scanner.Buffer(make([]byte, scannerInitialBuffer), scannerMaxBuffer)
 
@@ -79,16 +137,14 @@ func BuildDAWG(lang *Language, r io.Reader) (*DAWG, error) {
 			continue
 		}
 
-		if dawg.insert(ids) {
-			dawg.size++
-		}
+		add(ids)
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("scrabble: reading wordlist: %w", err)
+		return fmt.Errorf("scrabble: reading wordlist: %w", err)
 	}
 
-	return dawg, nil
+	return nil
 }
 
 // Size returns the number of words inserted into the DAWG.
@@ -96,9 +152,14 @@ func (d *DAWG) Size() int {
 	return d.size
 }
 
+// Source returns the SHA-256 of the wordlist the DAWG was built from.
+func (d *DAWG) Source() [sha256.Size]byte {
+	return d.source
+}
+
 // Root returns the entry point for move-generator traversal.
-func (d *DAWG) Root() *DAWGNode {
-	return d.root
+func (d *DAWG) Root() DAWGNode {
+	return DAWGNode{nodes: d.nodes, off: d.root}
 }
 
 // Contains reports whether word (already normalized to LetterIDs) is in
@@ -108,7 +169,7 @@ func (d *DAWG) Contains(word []LetterID) bool {
 		return false
 	}
 
-	node := d.root
+	node := d.Root()
 
 	for _, id := range word {
 		next, ok := node.Edge(id)
@@ -118,7 +179,7 @@ func (d *DAWG) Contains(word []LetterID) bool {
 		node = next
 	}
 
-	return node.terminal
+	return node.Terminal()
 }
 
 // ContainsString is a convenience wrapper that normalizes the word first.
@@ -132,41 +193,54 @@ func (d *DAWG) ContainsString(word string) bool {
 }
 
 // Terminal reports whether the node represents the end of a valid word.
-func (n *DAWGNode) Terminal() bool {
-	return n.terminal
+func (n DAWGNode) Terminal() bool {
+	return n.nodes[n.off]&dawgTerminalBit != 0
 }
 
-// Edge returns the child reached by following letter, or (nil, false) if
-// no such edge exists.
-func (n *DAWGNode) Edge(letter LetterID) (*DAWGNode, bool) {
-	idx := sort.Search(len(n.edges), func(i int) bool {
-		return n.edges[i].letter >= letter
-	})
+// Edge returns the child reached by following letter, or false if no
+// such edge exists.
+func (n DAWGNode) Edge(letter LetterID) (DAWGNode, bool) {
+	edges := n.edges()
+
+	lo, hi := 0, len(edges)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if LetterID(edges[mid]>>dawgLetterShift) < letter {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
 
-	if idx >= len(n.edges) || n.edges[idx].letter != letter {
-		return nil, false
+	if lo == len(edges) || LetterID(edges[lo]>>dawgLetterShift) != letter {
+		return DAWGNode{}, false
 	}
 
-	return n.edges[idx].target, true
+	return DAWGNode{nodes: n.nodes, off: edges[lo] & dawgTargetMask}, true
 }
 
 // Each calls fn for every outgoing edge in sorted-letter order. Iteration
 // stops early if fn returns false.
-func (n *DAWGNode) Each(fn func(letter LetterID, next *DAWGNode) bool) {
-	for _, edge := range n.edges {
-		if !fn(edge.letter, edge.target) {
+func (n DAWGNode) Each(fn func(letter LetterID, next DAWGNode) bool) {
+	for _, edge := range n.edges() {
+		if !fn(LetterID(edge>>dawgLetterShift), DAWGNode{nodes: n.nodes, off: edge & dawgTargetMask}) {
 			return
 		}
 	}
 }
 
-func (d *DAWG) insert(word []LetterID) bool {
-	node := d.root
+func (n DAWGNode) edges() []uint32 {
+	start := n.off + 1
+	return n.nodes[start : start+n.nodes[n.off]&dawgEdgeCountMask]
+}
+
+func (n *trieNode) insert(word []LetterID) bool {
+	node := n
 
 	for _, id := range word {
-		next, ok := node.Edge(id)
+		next, ok := node.edge(id)
 		if !ok {
-			next = &DAWGNode{}
+			next = &trieNode{}
 			node.addEdge(id, next)
 		}
 		node = next
@@ -181,12 +255,92 @@ func (d *DAWG) insert(word []LetterID) bool {
 	return true
 }
 
-func (n *DAWGNode) addEdge(letter LetterID, target *DAWGNode) {
+func (n *trieNode) edge(letter LetterID) (*trieNode, bool) {
+	idx := sort.Search(len(n.edges), func(i int) bool {
+		return n.edges[i].letter >= letter
+	})
+
+	if idx >= len(n.edges) || n.edges[idx].letter != letter {
+		return nil, false
+	}
+
+	return n.edges[idx].target, true
+}
+
+func (n *trieNode) addEdge(letter LetterID, target *trieNode) {
 	idx := sort.Search(len(n.edges), func(i int) bool {
 		return n.edges[i].letter >= letter
 	})
 
-	n.edges = append(n.edges, dawgEdge{})
+	n.edges = append(n.edges, trieEdge{})
 	copy(n.edges[idx+1:], n.edges[idx:])
-	n.edges[idx] = dawgEdge{letter: letter, target: target}
+	n.edges[idx] = trieEdge{letter: letter, target: target}
+}
+
+// pack minimizes the trie and lays it out children first. Each node is
+// placed after its children; a node whose packed words match one
+// already placed is that node, since equal children offsets mean equal
+// subtrees.
+func pack(root *trieNode) ([]uint32, uint32, error) {
+	p := newPacker()
+
+	rootOff, err := p.place(root)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return p.nodes, rootOff, nil
+}
+
+// packer lays out one or more tries into a shared node array, so a
+// graph too big to hold as a single trie can be packed a subtree at a
+// time.
+type packer struct {
+	nodes  []uint32
+	placed map[string]uint32
+}
+
+func newPacker() *packer {
+	return &packer{placed: make(map[string]uint32)}
+}
+
+func (p *packer) place(n *trieNode) (uint32, error) {
+	words := make([]uint32, 1, len(n.edges)+1)
+	words[0] = uint32(len(n.edges))
+	if n.terminal {
+		words[0] |= dawgTerminalBit
+	}
+
+	for _, edge := range n.edges {
+		target, err := p.place(edge.target)
+		if err != nil {
+			return 0, err
+		}
+		words = append(words, uint32(edge.letter)<<dawgLetterShift|target)
+	}
+
+	return p.placeWords(words)
+}
+
+// placeWords places a node already packed into its header and edge
+// words, returning the offset of an identical node if there is one.
+func (p *packer) placeWords(words []uint32) (uint32, error) {
+	key := make([]byte, 0, 4*len(words))
+	for _, word := range words {
+		key = binary.LittleEndian.AppendUint32(key, word)
+	}
+
+	if off, ok := p.placed[string(key)]; ok {
+		return off, nil
+	}
+
+	off := uint32(len(p.nodes))
+	if int(off)+len(words) > dawgTargetMask {
+		return 0, ErrDAWGTooLarge
+	}
+
+	p.nodes = append(p.nodes, words...)
+	p.placed[string(key)] = off
+
+	return off, nil
 }
@@ -123,3 +123,35 @@ func TestDAWGEdgeTraversal(t *testing.T) {
 		t.Error("H -> O should exist (HO, HORSE, HOT)")
 	}
 }
+
+func TestDAWGSharesSuffixes(t *testing.T) {
+	lang := English()
+
+	// As a trie this is 13 nodes: the root and one per letter.
+	dawg, err := BuildDAWG(lang, strings.NewReader("CATS\nRATS\nDOGS"))
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	// Minimized, CATS and RATS share everything after their first
+	// letter, and all three words share the node before the final S:
+	// root, {C,R}·, ·A, D·, ·O, {CAT,RAT,DOG}·, and the end of the word.
+	nodes := 0
+	for off := 0; off < len(dawg.nodes); off += 1 + int(dawg.nodes[off]&dawgEdgeCountMask) {
+		nodes++
+	}
+
+	if nodes != 7 {
+		t.Errorf("minimized graph has %d nodes, want 7", nodes)
+	}
+
+	for _, w := range []string{"CATS", "RATS", "DOGS"} {
+		if !dawg.ContainsString(w) {
+			t.Errorf("expected %q after minimization", w)
+		}
+	}
+
+	if dawg.ContainsString("CAT") || dawg.ContainsString("DATS") {
+		t.Error("minimization must not add words")
+	}
+}
@@ -0,0 +1,229 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"unsafe"
+)
+
+// A compiled DAWG file is a fixed 64-byte header followed by the packed
+// node words, little-endian:
+//
+//	magic    [4]byte  "DAWG"
+//	version  uint32   dawgFileVersion
+//	letters  uint32   alphabet size of the language
+//	words    uint32   number of words (Size)
+//	root     uint32   offset of the root node
+//	nodes    uint32   number of node words that follow
+//	language [8]byte  language code, zero-padded
+//	source   [32]byte SHA-256 of the wordlist it was built from
+//
+// The node words start 64 bytes in, so a file mapped or read into
+// memory on a little-endian machine is used in place by DecodeDAWG.
+const (
+	dawgMagic        = "DAWG"
+	dawgFileVersion  = 1
+	dawgHeaderSize   = 64
+	dawgLanguageSize = 8
+)
+
+// ErrBadDAWGFile is returned when a compiled DAWG is truncated, corrupt,
+// or built for a different language.
+var ErrBadDAWGFile = errors.New("scrabble: bad DAWG file")
+
+var nativeLittleEndian = binary.NativeEndian.Uint16([]byte{1, 0}) == 1
+
+type dawgHeader struct {
+	Magic    [4]byte
+	Version  uint32
+	Letters  uint32
+	Words    uint32
+	Root     uint32
+	Nodes    uint32
+	Language [dawgLanguageSize]byte
+	Source   [sha256.Size]byte
+}
+
+// WriteTo writes the DAWG in the compiled format DecodeDAWG reads.
+func (d *DAWG) WriteTo(w io.Writer) (int64, error) {
+	header := dawgHeader{
+		Version: dawgFileVersion,
+		Letters: uint32(d.lang.AlphabetSize()),
+		Words:   uint32(d.size),
+		Root:    d.root,
+		Nodes:   uint32(len(d.nodes)),
+		Source:  d.source,
+	}
+	copy(header.Magic[:], dawgMagic)
+	copy(header.Language[:], d.lang.Code)
+
+	bw := bufio.NewWriter(w)
+	if err := binary.Write(bw, binary.LittleEndian, &header); err != nil {
+		return 0, err
+	}
+
+	var buf [4]byte
+	for _, word := range d.nodes {
+		binary.LittleEndian.PutUint32(buf[:], word)
+		if _, err := bw.Write(buf[:]); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return 0, err
+	}
+
+	return dawgHeaderSize + 4*int64(len(d.nodes)), nil
+}
+
+// ReadDAWG reads a compiled DAWG for lang from r.
+func ReadDAWG(lang *Language, r io.Reader) (*DAWG, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("scrabble: reading DAWG: %w", err)
+	}
+
+	return DecodeDAWG(lang, data)
+}
+
+// DecodeDAWG returns the DAWG compiled into data for lang. On a
+// little-endian machine the DAWG uses data in place, so data must not
+// be modified afterwards. Every node is checked before use: edges must
+// be sorted, in the alphabet and point back to an earlier node, so a
+// corrupt file cannot send a traversal out of bounds or round a cycle.
+func DecodeDAWG(lang *Language, data []byte) (*DAWG, error) {
+	header, ok := readDAWGHeader(data)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing header", ErrBadDAWGFile)
+	}
+
+	switch {
+	case header.Version != dawgFileVersion:
+		return nil, fmt.Errorf("%w: version %d", ErrBadDAWGFile, header.Version)
+	case string(bytes.TrimRight(header.Language[:], "\x00")) != lang.Code:
+		return nil, fmt.Errorf("%w: built for language %q", ErrBadDAWGFile, bytes.TrimRight(header.Language[:], "\x00"))
+	case int(header.Letters) != lang.AlphabetSize():
+		return nil, fmt.Errorf("%w: built for %d letters, %s has %d", ErrBadDAWGFile, header.Letters, lang.Code, lang.AlphabetSize())
+	case int64(len(data)) != dawgHeaderSize+4*int64(header.Nodes):
+		return nil, fmt.Errorf("%w: %d bytes for %d node words", ErrBadDAWGFile, len(data), header.Nodes)
+	}
+
+	nodes := packedWords(data[dawgHeaderSize:])
+	if err := checkNodes(nodes, header.Root, lang.AlphabetSize()); err != nil {
+		return nil, err
+	}
+
+	return &DAWG{
+		lang:   lang,
+		nodes:  nodes,
+		root:   header.Root,
+		size:   int(header.Words),
+		source: header.Source,
+	}, nil
+}
+
+// DAWGFileSource reports whether data is a compiled DAWG and, if so,
+// returns the SHA-256 of the wordlist it was built from.
+func DAWGFileSource(data []byte) ([sha256.Size]byte, bool) {
+	header, ok := readDAWGHeader(data)
+	if !ok {
+		return [sha256.Size]byte{}, false
+	}
+
+	return header.Source, true
+}
+
+func readDAWGHeader(data []byte) (dawgHeader, bool) {
+	var header dawgHeader
+	if len(data) < dawgHeaderSize || string(data[:len(dawgMagic)]) != dawgMagic {
+		return header, false
+	}
+
+	if _, err := binary.Decode(data[:dawgHeaderSize], binary.LittleEndian, &header); err != nil {
+		return header, false
+	}
+
+	return header, true
+}
+
+// packedWords views b as little-endian uint32s, without copying when
+// the machine's byte order and b's alignment allow.
+func packedWords(b []byte) []uint32 {
+	if len(b) == 0 {
+		return nil
+	}
+
+	if nativeLittleEndian && uintptr(unsafe.Pointer(&b[0]))%4 == 0 {
+		return unsafe.Slice((*uint32)(unsafe.Pointer(&b[0])), len(b)/4)
+	}
+
+	words := make([]uint32, len(b)/4)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint32(b[4*i:])
+	}
+
+	return words
+}
+
+// checkNodes walks the packed nodes in order, the way pack laid them
+// out, and validates each against the nodes before it.
+func checkNodes(nodes []uint32, root uint32, letters int) error {
+	starts := make([]uint64, (len(nodes)+63)/64)
+	isStart := func(off int) bool { return starts[off/64]&(1<<(off%64)) != 0 }
+
+	for off := 0; off < len(nodes); {
+		count := int(nodes[off] & dawgEdgeCountMask)
+		if off+1+count > len(nodes) {
+			return fmt.Errorf("%w: node %d runs past the end", ErrBadDAWGFile, off)
+		}
+
+		prev := -1
+		for _, edge := range nodes[off+1 : off+1+count] {
+			letter, target := int(edge>>dawgLetterShift), int(edge&dawgTargetMask)
+			if letter <= prev || letter >= letters {
+				return fmt.Errorf("%w: node %d has a bad edge letter", ErrBadDAWGFile, off)
+			}
+			if target >= off || !isStart(target) {
+				return fmt.Errorf("%w: node %d has a bad edge target", ErrBadDAWGFile, off)
+			}
+			prev = letter
+		}
+
+		starts[off/64] |= 1 << (off % 64)
+		off += 1 + count
+	}
+
+	if int(root) >= len(nodes) || !isStart(int(root)) {
+		return fmt.Errorf("%w: bad root", ErrBadDAWGFile)
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func compileDAWG(t testing.TB, dawg *DAWG) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	n, err := dawg.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("write dawg: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Fatalf("WriteTo reported %d bytes, wrote %d", n, buf.Len())
+	}
+
+	return buf.Bytes()
+}
+
+func TestDAWGFileRoundTrip(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+	data := compileDAWG(t, dawg)
+
+	source, ok := DAWGFileSource(data)
+	if !ok || source != sha256.Sum256([]byte(testWords)) {
+		t.Errorf("DAWGFileSource: got (%x, %v), want the wordlist's SHA-256", source, ok)
+	}
+
+	decoded, err := DecodeDAWG(lang, data)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if decoded.Size() != dawg.Size() || decoded.Source() != dawg.Source() {
+		t.Errorf("decoded size/source differ: %d %x vs %d %x", decoded.Size(), decoded.Source(), dawg.Size(), dawg.Source())
+	}
+
+	for _, w := range strings.Split(testWords, "\n") {
+		if !decoded.ContainsString(w) {
+			t.Errorf("decoded dawg is missing %q", w)
+		}
+	}
+
+	if decoded.ContainsString("HORS") {
+		t.Error("decoded dawg should not contain HORS")
+	}
+
+	board := NewBoard()
+	rack := rackFromWord(t, lang, "HORSE")
+	if best := BestMove(board, rack, decoded, lang); best == nil || best.Result.Score != 24 {
+		t.Errorf("BestMove on the decoded dawg: got %+v, want a 24-point play", best)
+	}
+}
+
+func TestDecodeDAWGRejectsBadFiles(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+	data := compileDAWG(t, dawg)
+
+	if _, ok := DAWGFileSource([]byte(testWords)); ok {
+		t.Error("a plain wordlist is not a compiled DAWG")
+	}
+
+	corrupt := func(mutate func([]byte) []byte) []byte {
+		return mutate(bytes.Clone(data))
+	}
+
+	cases := map[string]struct {
+		data []byte
+		lang *Language
+	}{
+		"wrong language": {data, French()},
+		"truncated":      {data[:len(data)-4], lang},
+		"not a dawg":     {[]byte(testWords), lang},
+		"bad version": {corrupt(func(b []byte) []byte {
+			b[4] = 9
+			return b
+		}), lang},
+		"forward edge": {corrupt(func(b []byte) []byte {
+			// Point the root's first edge at the root itself.
+			root := int(dawg.root)
+			edge := b[dawgHeaderSize+4*(root+1):]
+			edge[0], edge[1], edge[2] = byte(root), byte(root>>8), byte(root>>16)
+			return b
+		}), lang},
+	}
+
+	for name, tc := range cases {
+		if _, err := DecodeDAWG(tc.lang, tc.data); !errors.Is(err, ErrBadDAWGFile) {
+			t.Errorf("%s: got %v, want ErrBadDAWGFile", name, err)
+		}
+	}
+}
+
+// benchWordlist is the bundled English wordlist, for benchmarks at real
+// dictionary size.
+func benchWordlist(b *testing.B) []byte {
+	b.Helper()
+
+	words, err := os.ReadFile("../dict/en.txt")
+	if err != nil {
+		b.Skipf("no bundled wordlist: %v", err)
+	}
+
+	return words
+}
+
+// BenchmarkBuildDAWG and BenchmarkDecodeDAWG compare the two ways a pod
+// can load its dictionary at startup: building from text, or decoding a
+// file compiled by cmd/mkdawg.
+func BenchmarkBuildDAWG(b *testing.B) {
+	words := benchWordlist(b)
+	lang := English()
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := BuildDAWG(lang, bytes.NewReader(words)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeDAWG(b *testing.B) {
+	words := benchWordlist(b)
+	lang := English()
+
+	dawg, err := BuildDAWG(lang, bytes.NewReader(words))
+	if err != nil {
+		b.Fatal(err)
+	}
+	data := compileDAWG(b, dawg)
+	b.ReportMetric(float64(len(data)), "file-bytes")
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := DecodeDAWG(lang, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDAWGHeap reports the live heap a built dictionary holds on
+// to once the build garbage is collected.
+func BenchmarkDAWGHeap(b *testing.B) {
+	words := benchWordlist(b)
+	lang := English()
+
+	var before, after runtime.MemStats
+	for b.Loop() {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		dawg, err := BuildDAWG(lang, bytes.NewReader(words))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		runtime.KeepAlive(dawg)
+	}
+
+	b.ReportMetric(float64(after.HeapAlloc)-float64(before.HeapAlloc), "heap-bytes")
+}
+
+// BenchmarkBestMoveFullDictionary runs the move generator over the
+// packed graph with the full English list, from a mid-game position.
+func BenchmarkBestMoveFullDictionary(b *testing.B) {
+	words := benchWordlist(b)
+	lang := English()
+
+	dawg, err := BuildDAWG(lang, bytes.NewReader(words))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	benchBestMove(b, dawg, lang, 4*len(dawg.nodes))
+}
+
+// BenchmarkBestMoveGADDAG runs Gordon's generator over a GADDAG of the
+// same list from the same position, for comparison with
+// BenchmarkBestMoveFullDictionary. Both report the size of their graph.
+func BenchmarkBestMoveGADDAG(b *testing.B) {
+	words := benchWordlist(b)
+	lang := English()
+
+	gaddag, err := BuildGADDAG(lang, bytes.NewReader(words))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	benchBestMove(b, gaddag, lang, 4*len(gaddag.nodes))
+}
+
+func benchBestMove(b *testing.B, dict Dictionary, lang *Language, graphBytes int) {
+	board := NewBoard()
+	placeWord(b, board, lang, "HORSE", 7, 5, Horizontal)
+	placeWord(b, board, lang, "QUIT", 3, 8, Vertical)
+	rack := rackFromWord(b, lang, "RETAINS")
+
+	for b.Loop() {
+		if BestMove(board, rack, dict, lang) == nil {
+			b.Fatal("no move found")
+		}
+	}
+
+	b.ReportMetric(float64(graphBytes), "graph-bytes")
+}
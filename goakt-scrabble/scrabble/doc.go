@@ -27,12 +27,20 @@
 // touching the goakt runtime. The engine covers:
 //
 //   - Per-language tile distributions and point values (Language)
-//   - A 15x15 board with the standard premium-square layout (Board)
+//   - Boards laid out by a Layout: standard 15x15, Super 21x21 or custom (Board)
 //   - The shuffled tile bag (Bag) and the player rack (Rack)
-//   - Dictionary lookup and the DAWG used for move generation
+//   - Dictionary lookup and the minimized DAWG used for move generation,
+//     with a compact binary file format for fast loading
+//   - The Clabbers variant, which accepts any anagram of a word, and
+//     its move generator
+//   - Word search over the DAWG: anagrams, patterns and hooks
 //   - Move validation + scoring (Move)
 //   - End-of-game detection and rack-penalty scoring (Endgame)
+//   - Tournament time controls and overtime penalties (Clock)
 //   - A greedy Appel/Jacobson move generator used by the bot
+//   - A GADDAG and Gordon's move generator, benchmarked against it
+//   - Post-game analysis of a game record against that generator
+//   - Glicko-2 player ratings from finished games (Rating)
 //
 // The actor layer (LobbyActor / RoomActor / BotActor / SessionActor)
 // lives in the parent package and wraps these types; nothing in this
@@ -22,6 +22,8 @@
 
 package scrabble
 
+import "time"
+
 // ScorelessTurnsToEnd is the official Scrabble cutoff for ending a game
 // where no one is playing tiles: six consecutive scoreless turns (passes
 // or zero-score exchanges).
@@ -67,9 +69,12 @@ func FirstEmptyRack(racks []*Rack) int {
 //   - If exactly one player went out (empty rack), they gain the sum of
 //     every other player's remaining rack values.
 //   - If no one went out (six-passes ending), only the rack penalty applies.
+//   - In a timed game every player loses OvertimePenalty for their
+//     overtime; overtime is nil in an untimed game.
 //
-// currentScores and racks are parallel: index i in both is player i.
-func FinalScores(currentScores []int, racks []*Rack, lang *Language) []int {
+// currentScores, racks and overtime are parallel: index i in each is
+// player i.
+func FinalScores(currentScores []int, racks []*Rack, overtime []time.Duration, lang *Language) []int {
 	out := make([]int, len(currentScores))
 	copy(out, currentScores)
 
@@ -83,6 +88,10 @@ func FinalScores(currentScores []int, racks []*Rack, lang *Language) []int {
 		out[i] -= rackValues[i]
 	}
 
+	for i, over := range overtime {
+		out[i] -= OvertimePenalty(over)
+	}
+
 	outIdx := FirstEmptyRack(racks)
 
 	if outIdx >= 0 {
@@ -25,9 +25,10 @@ package scrabble
 import (
 	"math/rand/v2"
 	"testing"
+	"time"
 )
 
-func rackFromWord(t *testing.T, lang *Language, word string) *Rack {
+func rackFromWord(t testing.TB, lang *Language, word string) *Rack {
 	t.Helper()
 
 	ids, err := lang.NormalizeWord(word)
@@ -86,7 +87,7 @@ func TestFinalScoresRackPenaltyOnly(t *testing.T) {
 	rackA := rackFromWord(t, lang, "BC")
 	rackB := rackFromWord(t, lang, "DE")
 
-	got := FinalScores([]int{50, 30}, []*Rack{rackA, rackB}, lang)
+	got := FinalScores([]int{50, 30}, []*Rack{rackA, rackB}, nil, lang)
 
 	want := []int{50 - (3 + 3), 30 - (2 + 1)}
 
@@ -101,7 +102,7 @@ func TestFinalScoresOutBonus(t *testing.T) {
 	rackOut := NewRack()
 	rackOther := rackFromWord(t, lang, "BC")
 
-	got := FinalScores([]int{50, 30}, []*Rack{rackOut, rackOther}, lang)
+	got := FinalScores([]int{50, 30}, []*Rack{rackOut, rackOther}, nil, lang)
 
 	want := []int{50 + (3 + 3), 30 - (3 + 3)}
 
@@ -109,3 +110,19 @@ func TestFinalScoresOutBonus(t *testing.T) {
 		t.Errorf("final scores with out-bonus: got %v want %v", got, want)
 	}
 }
+
+func TestFinalScoresOvertimePenalty(t *testing.T) {
+	lang := English()
+
+	rackOut := NewRack()
+	rackOther := rackFromWord(t, lang, "BC")
+	overtime := []time.Duration{0, 61 * time.Second}
+
+	got := FinalScores([]int{50, 30}, []*Rack{rackOut, rackOther}, overtime, lang)
+
+	want := []int{50 + (3 + 3), 30 - (3 + 3) - 20}
+
+	if got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("final scores with overtime: got %v want %v", got, want)
+	}
+}
@@ -0,0 +1,292 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"io"
+	"slices"
+)
+
+// gaddagSeparator marks, in a GADDAG path, where the reversed prefix of
+// a word ends and its suffix begins. It sorts after every letter.
+const gaddagSeparator LetterID = 0xFF
+
+// GADDAG is Gordon's (1994) word graph for move generation. Each word
+// is stored once per letter: the letters up to and including it
+// reversed, a separator, then the rest of the word, so HORSE is
+// H◇ORSE, OH◇RSE, ROH◇SE, SROH◇E and ESROH. A play can then be grown
+// from its anchor square leftwards and then rightwards, with no left
+// part tried that no word ends with.
+//
+// It is packed like the DAWG, and about seven times its size; it has no
+// file format, and the game itself plays with the DAWG. It exists to
+// compare the two generators: see BenchmarkBestMoveGADDAG.
+type GADDAG struct {
+	nodes []uint32
+	root  uint32
+	size  int
+}
+
+var _ Dictionary = (*GADDAG)(nil)
+
+// BuildGADDAG reads a wordlist as BuildDAWG does. To bound the memory
+// the unminimized trie takes, it is built and packed one subtree of the
+// root, one first letter, at a time.
+func BuildGADDAG(lang *Language, r io.Reader) (*GADDAG, error) {
+	var words [][]LetterID
+	seen := make(map[string]bool)
+
+	err := scanWords(lang, r, func(ids []LetterID) {
+		key := string(ids)
+		if !seen[key] {
+			seen[key] = true
+			words = append(words, ids)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := newPacker()
+	root := []uint32{0}
+
+	for letter := range LetterID(lang.AlphabetSize()) {
+		sub := &trieNode{}
+		for _, word := range words {
+			for i, id := range word {
+				if id == letter {
+					sub.insert(gaddagTail(word, i))
+				}
+			}
+		}
+
+		if len(sub.edges) == 0 && !sub.terminal {
+			continue
+		}
+
+		off, err := p.place(sub)
+		if err != nil {
+			return nil, err
+		}
+		root = append(root, uint32(letter)<<dawgLetterShift|off)
+	}
+
+	root[0] = uint32(len(root) - 1)
+	rootOff, err := p.placeWords(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GADDAG{nodes: p.nodes, root: rootOff, size: len(words)}, nil
+}
+
+// gaddagTail is the path stored for word split after letter i, less
+// that first letter, which the root's edge carries.
+func gaddagTail(word []LetterID, i int) []LetterID {
+	path := make([]LetterID, 0, len(word)+1)
+	for j := i - 1; j >= 0; j-- {
+		path = append(path, word[j])
+	}
+
+	if i < len(word)-1 {
+		path = append(path, gaddagSeparator)
+		path = append(path, word[i+1:]...)
+	}
+
+	return path
+}
+
+// Size returns the number of words in the GADDAG.
+func (g *GADDAG) Size() int {
+	return g.size
+}
+
+func (g *GADDAG) rootNode() DAWGNode {
+	return DAWGNode{nodes: g.nodes, off: g.root}
+}
+
+// Contains reports whether word is in the dictionary, by following it
+// reversed: the path a GADDAG keeps for a word split at its last letter.
+func (g *GADDAG) Contains(word []LetterID) bool {
+	if len(word) < MinWordLength {
+		return false
+	}
+
+	node := g.rootNode()
+
+	for i := len(word) - 1; i >= 0; i-- {
+		next, ok := node.Edge(word[i])
+		if !ok {
+			return false
+		}
+		node = next
+	}
+
+	return node.Terminal()
+}
+
+// GenerateMoves returns every legal move available to the rack on the
+// board, using Gordon's generator: from each anchor square it places
+// letters leftwards along the reversed prefix, then, past the
+// separator, rightwards from the anchor.
+func (g *GADDAG) GenerateMoves(board *Board, rack *Rack, lang *Language) []ScoredMove {
+	gen := &gaddagGen{
+		board:     board,
+		rackCount: make([]int, lang.AlphabetSize()),
+	}
+
+	for _, tile := range rack.tiles {
+		if tile.Blank {
+			gen.blanks++
+		} else {
+			gen.rackCount[tile.Letter]++
+		}
+	}
+
+	gen.crossH = computeCrossChecks(board, g, lang, Vertical)
+	gen.crossV = computeCrossChecks(board, g, lang, Horizontal)
+
+	if board.IsEmptyBoard() {
+		gen.anchors = newGrid[bool](board.Size())
+		row, col := board.Center()
+		gen.anchors[row][col] = true
+	} else {
+		gen.anchors = buildAnchorGrid(board)
+	}
+
+	for row := range board.Size() {
+		for col := range board.Size() {
+			if !gen.anchors[row][col] {
+				continue
+			}
+			for _, dir := range []Direction{Horizontal, Vertical} {
+				gen.row, gen.col, gen.dir = row, col, dir
+				gen.cross = gen.crossV
+				if dir == Horizontal {
+					gen.cross = gen.crossH
+				}
+				gen.gen(0, g.rootNode(), nil)
+			}
+		}
+	}
+
+	return scoreMoves(board, gen.emitted, g, lang)
+}
+
+// gaddagGen bundles inputs and scratch state for one
+// GADDAG.GenerateMoves call. row, col and dir are the anchor being
+// played through; squares are addressed by their offset from it.
+type gaddagGen struct {
+	board     *Board
+	rackCount []int
+	blanks    int
+	crossH    *crossChecks
+	crossV    *crossChecks
+	anchors   [][]bool
+	emitted   []Move
+
+	row, col int
+	dir      Direction
+	cross    *crossChecks
+}
+
+func (g *gaddagGen) square(pos int) (int, int) {
+	dRow, dCol := step(g.dir)
+	return g.row + dRow*pos, g.col + dCol*pos
+}
+
+// open reports whether the square at pos is off the board or empty.
+func (g *gaddagGen) open(pos int) bool {
+	row, col := g.square(pos)
+	return !g.board.InBounds(row, col) || !g.board.At(row, col).Filled
+}
+
+// gen plays the square at pos: its board tile, or each rack tile the
+// cross-checks allow there.
+func (g *gaddagGen) gen(pos int, node DAWGNode, placements []Placement) {
+	row, col := g.square(pos)
+	if sq := g.board.At(row, col); sq.Filled {
+		if next, ok := node.Edge(sq.Tile.Letter); ok {
+			g.goOn(pos, next, placements)
+		}
+		return
+	}
+
+	node.Each(func(letter LetterID, next DAWGNode) bool {
+		if letter == gaddagSeparator || !g.cross.allows(row, col, letter) {
+			return true
+		}
+
+		if g.rackCount[letter] > 0 {
+			g.rackCount[letter]--
+			g.goOn(pos, next, appendPlacement(placements, Placement{Row: row, Col: col, Tile: Tile{Letter: letter}}))
+			g.rackCount[letter]++
+		}
+
+		if g.blanks > 0 {
+			g.blanks--
+			g.goOn(pos, next, appendPlacement(placements, Placement{Row: row, Col: col, Tile: Tile{Letter: letter, Blank: true}}))
+			g.blanks++
+		}
+
+		return true
+	})
+}
+
+// goOn records a word ending at pos and extends the play: further left,
+// or across the separator to the right of the anchor, or further right.
+func (g *gaddagGen) goOn(pos int, node DAWGNode, placements []Placement) {
+	if pos <= 0 {
+		if node.Terminal() && g.open(pos-1) && g.open(1) {
+			g.record(placements)
+		}
+
+		// An empty anchor to the left plays its own left parts.
+		if row, col := g.square(pos - 1); g.board.InBounds(row, col) && !g.anchors[row][col] {
+			g.gen(pos-1, node, placements)
+		}
+
+		if sep, ok := node.Edge(gaddagSeparator); ok && g.open(pos-1) {
+			if row, col := g.square(1); g.board.InBounds(row, col) {
+				g.gen(1, sep, placements)
+			}
+		}
+		return
+	}
+
+	if node.Terminal() && g.open(pos+1) {
+		g.record(placements)
+	}
+
+	if row, col := g.square(pos + 1); g.board.InBounds(row, col) {
+		g.gen(pos+1, node, placements)
+	}
+}
+
+func (g *gaddagGen) record(placements []Placement) {
+	if len(placements) == 0 {
+		return
+	}
+
+	g.emitted = append(g.emitted, Move{Placements: slices.Clone(placements)})
+}
@@ -0,0 +1,117 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func newTestGADDAG(t *testing.T) *GADDAG {
+	t.Helper()
+
+	gaddag, err := BuildGADDAG(English(), strings.NewReader(testWords))
+	if err != nil {
+		t.Fatalf("build gaddag: %v", err)
+	}
+
+	return gaddag
+}
+
+func TestGADDAGContains(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+	gaddag := newTestGADDAG(t)
+
+	if gaddag.Size() != dawg.Size() {
+		t.Errorf("size: got %d want %d", gaddag.Size(), dawg.Size())
+	}
+
+	for _, word := range []string{"HORSE", "HORSES", "QI", "ZA", "HOSIERY", "HORZ", "HORS", "ESROH", "OH"} {
+		ids, _ := lang.NormalizeWord(word)
+		if got, want := gaddag.Contains(ids), dawg.Contains(ids); got != want {
+			t.Errorf("%s: got %t want %t", word, got, want)
+		}
+	}
+}
+
+// TestGADDAGGeneratesDAWGMoves plays out games with the DAWG generator
+// and checks that the GADDAG finds the same set of moves at every turn.
+func TestGADDAGGeneratesDAWGMoves(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+	gaddag := newTestGADDAG(t)
+
+	for seed := range uint64(10) {
+		bag := NewBag(lang, rand.New(rand.NewPCG(seed, 1)))
+		board := NewBoard()
+
+		for turn := range 30 {
+			rack := NewRack()
+			rack.Refill(bag)
+			if rack.Size() == 0 {
+				break
+			}
+
+			want := moveKeys(GenerateMoves(board, rack, dawg, lang))
+			got := moveKeys(gaddag.GenerateMoves(board, rack, lang))
+			if !slices.Equal(got, want) {
+				t.Fatalf("seed %d turn %d: GADDAG found %d moves, DAWG %d\ngot  %v\nwant %v", seed, turn, len(got), len(want), got, want)
+			}
+
+			best := BestMove(board, rack, dawg, lang)
+			if best == nil {
+				continue
+			}
+			if err := best.Move.Apply(board); err != nil {
+				t.Fatalf("apply: %v", err)
+			}
+		}
+	}
+}
+
+// moveKeys names each distinct move by its sorted placements.
+func moveKeys(moves []ScoredMove) []string {
+	keys := make([]string, 0, len(moves))
+	for _, move := range moves {
+		placements := slices.Clone(move.Move.Placements)
+		slices.SortFunc(placements, func(a, b Placement) int {
+			if a.Row != b.Row {
+				return a.Row - b.Row
+			}
+			return a.Col - b.Col
+		})
+
+		var key bytes.Buffer
+		for _, p := range placements {
+			fmt.Fprintf(&key, "%d,%d:%d%t ", p.Row, p.Col, p.Tile.Letter, p.Tile.Blank)
+		}
+		keys = append(keys, key.String())
+	}
+
+	slices.Sort(keys)
+
+	return slices.Compact(keys)
+}
@@ -0,0 +1,639 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// GCG errors. Compare with errors.Is.
+var (
+	ErrGCGSyntax   = errors.New("scrabble: malformed gcg line")
+	ErrGCGMismatch = errors.New("scrabble: gcg record does not replay")
+)
+
+// gcgTimeMarker stands where the counted tiles of a rack penalty would.
+const gcgTimeMarker = "(time)"
+
+// GCGKind classifies one move line of a GCG game record.
+type GCGKind uint8
+
+const (
+	// GCGPlay places tiles: ">nick: RACK 8H WORD +score total".
+	GCGPlay GCGKind = iota
+	// GCGExchange swaps tiles: ">nick: RACK -TILES +0 total".
+	GCGExchange
+	// GCGPass is a pass, a timed-out turn or a turn lost to a failed
+	// challenge: ">nick: RACK - +0 total".
+	GCGPass
+	// GCGWithdraw takes back the player's previous play after a
+	// successful challenge: ">nick: RACK -- -score total".
+	GCGWithdraw
+	// GCGEndRack credits the player who went out with an opponent's
+	// leftover tiles: ">nick: (TILES) +score total".
+	GCGEndRack
+	// GCGRackPenalty charges a player for their own leftover tiles:
+	// ">nick: RACK (TILES) -score total".
+	GCGRackPenalty
+	// GCGTimePenalty charges a player for running over their clock:
+	// ">nick: RACK (time) -score total".
+	GCGTimePenalty
+)
+
+// GCGPlayer is one "#playerN nick Full Name" pragma. Nick has no spaces;
+// move lines refer to the player by it.
+type GCGPlayer struct {
+	Nick string
+	Name string
+}
+
+// GCGEvent is one move line. Rack is the player's rack before the move
+// (blanks unassigned). Move is set for GCGPlay; Tiles holds the swapped
+// tiles for GCGExchange and the counted rack for GCGEndRack and
+// GCGRackPenalty. Score is signed and Total is the player's running score
+// after the line.
+type GCGEvent struct {
+	Player int
+	Kind   GCGKind
+	Rack   []Tile
+	Move   Move
+	Tiles  []Tile
+	Score  int
+	Total  int
+}
+
+// GCGGame is a game record in the GCG format used by Quackle, Macondo
+// and tournament software. Multi-letter tiles such as Spanish CH are
+// written in brackets ("[CH]"); played blanks are lowercase and "?" is a
+// blank on a rack.
+//
+// Lexicon names the wordlist the game was judged against, written as a
+// "#lexicon" pragma when set. Layout is the board the game was played
+// on, nil for the standard one. Any other is written as a "#board name"
+// pragma, which ReadGCG resolves among the built-in layouts.
+type GCGGame struct {
+	Title   string
+	Lexicon string
+	Layout  *Layout
+	Players []GCGPlayer
+	Events  []GCGEvent
+}
+
+// AddPlayer appends a player and returns their index. The nick is derived
+// from name with whitespace removed and made unique within the game.
+func (g *GCGGame) AddPlayer(name string) int {
+	nick := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == ':' {
+			return -1
+		}
+		return r
+	}, name)
+
+	if nick == "" {
+		nick = fmt.Sprintf("player%d", len(g.Players)+1)
+	}
+
+	base := nick
+	for n := 2; g.playerIndex(nick) >= 0; n++ {
+		nick = fmt.Sprintf("%s%d", base, n)
+	}
+
+	g.Players = append(g.Players, GCGPlayer{Nick: nick, Name: name})
+
+	return len(g.Players) - 1
+}
+
+// newBoard returns an empty board with the game's layout.
+func (g *GCGGame) newBoard() *Board {
+	if g.Layout == nil {
+		return NewBoard()
+	}
+
+	return NewLayoutBoard(g.Layout)
+}
+
+func (g *GCGGame) playerIndex(nick string) int {
+	for i, player := range g.Players {
+		if player.Nick == nick {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Write renders the record as GCG. Plays are walked on a scratch board so
+// squares the main word runs through are written as ".".
+func (g *GCGGame) Write(w io.Writer, lang *Language) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "#character-encoding UTF-8")
+	for i, player := range g.Players {
+		fmt.Fprintf(bw, "#player%d %s %s\n", i+1, player.Nick, player.Name)
+	}
+	if g.Title != "" {
+		fmt.Fprintf(bw, "#title %s\n", g.Title)
+	}
+	if g.Lexicon != "" {
+		fmt.Fprintf(bw, "#lexicon %s\n", g.Lexicon)
+	}
+	if g.Layout != nil && g.Layout.Name != LayoutStandard {
+		fmt.Fprintf(bw, "#board %s\n", g.Layout.Name)
+	}
+
+	board := g.newBoard()
+	last := make(map[int]Move)
+
+	for i, evt := range g.Events {
+		if evt.Player < 0 || evt.Player >= len(g.Players) {
+			return fmt.Errorf("%w: event %d names player %d", ErrGCGMismatch, i+1, evt.Player)
+		}
+
+		rack := gcgRack(evt.Rack, lang)
+		var body string
+
+		switch evt.Kind {
+		case GCGPlay:
+			pos, word, err := playNotation(board, evt.Move, lang, false)
+			if err != nil {
+				return fmt.Errorf("event %d: %w", i+1, err)
+			}
+			if err := evt.Move.Apply(board); err != nil {
+				return fmt.Errorf("event %d: %w", i+1, err)
+			}
+			last[evt.Player] = evt.Move
+			body = rack + " " + pos + " " + word
+		case GCGExchange:
+			body = rack + " -" + gcgRack(evt.Tiles, lang)
+		case GCGPass:
+			body = rack + " -"
+		case GCGWithdraw:
+			if move, ok := last[evt.Player]; ok {
+				if _, err := move.Withdraw(board); err != nil {
+					return fmt.Errorf("event %d: %w", i+1, err)
+				}
+				delete(last, evt.Player)
+			}
+			body = rack + " --"
+		case GCGEndRack:
+			body = "(" + gcgRack(evt.Tiles, lang) + ")"
+		case GCGRackPenalty:
+			body = rack + " (" + gcgRack(evt.Tiles, lang) + ")"
+		case GCGTimePenalty:
+			body = rack + " " + gcgTimeMarker
+		}
+
+		fmt.Fprintf(bw, ">%s: %s %+d %d\n", g.Players[evt.Player].Nick, body, evt.Score, evt.Total)
+	}
+
+	return bw.Flush()
+}
+
+// ReadGCG parses a GCG record. Pragmas other than #playerN, #title,
+// #lexicon and #board and free-form note lines are ignored.
+func ReadGCG(r io.Reader, lang *Language) (*GCGGame, error) {
+	game := &GCGGame{}
+	scanner := bufio.NewScanner(r)
+
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		var err error
+		switch {
+		case strings.HasPrefix(line, "#player"):
+			err = game.parsePlayer(line)
+		case strings.HasPrefix(line, "#title "):
+			game.Title = strings.TrimSpace(strings.TrimPrefix(line, "#title "))
+		case strings.HasPrefix(line, "#lexicon "):
+			game.Lexicon = strings.TrimSpace(strings.TrimPrefix(line, "#lexicon "))
+		case strings.HasPrefix(line, "#board "):
+			name := strings.TrimSpace(strings.TrimPrefix(line, "#board "))
+			layout, ok := LayoutByName(name)
+			if !ok {
+				err = fmt.Errorf("%w: unknown board %q", ErrGCGSyntax, name)
+			}
+			game.Layout = layout
+		case strings.HasPrefix(line, ">"):
+			var evt GCGEvent
+			evt, err = game.parseEvent(line, lang)
+			game.Events = append(game.Events, evt)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("gcg line %d: %w", lineNo, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return game, nil
+}
+
+// Replay plays the record through Board, Rack and Move and checks every
+// recorded score and running total. Words are not looked up: under the
+// double-challenge rule an unchallenged phony stands. It returns the final
+// board.
+func (g *GCGGame) Replay(lang *Language) (*Board, error) {
+	board := g.newBoard()
+	totals := make([]int, len(g.Players))
+	last := make(map[int]Move)
+	lastScore := make(map[int]int)
+
+	for i, evt := range g.Events {
+		if evt.Player < 0 || evt.Player >= len(g.Players) {
+			return nil, fmt.Errorf("%w: event %d names player %d", ErrGCGMismatch, i+1, evt.Player)
+		}
+
+		var score int
+
+		switch evt.Kind {
+		case GCGPlay:
+			if len(evt.Rack) > 0 {
+				rack := &Rack{tiles: append([]Tile(nil), evt.Rack...)}
+				if _, err := rack.Remove(rackTilesFor(evt.Move.Placements)); err != nil {
+					return nil, fmt.Errorf("%w: event %d: play not on rack", ErrGCGMismatch, i+1)
+				}
+			}
+			result, err := evt.Move.ValidateLenient(board, lang)
+			if err != nil {
+				return nil, fmt.Errorf("%w: event %d: %w", ErrGCGMismatch, i+1, err)
+			}
+			if err := evt.Move.Apply(board); err != nil {
+				return nil, fmt.Errorf("%w: event %d: %w", ErrGCGMismatch, i+1, err)
+			}
+			score = result.Score
+			last[evt.Player] = evt.Move
+			lastScore[evt.Player] = score
+		case GCGWithdraw:
+			move, ok := last[evt.Player]
+			if !ok {
+				return nil, fmt.Errorf("%w: event %d withdraws no play", ErrGCGMismatch, i+1)
+			}
+			if _, err := move.Withdraw(board); err != nil {
+				return nil, fmt.Errorf("%w: event %d: %w", ErrGCGMismatch, i+1, err)
+			}
+			score = -lastScore[evt.Player]
+			delete(last, evt.Player)
+		case GCGEndRack:
+			score = tilesValue(evt.Tiles, lang)
+		case GCGRackPenalty:
+			score = -tilesValue(evt.Tiles, lang)
+		case GCGTimePenalty:
+			// The clock is not in the record; any whole-minute
+			// penalty is taken as written.
+			if evt.Score > 0 || evt.Score%OvertimePenaltyPerMinute != 0 {
+				return nil, fmt.Errorf("%w: event %d time penalty %+d", ErrGCGMismatch, i+1, evt.Score)
+			}
+			score = evt.Score
+		}
+
+		if score != evt.Score {
+			return nil, fmt.Errorf("%w: event %d scores %+d, recorded %+d", ErrGCGMismatch, i+1, score, evt.Score)
+		}
+
+		totals[evt.Player] += score
+		if totals[evt.Player] != evt.Total {
+			return nil, fmt.Errorf("%w: event %d totals %d, recorded %d", ErrGCGMismatch, i+1, totals[evt.Player], evt.Total)
+		}
+	}
+
+	return board, nil
+}
+
+func (g *GCGGame) parsePlayer(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return fmt.Errorf("%w: %q", ErrGCGSyntax, line)
+	}
+
+	n, err := strconv.Atoi(strings.TrimPrefix(fields[0], "#player"))
+	if err != nil || n < 1 {
+		return fmt.Errorf("%w: %q", ErrGCGSyntax, line)
+	}
+
+	for len(g.Players) < n {
+		g.Players = append(g.Players, GCGPlayer{})
+	}
+
+	g.Players[n-1] = GCGPlayer{Nick: fields[1], Name: strings.Join(fields[2:], " ")}
+
+	return nil
+}
+
+func (g *GCGGame) parseEvent(line string, lang *Language) (GCGEvent, error) {
+	nick, rest, ok := strings.Cut(line[1:], ":")
+	if !ok {
+		return GCGEvent{}, fmt.Errorf("%w: %q", ErrGCGSyntax, line)
+	}
+
+	evt := GCGEvent{Player: g.playerIndex(strings.TrimSpace(nick))}
+	if evt.Player < 0 {
+		return evt, fmt.Errorf("%w: unknown player %q", ErrGCGSyntax, nick)
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) < 3 {
+		return evt, fmt.Errorf("%w: %q", ErrGCGSyntax, line)
+	}
+
+	var err error
+	if evt.Score, err = strconv.Atoi(fields[len(fields)-2]); err != nil {
+		return evt, fmt.Errorf("%w: score %q", ErrGCGSyntax, fields[len(fields)-2])
+	}
+	if evt.Total, err = strconv.Atoi(fields[len(fields)-1]); err != nil {
+		return evt, fmt.Errorf("%w: total %q", ErrGCGSyntax, fields[len(fields)-1])
+	}
+
+	body := fields[:len(fields)-2]
+
+	if len(body) == 1 {
+		if !isParenthesized(body[0]) {
+			return evt, fmt.Errorf("%w: %q", ErrGCGSyntax, line)
+		}
+		evt.Kind = GCGEndRack
+		evt.Tiles, err = parseGCGRack(body[0][1:len(body[0])-1], lang)
+		return evt, err
+	}
+
+	if evt.Rack, err = parseGCGRack(body[0], lang); err != nil {
+		return evt, err
+	}
+
+	switch {
+	case len(body) == 3:
+		evt.Kind = GCGPlay
+		evt.Move, err = parseGCGPlay(body[1], body[2], lang)
+	case len(body) != 2:
+		err = fmt.Errorf("%w: %q", ErrGCGSyntax, line)
+	case body[1] == "-":
+		evt.Kind = GCGPass
+	case body[1] == "--":
+		evt.Kind = GCGWithdraw
+	case body[1] == gcgTimeMarker:
+		evt.Kind = GCGTimePenalty
+	case isParenthesized(body[1]):
+		evt.Kind = GCGRackPenalty
+		evt.Tiles, err = parseGCGRack(body[1][1:len(body[1])-1], lang)
+	case strings.HasPrefix(body[1], "-"):
+		// "-7" records only how many tiles were exchanged.
+		evt.Kind = GCGExchange
+		if _, numErr := strconv.Atoi(body[1][1:]); numErr != nil {
+			evt.Tiles, err = parseGCGRack(body[1][1:], lang)
+		}
+	default:
+		err = fmt.Errorf("%w: %q", ErrGCGSyntax, line)
+	}
+
+	return evt, err
+}
+
+// parseGCGPlay turns a coordinate ("8H" across, "H8" down) and a word
+// into placements, skipping the "." squares the word runs through.
+func parseGCGPlay(pos, word string, lang *Language) (Move, error) {
+	row, col, dir, err := parseGCGPosition(pos)
+	if err != nil {
+		return Move{}, err
+	}
+
+	faces, err := splitGCGTiles(word)
+	if err != nil {
+		return Move{}, err
+	}
+
+	var move Move
+
+	for i, face := range faces {
+		r, c := row, col+i
+		if dir == Vertical {
+			r, c = row+i, col
+		}
+		if face == "." {
+			continue
+		}
+
+		tile, err := parseGCGTile(face, lang)
+		if err != nil {
+			return Move{}, err
+		}
+		move.Placements = append(move.Placements, Placement{Row: r, Col: c, Tile: tile})
+	}
+
+	return move, nil
+}
+
+func parseGCGPosition(pos string) (int, int, Direction, error) {
+	if pos == "" {
+		return 0, 0, 0, fmt.Errorf("%w: empty position", ErrGCGSyntax)
+	}
+
+	dir := Vertical
+	digits, letter := pos[1:], pos[:1]
+	if pos[0] >= '0' && pos[0] <= '9' {
+		dir = Horizontal
+		digits, letter = pos[:len(pos)-1], pos[len(pos)-1:]
+	}
+
+	row, err := strconv.Atoi(digits)
+	col := int(letter[0]) - 'A'
+	if err != nil || row < 1 || row > maxLayoutSize || col < 0 || col >= maxLayoutSize {
+		return 0, 0, 0, fmt.Errorf("%w: position %q", ErrGCGSyntax, pos)
+	}
+
+	return row - 1, col, dir, nil
+}
+
+func parseGCGRack(s string, lang *Language) ([]Tile, error) {
+	faces, err := splitGCGTiles(s)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Tile, 0, len(faces))
+
+	for _, face := range faces {
+		if face == "?" {
+			out = append(out, BlankTile)
+			continue
+		}
+
+		id, ok := lang.Lookup(face)
+		if !ok {
+			return nil, fmt.Errorf("%w: tile %q not in %q alphabet", ErrGCGSyntax, face, lang.Code)
+		}
+		out = append(out, Tile{Letter: id})
+	}
+
+	return out, nil
+}
+
+// parseGCGTile reads one placed tile; a lowercase face is a blank.
+func parseGCGTile(face string, lang *Language) (Tile, error) {
+	id, ok := lang.Lookup(face)
+	if !ok {
+		return Tile{}, fmt.Errorf("%w: tile %q not in %q alphabet", ErrGCGSyntax, face, lang.Code)
+	}
+
+	return Tile{Letter: id, Blank: strings.ToUpper(face) != face}, nil
+}
+
+// splitGCGTiles splits a GCG tile string into faces: "[CH]" is one face,
+// every other rune is its own.
+func splitGCGTiles(s string) ([]string, error) {
+	var out []string
+
+	for i := 0; i < len(s); {
+		if s[i] == '[' {
+			end := strings.IndexByte(s[i:], ']')
+			if end < 2 {
+				return nil, fmt.Errorf("%w: tiles %q", ErrGCGSyntax, s)
+			}
+			out = append(out, s[i+1:i+end])
+			i += end + 1
+			continue
+		}
+
+		_, width := utf8.DecodeRuneInString(s[i:])
+		out = append(out, s[i:i+width])
+		i += width
+	}
+
+	return out, nil
+}
+
+// playNotation returns the GCG coordinate and main word of move on board.
+// A single tile is written along whichever axis forms a word, preferring
+// across. Tiles already on the board are written as "." unless through
+// is set.
+func playNotation(board *Board, move Move, lang *Language, through bool) (string, string, error) {
+	if len(move.Placements) == 0 {
+		return "", "", ErrEmptyMove
+	}
+
+	dir, err := move.lineDirection()
+	if err != nil {
+		return "", "", err
+	}
+
+	tmp := board.Clone()
+	placed := make(map[[2]int]bool, len(move.Placements))
+
+	for _, p := range move.Placements {
+		if err := tmp.Place(p.Row, p.Col, p.Tile); err != nil {
+			return "", "", err
+		}
+		placed[[2]int{p.Row, p.Col}] = true
+	}
+
+	first := move.Placements[0]
+	main := extractWord(tmp, first.Row, first.Col, dir)
+	if len(move.Placements) == 1 && len(main) < 2 {
+		dir = Vertical
+		main = extractWord(tmp, first.Row, first.Col, dir)
+	}
+
+	var sb strings.Builder
+
+	for _, wt := range main {
+		if through || placed[[2]int{wt.row, wt.col}] {
+			sb.WriteString(gcgFace(wt.tile, lang))
+		} else {
+			sb.WriteByte('.')
+		}
+	}
+
+	start := main[0]
+	pos := fmt.Sprintf("%c%d", 'A'+start.col, start.row+1)
+	if dir == Horizontal {
+		pos = fmt.Sprintf("%d%c", start.row+1, 'A'+start.col)
+	}
+
+	return pos, sb.String(), nil
+}
+
+// gcgFace writes a placed tile: lowercase for a blank, bracketed when the
+// face is more than one letter.
+func gcgFace(tile Tile, lang *Language) string {
+	face := lang.Letter(tile.Letter)
+	if tile.Blank {
+		face = strings.ToLower(face)
+	}
+	if utf8.RuneCountInString(face) > 1 {
+		return "[" + face + "]"
+	}
+
+	return face
+}
+
+// gcgRack writes rack tiles, with "?" for a blank.
+func gcgRack(tiles []Tile, lang *Language) string {
+	var sb strings.Builder
+
+	for _, tile := range tiles {
+		if tile.Blank {
+			sb.WriteByte('?')
+			continue
+		}
+		sb.WriteString(gcgFace(tile, lang))
+	}
+
+	return sb.String()
+}
+
+func isParenthesized(s string) bool {
+	return len(s) >= 2 && s[0] == '(' && s[len(s)-1] == ')'
+}
+
+// rackTilesFor returns the rack tiles a set of placements consumes: a
+// blank placement consumes an unassigned blank.
+func rackTilesFor(placements []Placement) []Tile {
+	out := make([]Tile, len(placements))
+
+	for i, p := range placements {
+		if p.Tile.Blank {
+			out[i] = BlankTile
+		} else {
+			out[i] = Tile{Letter: p.Tile.Letter}
+		}
+	}
+
+	return out
+}
+
+func tilesValue(tiles []Tile, lang *Language) int {
+	total := 0
+
+	for _, tile := range tiles {
+		total += tile.Score(lang)
+	}
+
+	return total
+}
@@ -0,0 +1,219 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testGCGGame(t *testing.T, lang *Language) *GCGGame {
+	t.Helper()
+
+	game := &GCGGame{Title: "test", Lexicon: "en:test"}
+	alice := game.AddPlayer("Alice Smith")
+	bob := game.AddPlayer("Bob")
+
+	eID, _ := lang.ID('E')
+	sID, _ := lang.ID('S')
+
+	game.Events = []GCGEvent{
+		{Player: alice, Kind: GCGPlay, Rack: rackFromWord(t, lang, "HORSEQZ").Tiles(),
+			Move: Move{Placements: placementsFor(t, lang, "HORSE", 7, 7, Horizontal)}, Score: 18, Total: 18},
+		{Player: bob, Kind: GCGPlay, Rack: rackFromWord(t, lang, "EABCDFG").Tiles(),
+			Move: Move{Placements: []Placement{{Row: 8, Col: 7, Tile: Tile{Letter: eID}}}}, Score: 5, Total: 5},
+		{Player: alice, Kind: GCGPlay, Rack: rackFromWord(t, lang, "SQZ").Tiles(),
+			Move: Move{Placements: []Placement{{Row: 7, Col: 12, Tile: Tile{Letter: sID}}}}, Score: 9, Total: 27},
+		{Player: alice, Kind: GCGWithdraw, Rack: rackFromWord(t, lang, "SQZ").Tiles(), Score: -9, Total: 18},
+		{Player: bob, Kind: GCGExchange, Rack: rackFromWord(t, lang, "ABCDFGH").Tiles(),
+			Tiles: rackFromWord(t, lang, "CD").Tiles(), Total: 5},
+		{Player: alice, Kind: GCGPass, Rack: rackFromWord(t, lang, "SQZ").Tiles(), Total: 18},
+		{Player: bob, Kind: GCGRackPenalty, Rack: rackFromWord(t, lang, "AB").Tiles(),
+			Tiles: rackFromWord(t, lang, "AB").Tiles(), Score: -4, Total: 1},
+		{Player: alice, Kind: GCGTimePenalty, Rack: rackFromWord(t, lang, "SQZ").Tiles(), Score: -10, Total: 8},
+	}
+
+	return game
+}
+
+func TestGCGWrite(t *testing.T) {
+	_, lang := newTestDAWG(t)
+	game := testGCGGame(t, lang)
+
+	var buf bytes.Buffer
+	if err := game.Write(&buf, lang); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"#character-encoding UTF-8",
+		"#player1 AliceSmith Alice Smith",
+		"#player2 Bob Bob",
+		"#title test",
+		"#lexicon en:test",
+		">AliceSmith: HORSEQZ 8H HORSE +18 18",
+		">Bob: EABCDFG H8 .E +5 5",
+		">AliceSmith: SQZ 8H .....S +9 27",
+		">AliceSmith: SQZ -- -9 18",
+		">Bob: ABCDFGH -CD +0 5",
+		">AliceSmith: SQZ - +0 18",
+		">Bob: AB (AB) -4 1",
+		">AliceSmith: SQZ (time) -10 8",
+	}, "\n") + "\n"
+
+	if buf.String() != want {
+		t.Errorf("gcg mismatch:\ngot:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestGCGRoundTripReplays(t *testing.T) {
+	_, lang := newTestDAWG(t)
+	game := testGCGGame(t, lang)
+
+	var buf bytes.Buffer
+	if err := game.Write(&buf, lang); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	parsed, err := ReadGCG(&buf, lang)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	if len(parsed.Players) != 2 || parsed.Players[0].Name != "Alice Smith" || parsed.Title != "test" || parsed.Lexicon != "en:test" {
+		t.Errorf("header mismatch: %+v %q %q", parsed.Players, parsed.Title, parsed.Lexicon)
+	}
+
+	if len(parsed.Events) != len(game.Events) {
+		t.Fatalf("got %d events want %d", len(parsed.Events), len(game.Events))
+	}
+
+	board, err := parsed.Replay(lang)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+
+	if board.At(7, 12).Filled {
+		t.Error("withdrawn S should not be on the board")
+	}
+
+	if !board.At(8, 7).Filled || !board.At(7, 11).Filled {
+		t.Error("HORSE and HE should be on the board")
+	}
+}
+
+func TestGCGReplayDetectsBadScore(t *testing.T) {
+	_, lang := newTestDAWG(t)
+
+	input := strings.Join([]string{
+		"#player1 a A",
+		"#player2 b B",
+		">a: HORSEAB 8H HORSE +20 20",
+	}, "\n")
+
+	game, err := ReadGCG(strings.NewReader(input), lang)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	if _, err := game.Replay(lang); !errors.Is(err, ErrGCGMismatch) {
+		t.Errorf("expected ErrGCGMismatch, got %v", err)
+	}
+}
+
+func TestGCGDigraphsAndBlanks(t *testing.T) {
+	lang := Spanish()
+
+	input := strings.Join([]string{
+		"#player1 a A",
+		"#player2 b B",
+		">a: [CH]?O[LL]AR? 8D [CH]i[LL]Ar +12 12",
+	}, "\n")
+
+	game, err := ReadGCG(strings.NewReader(input), lang)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	evt := game.Events[0]
+	if len(evt.Rack) != 7 || !evt.Rack[1].IsUnassignedBlank() {
+		t.Errorf("rack: got %+v", evt.Rack)
+	}
+
+	placements := evt.Move.Placements
+	if len(placements) != 5 {
+		t.Fatalf("got %d placements want 5", len(placements))
+	}
+
+	if lang.Letter(placements[0].Tile.Letter) != "CH" || placements[0].Tile.Blank {
+		t.Errorf("first tile: got %+v", placements[0].Tile)
+	}
+
+	if lang.Letter(placements[1].Tile.Letter) != "I" || !placements[1].Tile.Blank {
+		t.Errorf("second tile should be a blank I, got %+v", placements[1].Tile)
+	}
+
+	if placements[4].Row != 7 || placements[4].Col != 7 {
+		t.Errorf("last tile at (%d,%d) want (7,7)", placements[4].Row, placements[4].Col)
+	}
+
+	var buf bytes.Buffer
+	if err := game.Write(&buf, lang); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), ">a: [CH]?O[LL]AR? 8D [CH]i[LL]Ar +12 12") {
+		t.Errorf("digraph play not written back verbatim:\n%s", buf.String())
+	}
+}
+
+func TestGCGTimePenalty(t *testing.T) {
+	_, lang := newTestDAWG(t)
+
+	input := strings.Join([]string{
+		"#player1 a A",
+		"#player2 b B",
+		">a: HORSEAB 8H HORSE +18 18",
+		">a: AB (time) -20 -2",
+	}, "\n")
+
+	game, err := ReadGCG(strings.NewReader(input), lang)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	if game.Events[1].Kind != GCGTimePenalty {
+		t.Fatalf("got kind %d want GCGTimePenalty", game.Events[1].Kind)
+	}
+
+	if _, err := game.Replay(lang); err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+
+	game.Events[1].Score, game.Events[1].Total = -7, 11
+	if _, err := game.Replay(lang); !errors.Is(err, ErrGCGMismatch) {
+		t.Errorf("expected ErrGCGMismatch for a part-minute penalty, got %v", err)
+	}
+}
@@ -28,25 +28,35 @@ import (
 	"unicode"
 )
 
+// maxAlphabetSize is the largest alphabet a Language may declare. LetterID
+// is a uint8 and sentinelLetter (255) is reserved for rack matching.
+const maxAlphabetSize = int(sentinelLetter)
+
 // LetterID is the per-language index of a letter in [0, len(Language.Letters)).
 type LetterID uint8
 
 // Language describes one playable Scrabble language. Letters, PointValues and
 // Distribution are parallel slices indexed by LetterID.
+//
+// A letter is the face printed on a tile, which is not always one rune:
+// Spanish has CH, LL and RR tiles and Dutch has IJ. NormalizeWord splits
+// words into tiles by longest match, so "CHILLAR" becomes CH-I-LL-A-R.
 type Language struct {
 	Name         string
 	Code         string
-	Letters      []rune
+	Letters      []string
 	PointValues  []int
 	Distribution []int
 	Blanks       int
 
-	rune2id map[rune]LetterID
+	face2id map[string]LetterID
+	folds   map[rune]string
+	maxFace int
 }
 
 // English is the canonical 26-letter / 2-blank Scrabble distribution.
 func English() *Language {
-	letters := []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	letters := splitFaces("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
 
 	points := []int{
 		1, 3, 3, 2, 1, 4, 2, 4, 1, 8, 5, 1, 3,
@@ -58,7 +68,100 @@ func English() *Language {
 		6, 8, 2, 1, 6, 4, 6, 4, 2, 2, 1, 2, 1,
 	}
 
-	return newLanguage("English", "en", letters, points, dist, 2)
+	return newLanguage("English", "en", letters, points, dist, 2, nil)
+}
+
+// French is the 102-tile French distribution. Accents are not printed on
+// French tiles, so É, È, Ê, À, Ç and friends fold to their base letter.
+func French() *Language {
+	letters := splitFaces("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+
+	points := []int{
+		1, 3, 3, 2, 1, 4, 2, 4, 1, 8, 10, 1, 2,
+		1, 1, 3, 8, 1, 1, 1, 1, 4, 10, 10, 10, 10,
+	}
+
+	dist := []int{
+		9, 2, 2, 3, 15, 2, 2, 2, 8, 1, 1, 5, 3,
+		6, 6, 2, 1, 6, 6, 6, 6, 2, 1, 1, 1, 1,
+	}
+
+	folds := foldAccents("ÀÂÄ", "A", "Ç", "C", "ÉÈÊË", "E", "ÎÏ", "I", "ÔÖ", "O", "ÙÛÜ", "U", "Ÿ", "Y")
+	folds['Œ'] = "OE"
+	folds['Æ'] = "AE"
+
+	return newLanguage("Français", "fr", letters, points, dist, 2, folds)
+}
+
+// Spanish is the 100-tile Spanish distribution with the CH, LL and RR
+// digraph tiles and Ñ as a letter of its own. There are no K or W tiles.
+func Spanish() *Language {
+	letters := []string{
+		"A", "B", "C", "CH", "D", "E", "F", "G", "H", "I", "J", "L", "LL", "M",
+		"N", "Ñ", "O", "P", "Q", "R", "RR", "S", "T", "U", "V", "X", "Y", "Z",
+	}
+
+	points := []int{
+		1, 3, 3, 5, 2, 1, 4, 2, 4, 1, 8, 1, 8, 3,
+		1, 8, 1, 3, 5, 1, 8, 1, 1, 1, 4, 8, 4, 10,
+	}
+
+	dist := []int{
+		12, 2, 4, 1, 5, 12, 1, 2, 2, 6, 1, 4, 1, 2,
+		5, 1, 9, 2, 1, 5, 1, 6, 4, 5, 1, 1, 1, 1,
+	}
+
+	folds := foldAccents("Á", "A", "É", "E", "Í", "I", "ÓÖ", "O", "ÚÜ", "U")
+
+	return newLanguage("Español", "es", letters, points, dist, 2, folds)
+}
+
+// German is the 102-tile German distribution. Ä, Ö and Ü are tiles of
+// their own; ß has no tile and is spelled SS.
+func German() *Language {
+	letters := splitFaces("AÄBCDEFGHIJKLMNOÖPQRSTUÜVWXYZ")
+
+	points := []int{
+		1, 6, 3, 4, 1, 1, 4, 2, 2, 1, 6, 4, 2, 3, 1,
+		2, 8, 4, 10, 1, 1, 1, 1, 6, 6, 3, 8, 10, 3,
+	}
+
+	dist := []int{
+		5, 1, 2, 2, 4, 15, 2, 3, 4, 6, 1, 2, 3, 4, 9,
+		3, 1, 1, 1, 6, 7, 6, 6, 1, 1, 1, 1, 1, 1,
+	}
+
+	folds := map[rune]string{'ß': "SS", 'ẞ': "SS"}
+
+	return newLanguage("Deutsch", "de", letters, points, dist, 2, folds)
+}
+
+// Dutch is the Dutch distribution with the IJ digraph tile. Accented
+// vowels (Ë in "ideeën", É in "café") fold to their base letter.
+func Dutch() *Language {
+	letters := []string{
+		"A", "B", "C", "D", "E", "F", "G", "H", "I", "IJ", "J", "K", "L", "M",
+		"N", "O", "P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z",
+	}
+
+	points := []int{
+		1, 3, 5, 2, 1, 4, 3, 4, 1, 4, 4, 3, 3, 3,
+		1, 1, 3, 10, 2, 2, 2, 4, 4, 5, 8, 8, 4,
+	}
+
+	dist := []int{
+		6, 2, 2, 5, 18, 2, 3, 2, 4, 2, 2, 3, 3, 3,
+		10, 6, 2, 1, 5, 5, 5, 3, 2, 2, 1, 1, 2,
+	}
+
+	folds := foldAccents("ÁÀÄ", "A", "ÉÈÊË", "E", "ÍÏ", "I", "ÓÖ", "O", "ÚÜ", "U")
+
+	return newLanguage("Nederlands", "nl", letters, points, dist, 2, folds)
+}
+
+// Languages returns every built-in language, English first.
+func Languages() []*Language {
+	return []*Language{English(), French(), Spanish(), German(), Dutch()}
 }
 
 // AlphabetSize returns the number of distinct (non-blank) letters.
@@ -77,49 +180,104 @@ func (l *Language) TotalTiles() int {
 	return total
 }
 
-// Rune returns the rune for a LetterID.
-func (l *Language) Rune(id LetterID) rune {
+// Letter returns the tile face for a LetterID, e.g. "A" or "LL".
+func (l *Language) Letter(id LetterID) string {
 	return l.Letters[id]
 }
 
-// ID returns the LetterID for a rune (case-insensitive).
-func (l *Language) ID(r rune) (LetterID, bool) {
-	id, ok := l.rune2id[unicode.ToUpper(r)]
+// Lookup returns the LetterID for a tile face (case-insensitive). Digraph
+// tiles must be looked up by their full face: "ll" finds LL, not L.
+func (l *Language) Lookup(face string) (LetterID, bool) {
+	id, ok := l.face2id[strings.ToUpper(face)]
 
 	return id, ok
 }
 
+// ID returns the LetterID for a single-rune letter (case-insensitive).
+func (l *Language) ID(r rune) (LetterID, bool) {
+	return l.Lookup(string(unicode.ToUpper(r)))
+}
+
 // PointValue returns the point value of a letter (ignores the blank rule).
 func (l *Language) PointValue(id LetterID) int {
 	return l.PointValues[id]
 }
 
-// NormalizeWord uppercases s and converts it into LetterIDs. Returns an
-// error naming the first rune not in this language's alphabet.
+// NormalizeWord uppercases s, folds accents this language does not print
+// on tiles, and splits the result into LetterIDs by longest tile-face
+// match. Returns an error naming the first rune no tile covers.
 func (l *Language) NormalizeWord(s string) ([]LetterID, error) {
-	upper := strings.ToUpper(s)
-	out := make([]LetterID, 0, len(upper))
+	runes := l.fold(strings.ToUpper(s))
+	out := make([]LetterID, 0, len(runes))
 
-	for _, letter := range upper {
-		id, ok := l.rune2id[letter]
+	for i := 0; i < len(runes); {
+		id, width, ok := l.matchFace(runes[i:])
 		if !ok {
-			return nil, fmt.Errorf("scrabble: rune %q not in %q alphabet", letter, l.Code)
+			return nil, fmt.Errorf("scrabble: rune %q not in %q alphabet", runes[i], l.Code)
 		}
 		out = append(out, id)
+		i += width
 	}
 
 	return out, nil
 }
 
-func newLanguage(name, code string, letters []rune, points, dist []int, blanks int) *Language {
+// String joins the tile faces of word back into a single string.
+func (l *Language) String(word []LetterID) string {
+	var sb strings.Builder
+
+	for _, id := range word {
+		sb.WriteString(l.Letters[id])
+	}
+
+	return sb.String()
+}
+
+func (l *Language) fold(upper string) []rune {
+	if len(l.folds) == 0 {
+		return []rune(upper)
+	}
+
+	out := make([]rune, 0, len(upper))
+
+	for _, r := range upper {
+		if repl, ok := l.folds[r]; ok {
+			out = append(out, []rune(repl)...)
+			continue
+		}
+		out = append(out, r)
+	}
+
+	return out
+}
+
+// matchFace finds the longest tile face that prefixes runes and returns
+// its LetterID and width in runes.
+func (l *Language) matchFace(runes []rune) (LetterID, int, bool) {
+	for width := min(l.maxFace, len(runes)); width > 0; width-- {
+		if id, ok := l.face2id[string(runes[:width])]; ok {
+			return id, width, true
+		}
+	}
+
+	return 0, 0, false
+}
+
+func newLanguage(name, code string, letters []string, points, dist []int, blanks int, folds map[rune]string) *Language {
 	if len(letters) != len(points) || len(letters) != len(dist) {
 		panic(fmt.Sprintf("scrabble: language %q parallel-slice mismatch", code))
 	}
 
-	lookup := make(map[rune]LetterID, len(letters))
+	if len(letters) > maxAlphabetSize {
+		panic(fmt.Sprintf("scrabble: language %q has %d letters, max is %d", code, len(letters), maxAlphabetSize))
+	}
 
-	for i, r := range letters {
-		lookup[r] = LetterID(i)
+	lookup := make(map[string]LetterID, len(letters))
+	longest := 0
+
+	for i, face := range letters {
+		lookup[face] = LetterID(i)
+		longest = max(longest, len([]rune(face)))
 	}
 
 	return &Language{
@@ -129,6 +287,32 @@ func newLanguage(name, code string, letters []rune, points, dist []int, blanks i
 		PointValues:  points,
 		Distribution: dist,
 		Blanks:       blanks,
-		rune2id:      lookup,
+		face2id:      lookup,
+		folds:        folds,
+		maxFace:      longest,
+	}
+}
+
+// splitFaces turns an alphabet string into one single-rune face per letter.
+func splitFaces(alphabet string) []string {
+	out := make([]string, 0, len(alphabet))
+
+	for _, r := range alphabet {
+		out = append(out, string(r))
+	}
+
+	return out
+}
+
+// foldAccents builds a fold table from (accented runes, base letter) pairs.
+func foldAccents(pairs ...string) map[rune]string {
+	out := make(map[rune]string)
+
+	for i := 0; i+1 < len(pairs); i += 2 {
+		for _, r := range pairs[i] {
+			out[r] = pairs[i+1]
+		}
 	}
+
+	return out
 }
@@ -80,8 +80,8 @@ func TestNormalizeWord(t *testing.T) {
 	}
 
 	for i, r := range want {
-		if lang.Rune(ids[i]) != r {
-			t.Errorf("position %d: got %q want %q", i, lang.Rune(ids[i]), r)
+		if lang.Letter(ids[i]) != string(r) {
+			t.Errorf("position %d: got %q want %q", i, lang.Letter(ids[i]), r)
 		}
 	}
 
@@ -89,3 +89,86 @@ func TestNormalizeWord(t *testing.T) {
 		t.Errorf("expected error for punctuation, got nil")
 	}
 }
+
+func TestBuiltinDistributions(t *testing.T) {
+	want := map[string]struct {
+		letters int
+		tiles   int
+	}{
+		"en": {26, 100},
+		"fr": {26, 102},
+		"es": {28, 100},
+		"de": {29, 102},
+		"nl": {27, 104},
+	}
+
+	for _, lang := range Languages() {
+		w, ok := want[lang.Code]
+		if !ok {
+			t.Errorf("unexpected language %q", lang.Code)
+			continue
+		}
+		if lang.AlphabetSize() != w.letters {
+			t.Errorf("%s: %d letters, want %d", lang.Code, lang.AlphabetSize(), w.letters)
+		}
+		if lang.TotalTiles() != w.tiles {
+			t.Errorf("%s: %d tiles, want %d", lang.Code, lang.TotalTiles(), w.tiles)
+		}
+	}
+}
+
+func TestNormalizeWordDigraphs(t *testing.T) {
+	cases := []struct {
+		lang *Language
+		word string
+		want []string
+	}{
+		{Spanish(), "chillar", []string{"CH", "I", "LL", "A", "R"}},
+		{Spanish(), "perro", []string{"P", "E", "RR", "O"}},
+		{Spanish(), "niño", []string{"N", "I", "Ñ", "O"}},
+		{Spanish(), "canción", []string{"C", "A", "N", "C", "I", "O", "N"}},
+		{Dutch(), "ijsje", []string{"IJ", "S", "J", "E"}},
+		{French(), "Élève", []string{"E", "L", "E", "V", "E"}},
+		{German(), "Straße", []string{"S", "T", "R", "A", "S", "S", "E"}},
+		{German(), "Bär", []string{"B", "Ä", "R"}},
+	}
+
+	for _, tc := range cases {
+		ids, err := tc.lang.NormalizeWord(tc.word)
+		if err != nil {
+			t.Errorf("%s %q: %v", tc.lang.Code, tc.word, err)
+			continue
+		}
+		if len(ids) != len(tc.want) {
+			t.Errorf("%s %q: got %d tiles want %d", tc.lang.Code, tc.word, len(ids), len(tc.want))
+			continue
+		}
+		for i, face := range tc.want {
+			if got := tc.lang.Letter(ids[i]); got != face {
+				t.Errorf("%s %q tile %d: got %q want %q", tc.lang.Code, tc.word, i, got, face)
+			}
+		}
+	}
+
+	if _, err := Spanish().NormalizeWord("kiwi"); err == nil {
+		t.Errorf("expected error for K in Spanish, got nil")
+	}
+}
+
+func TestLookupDigraph(t *testing.T) {
+	lang := Spanish()
+
+	ll, ok := lang.Lookup("ll")
+	if !ok || lang.Letter(ll) != "LL" {
+		t.Fatalf("lookup ll: got (%d, %v)", ll, ok)
+	}
+
+	l, ok := lang.ID('l')
+	if !ok || l == ll {
+		t.Errorf("ID('l') should find the single L tile, got (%d, %v)", l, ok)
+	}
+
+	if got := lang.String([]LetterID{ll, l}); got != "LLL" {
+		t.Errorf("String: got %q want %q", got, "LLL")
+	}
+}
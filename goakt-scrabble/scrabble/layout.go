@@ -0,0 +1,306 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// LayoutStandard and LayoutSuper name the built-in layouts.
+	LayoutStandard = "standard"
+	LayoutSuper    = "super"
+
+	minLayoutSize = 5
+	// maxLayoutSize keeps every column addressable by a GCG coordinate
+	// letter.
+	maxLayoutSize = 26
+)
+
+// ErrBadLayout is returned by ParseLayout.
+var ErrBadLayout = errors.New("scrabble: bad board layout")
+
+// Layout is a board's shape: its size, the start square and where the
+// premium squares are. BagScale is how many standard tile sets a game
+// on it is played with. A Layout is never mutated once built and may be
+// shared between boards.
+type Layout struct {
+	Name     string
+	BagScale int
+
+	size      int
+	centerRow int
+	centerCol int
+	premiums  []Premium
+}
+
+var (
+	standardLayout = buildStandardLayout()
+	superLayout    = mustParseLayout(LayoutSuper, superLayoutGrid)
+)
+
+// superLayoutGrid is a 21x21 board in the style of Super Scrabble, with
+// quadruple premiums in and near the corners. It is played with two
+// tile sets.
+const superLayoutGrid = `
+bag 2
+Q..d...T..d..T...d..Q
+.q....t.......t....q.
+..D......d.d......D..
+d..D...t.....t...D..d
+....D.....T.....D....
+.....D...d.d...D.....
+.t....D.......D....t.
+T..t...t.....t...t..T
+........d...d........
+..d..d.........d..d..
+d...T.....*.....T...d
+..d..d.........d..d..
+........d...d........
+T..t...t.....t...t..T
+.t....D.......D....t.
+.....D...d.d...D.....
+....D.....T.....D....
+d..D...t.....t...D..d
+..D......d.d......D..
+.q....t.......t....q.
+Q..d...T..d..T...d..Q
+`
+
+// StandardLayout is the 15x15 Hasbro layout.
+func StandardLayout() *Layout {
+	return standardLayout
+}
+
+// SuperLayout is the 21x21 Super Scrabble layout.
+func SuperLayout() *Layout {
+	return superLayout
+}
+
+// Layouts returns the built-in layouts.
+func Layouts() []*Layout {
+	return []*Layout{standardLayout, superLayout}
+}
+
+// LayoutByName returns the built-in layout called name.
+func LayoutByName(name string) (*Layout, bool) {
+	for _, layout := range Layouts() {
+		if layout.Name == name {
+			return layout, true
+		}
+	}
+
+	return nil, false
+}
+
+// Size is the number of rows, and of columns.
+func (l *Layout) Size() int {
+	return l.size
+}
+
+// Center returns the start square, which the first move must cover.
+func (l *Layout) Center() (int, int) {
+	return l.centerRow, l.centerCol
+}
+
+// InBounds reports whether (row, col) is on the board.
+func (l *Layout) InBounds(row, col int) bool {
+	return row >= 0 && row < l.size && col >= 0 && col < l.size
+}
+
+// Premium returns the premium of the square at (row, col).
+func (l *Layout) Premium(row, col int) Premium {
+	return l.premiums[row*l.size+col]
+}
+
+// Rows renders the layout in the grid notation ParseLayout reads, one
+// string per row.
+func (l *Layout) Rows() []string {
+	out := make([]string, l.size)
+
+	for row := range l.size {
+		var sb strings.Builder
+		for col := range l.size {
+			c := premiumChars[l.Premium(row, col)]
+			if row == l.centerRow && col == l.centerCol {
+				c = '*'
+				if l.Premium(row, col) == PremiumNone {
+					c = '+'
+				}
+			}
+			sb.WriteByte(c)
+		}
+		out[row] = sb.String()
+	}
+
+	return out
+}
+
+// premiumChars are the grid notation for each Premium.
+var premiumChars = map[Premium]byte{
+	PremiumNone:         '.',
+	PremiumDoubleLetter: 'd',
+	PremiumTripleLetter: 't',
+	PremiumQuadLetter:   'q',
+	PremiumDoubleWord:   'D',
+	PremiumTripleWord:   'T',
+	PremiumQuadWord:     'Q',
+}
+
+// LoadLayout reads a layout file; the layout is named after the file
+// with its extension stripped.
+func LoadLayout(path string) (*Layout, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	name := path[strings.LastIndexAny(path, `/\`)+1:]
+	if dot := strings.LastIndexByte(name, '.'); dot > 0 {
+		name = name[:dot]
+	}
+
+	return ParseLayout(name, f)
+}
+
+// ParseLayout reads a square grid with one character per square:
+//
+//	.  no premium          d t q  double, triple, quadruple letter
+//	*  start, double word  D T Q  double, triple, quadruple word
+//	+  start, no premium
+//
+// There must be exactly one start square. Blank lines and lines starting
+// with # are skipped, and a "bag N" line before the grid plays the
+// layout with N tile sets.
+func ParseLayout(name string, r io.Reader) (*Layout, error) {
+	layout := &Layout{Name: name, BagScale: 1, centerRow: -1}
+
+	var rows []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(line, "bag "); ok && rows == nil {
+			scale, err := strconv.Atoi(strings.TrimSpace(rest))
+			if err != nil || scale < 1 || scale > 4 {
+				return nil, fmt.Errorf("%w: %q", ErrBadLayout, line)
+			}
+			layout.BagScale = scale
+			continue
+		}
+
+		rows = append(rows, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	layout.size = len(rows)
+	if layout.size < minLayoutSize || layout.size > maxLayoutSize {
+		return nil, fmt.Errorf("%w: %d rows, want %d to %d", ErrBadLayout, layout.size, minLayoutSize, maxLayoutSize)
+	}
+
+	layout.premiums = make([]Premium, 0, layout.size*layout.size)
+
+	for row, line := range rows {
+		if len(line) != layout.size {
+			return nil, fmt.Errorf("%w: row %d has %d squares, want %d", ErrBadLayout, row+1, len(line), layout.size)
+		}
+
+		for col := range line {
+			premium, start, ok := parsePremium(line[col])
+			if !ok {
+				return nil, fmt.Errorf("%w: row %d: unknown square %q", ErrBadLayout, row+1, line[col])
+			}
+			if start {
+				if layout.centerRow >= 0 {
+					return nil, fmt.Errorf("%w: more than one start square", ErrBadLayout)
+				}
+				layout.centerRow, layout.centerCol = row, col
+			}
+			layout.premiums = append(layout.premiums, premium)
+		}
+	}
+
+	if layout.centerRow < 0 {
+		return nil, fmt.Errorf("%w: no start square", ErrBadLayout)
+	}
+
+	return layout, nil
+}
+
+func parsePremium(c byte) (Premium, bool, bool) {
+	switch c {
+	case '*':
+		return PremiumDoubleWord, true, true
+	case '+':
+		return PremiumNone, true, true
+	}
+
+	for premium, char := range premiumChars {
+		if char == c {
+			return premium, false, true
+		}
+	}
+
+	return PremiumNone, false, false
+}
+
+func mustParseLayout(name, grid string) *Layout {
+	layout, err := ParseLayout(name, strings.NewReader(grid))
+	if err != nil {
+		panic(err)
+	}
+
+	return layout
+}
+
+func buildStandardLayout() *Layout {
+	layout := &Layout{
+		Name:      LayoutStandard,
+		BagScale:  1,
+		size:      BoardSize,
+		centerRow: CenterRow,
+		centerCol: CenterCol,
+		premiums:  make([]Premium, 0, BoardSize*BoardSize),
+	}
+
+	for row := range BoardSize {
+		for col := range BoardSize {
+			layout.premiums = append(layout.premiums, standardPremium(row, col))
+		}
+	}
+
+	return layout
+}
@@ -0,0 +1,153 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"errors"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestSuperLayout(t *testing.T) {
+	layout := SuperLayout()
+
+	if layout.Size() != 21 || layout.BagScale != 2 {
+		t.Fatalf("got size %d bag %d, want 21 and 2", layout.Size(), layout.BagScale)
+	}
+
+	if row, col := layout.Center(); row != 10 || col != 10 {
+		t.Errorf("center: got (%d,%d) want (10,10)", row, col)
+	}
+
+	counts := map[Premium]int{}
+	for row := range layout.Size() {
+		for col := range layout.Size() {
+			p := layout.Premium(row, col)
+			counts[p]++
+			if mirror := layout.Premium(col, layout.Size()-1-row); mirror != p {
+				t.Errorf("(%d,%d) is not rotationally symmetric", row, col)
+			}
+		}
+	}
+
+	if counts[PremiumQuadWord] != 4 || counts[PremiumQuadLetter] != 4 {
+		t.Errorf("quad squares: got %d word %d letter, want 4 and 4", counts[PremiumQuadWord], counts[PremiumQuadLetter])
+	}
+
+	bag := NewLayoutBag(English(), layout, rand.New(rand.NewPCG(1, 1)))
+	if bag.Remaining() != 200 {
+		t.Errorf("super bag: got %d tiles want 200", bag.Remaining())
+	}
+}
+
+func TestLayoutRowsRoundTrip(t *testing.T) {
+	for _, layout := range Layouts() {
+		parsed, err := ParseLayout(layout.Name, strings.NewReader(strings.Join(layout.Rows(), "\n")))
+		if err != nil {
+			t.Fatalf("%s: %v", layout.Name, err)
+		}
+
+		for row := range layout.Size() {
+			for col := range layout.Size() {
+				if parsed.Premium(row, col) != layout.Premium(row, col) {
+					t.Errorf("%s (%d,%d): got %v want %v", layout.Name, row, col, parsed.Premium(row, col), layout.Premium(row, col))
+				}
+			}
+		}
+
+		if r1, c1 := parsed.Center(); r1 != layout.centerRow || c1 != layout.centerCol {
+			t.Errorf("%s center: got (%d,%d)", layout.Name, r1, c1)
+		}
+	}
+}
+
+func TestParseLayoutErrors(t *testing.T) {
+	cases := map[string]string{
+		"no start":    ".....\n.....\n.....\n.....\n.....",
+		"two starts":  "*....\n.....\n.....\n.....\n....*",
+		"ragged":      ".....\n....\n..*..\n.....\n.....",
+		"unknown":     ".....\n..x..\n..*..\n.....\n.....",
+		"too small":   "...\n.*.\n...",
+		"bad bag":     "bag 9\n.....\n.....\n..*..\n.....\n.....",
+		"not squared": ".....\n.....\n..*..\n.....",
+	}
+
+	for name, grid := range cases {
+		if _, err := ParseLayout(name, strings.NewReader(grid)); !errors.Is(err, ErrBadLayout) {
+			t.Errorf("%s: expected ErrBadLayout, got %v", name, err)
+		}
+	}
+}
+
+func TestQuadPremiumScoring(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+
+	layout, err := ParseLayout("tiny", strings.NewReader(`
+# a corner start, so the first word can reach both quads
+q.+.Q
+.....
+.....
+.....
+.....
+`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	board := NewLayoutBoard(layout)
+	move := Move{Placements: placementsFor(t, lang, "HORSE", 0, 0, Horizontal)}
+
+	result, err := move.Validate(board, dawg, lang)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	// H on the quad letter (4x4) plus O, R, S, E, all times four.
+	if result.Score != (16+4)*4 {
+		t.Errorf("score: got %d want %d", result.Score, (16+4)*4)
+	}
+
+	if _, err := (Move{Placements: placementsFor(t, lang, "HORSE", 1, 0, Horizontal)}).Validate(board, dawg, lang); !errors.Is(err, ErrFirstMoveMustCoverCenter) {
+		t.Errorf("expected ErrFirstMoveMustCoverCenter off the start square, got %v", err)
+	}
+
+	if _, err := (Move{Placements: placementsFor(t, lang, "HORSES", 0, 0, Horizontal)}).Validate(board, dawg, lang); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("expected ErrOutOfBounds past the edge, got %v", err)
+	}
+}
+
+func TestBestMoveOnSuperBoard(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+	board := NewLayoutBoard(SuperLayout())
+	placeWord(t, board, lang, "HORSE", 10, 8, Horizontal)
+
+	best := BestMove(board, rackFromWord(t, lang, "S"), dawg, lang)
+	if best == nil {
+		t.Fatal("expected HORSES on the super board")
+	}
+
+	if p := best.Move.Placements[0]; p.Row != 10 || p.Col != 13 {
+		t.Errorf("expected S at (10,13), got (%d,%d)", p.Row, p.Col)
+	}
+}
@@ -0,0 +1,79 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import "testing"
+
+func TestLeaveValuePrefersFlexibleTiles(t *testing.T) {
+	lang := English()
+	leaves := NewLeaveTable(lang)
+
+	good := append(rackFromWord(t, lang, "S").Tiles(), BlankTile)
+	bad := rackFromWord(t, lang, "VVU").Tiles()
+
+	if leaves.Value(good) <= leaves.Value(bad) {
+		t.Errorf("S? (%v) should beat VVU (%v)", leaves.Value(good), leaves.Value(bad))
+	}
+
+	one := leaves.Value(rackFromWord(t, lang, "E").Tiles())
+	two := leaves.Value(rackFromWord(t, lang, "EE").Tiles())
+	if two >= 2*one {
+		t.Errorf("duplicate E should be penalised: E=%v EE=%v", one, two)
+	}
+}
+
+func TestLeaveTableOtherLanguages(t *testing.T) {
+	lang := French()
+	leaves := NewLeaveTable(lang)
+
+	cheap := leaves.Value(rackFromWord(t, lang, "E").Tiles())
+	dear := leaves.Value(rackFromWord(t, lang, "Z").Tiles())
+	if cheap <= dear {
+		t.Errorf("French E (%v) should be worth keeping over Z (%v)", cheap, dear)
+	}
+}
+
+func TestLeaveRemovesPlayedTiles(t *testing.T) {
+	lang := English()
+	rack := rackFromWord(t, lang, "HORSEQZ")
+	move := Move{Placements: placementsFor(t, lang, "HORSE", 7, 7, Horizontal)}
+
+	leave := Leave(rack, move)
+	if got := lang.String(tileLetters(leave)); got != "QZ" {
+		t.Errorf("leave: got %q want QZ", got)
+	}
+
+	if rack.Size() != 7 {
+		t.Errorf("Leave must not modify the rack, size now %d", rack.Size())
+	}
+}
+
+func tileLetters(tiles []Tile) []LetterID {
+	out := make([]LetterID, len(tiles))
+
+	for i, tile := range tiles {
+		out[i] = tile.Letter
+	}
+
+	return out
+}
@@ -0,0 +1,153 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+)
+
+// Level is a bot difficulty.
+type Level uint8
+
+const (
+	// Beginner sticks to short words and plays from the lower-middle of
+	// the score range.
+	Beginner Level = iota
+	// Intermediate plays a good but rarely the best-scoring move.
+	Intermediate
+	// Expert maximises equity: score plus the value of its leave.
+	Expert
+)
+
+// beginnerMaxWordLen is the longest word a beginner bot will form. Words
+// this short are the ones a casual player knows.
+const beginnerMaxWordLen = 5
+
+// levelProfile is how a Level picks among the legal moves: moves are
+// ranked by score (or equity) and one is drawn uniformly from the
+// [lo, hi] percentile window of that ranking.
+type levelProfile struct {
+	maxWordLen int
+	equity     bool
+	lo, hi     float64
+}
+
+var levelProfiles = [...]levelProfile{
+	Beginner:     {maxWordLen: beginnerMaxWordLen, lo: 0.25, hi: 0.6},
+	Intermediate: {lo: 0.6, hi: 0.9},
+	Expert:       {equity: true, lo: 1, hi: 1},
+}
+
+// Levels lists the difficulty levels, weakest first.
+func Levels() []Level {
+	return []Level{Beginner, Intermediate, Expert}
+}
+
+func (l Level) String() string {
+	switch l {
+	case Beginner:
+		return "beginner"
+	case Intermediate:
+		return "intermediate"
+	case Expert:
+		return "expert"
+	}
+
+	return fmt.Sprintf("level(%d)", uint8(l))
+}
+
+// ParseLevel is the inverse of Level.String.
+func ParseLevel(s string) (Level, error) {
+	for _, level := range Levels() {
+		if level.String() == s {
+			return level, nil
+		}
+	}
+
+	return 0, fmt.Errorf("scrabble: unknown bot level %q", s)
+}
+
+// ChooseMove picks the move a bot of the given level plays, or nil to
+// pass. leaves is only consulted at Expert. rng drives the weaker levels'
+// deliberate mistakes.
+func ChooseMove(board *Board, rack *Rack, dawg *DAWG, lang *Language, level Level, leaves *LeaveTable, rng *rand.Rand) *ScoredMove {
+	if int(level) >= len(levelProfiles) {
+		level = Expert
+	}
+	profile := levelProfiles[level]
+
+	moves := GenerateMoves(board, rack, dawg, lang)
+	if profile.maxWordLen > 0 {
+		moves = slices.DeleteFunc(moves, func(m ScoredMove) bool {
+			return longestWord(m.Result) > profile.maxWordLen
+		})
+	}
+
+	if len(moves) == 0 {
+		return nil
+	}
+
+	value := func(m ScoredMove) float64 { return float64(m.Result.Score) }
+	if profile.equity && leaves != nil {
+		value = func(m ScoredMove) float64 { return leaves.Equity(rack, m) }
+	}
+
+	values := make([]float64, len(moves))
+	order := make([]int, len(moves))
+	for i := range moves {
+		values[i] = value(moves[i])
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(a, b int) int {
+		switch {
+		case values[a] < values[b]:
+			return -1
+		case values[a] > values[b]:
+			return 1
+		}
+		return 0
+	})
+
+	last := len(order) - 1
+	lo := int(profile.lo * float64(last))
+	hi := int(profile.hi * float64(last))
+	pick := hi
+	if hi > lo && rng != nil {
+		pick = lo + rng.IntN(hi-lo+1)
+	}
+
+	return &moves[order[pick]]
+}
+
+func longestWord(result *MoveResult) int {
+	longest := 0
+
+	for _, formed := range result.Words {
+		longest = max(longest, len(formed.Letters))
+	}
+
+	return longest
+}
@@ -0,0 +1,102 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	for _, level := range Levels() {
+		got, err := ParseLevel(level.String())
+		if err != nil || got != level {
+			t.Errorf("ParseLevel(%q) = (%v, %v)", level.String(), got, err)
+		}
+	}
+
+	if _, err := ParseLevel("grandmaster"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+}
+
+func TestExpertMaximisesEquity(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+	board := NewBoard()
+	placeWord(t, board, lang, "HORSE", 7, 7, Horizontal)
+	rack := rackFromWord(t, lang, "SITZQVV")
+	leaves := NewLeaveTable(lang)
+
+	got := ChooseMove(board, rack, dawg, lang, Expert, leaves, rand.New(rand.NewPCG(1, 2)))
+	if got == nil {
+		t.Fatal("expected an expert move")
+	}
+
+	best := leaves.Equity(rack, *got)
+	for _, m := range GenerateMoves(board, rack, dawg, lang) {
+		if eq := leaves.Equity(rack, m); eq > best {
+			t.Fatalf("move with equity %v beats expert's %v", eq, best)
+		}
+	}
+}
+
+func TestBeginnerAvoidsLongWords(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+	rack := rackFromWord(t, lang, "HOSIERY")
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	expert := ChooseMove(NewBoard(), rack, dawg, lang, Expert, NewLeaveTable(lang), rng)
+	if expert == nil || len(expert.Move.Placements) != 7 {
+		t.Fatalf("expert should bingo with HOSIERY, got %+v", expert)
+	}
+
+	for range 20 {
+		move := ChooseMove(NewBoard(), rack, dawg, lang, Beginner, nil, rng)
+		if move == nil {
+			t.Fatal("beginner should find a short word")
+		}
+		if n := longestWord(move.Result); n > beginnerMaxWordLen {
+			t.Fatalf("beginner formed a %d-letter word", n)
+		}
+	}
+}
+
+func TestIntermediateIsNotAlwaysBest(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+	rack := rackFromWord(t, lang, "HORSEIT")
+	rng := rand.New(rand.NewPCG(3, 4))
+
+	best := BestMove(NewBoard(), rack, dawg, lang)
+
+	for range 20 {
+		move := ChooseMove(NewBoard(), rack, dawg, lang, Intermediate, nil, rng)
+		if move == nil {
+			t.Fatal("intermediate should find a move")
+		}
+		if move.Result.Score < best.Result.Score {
+			return
+		}
+	}
+
+	t.Error("intermediate picked the top score 20 times in a row")
+}
@@ -78,6 +78,7 @@ type Move struct {
 // FormedWord describes one word resulting from a move.
 type FormedWord struct {
 	Word      string
+	Letters   []LetterID
 	Score     int
 	StartRow  int
 	StartCol  int
@@ -95,6 +96,50 @@ type MoveResult struct {
 // the score on success. The board is not mutated; the caller calls Apply
 // to commit.
 func (m Move) Validate(board *Board, dict Dictionary, lang *Language) (*MoveResult, error) {
+	return m.validate(board, dict, lang)
+}
+
+// ValidateLenient checks the move's geometry (line, contiguity, center,
+// connection) and scores it without consulting a dictionary. Challenge
+// rules use it to accept a phony provisionally; Phonies reports which of
+// the formed words a challenge would strike down.
+func (m Move) ValidateLenient(board *Board, lang *Language) (*MoveResult, error) {
+	return m.validate(board, nil, lang)
+}
+
+// Phonies returns the formed words dict does not contain, in the order
+// they were formed. An empty result means the move survives a challenge.
+func (r *MoveResult) Phonies(dict Dictionary) []string {
+	var out []string
+
+	for _, formed := range r.Words {
+		if !dict.Contains(formed.Letters) {
+			out = append(out, formed.Word)
+		}
+	}
+
+	return out
+}
+
+// Withdraw lifts the move's placements back off the board, undoing Apply.
+// It returns the lifted tiles in placement order.
+func (m Move) Withdraw(board *Board) ([]Tile, error) {
+	out := make([]Tile, 0, len(m.Placements))
+
+	for _, p := range m.Placements {
+		tile, err := board.Lift(p.Row, p.Col)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, tile)
+	}
+
+	return out, nil
+}
+
+// validate is the shared body of Validate and ValidateLenient; a nil dict
+// skips the dictionary check.
+func (m Move) validate(board *Board, dict Dictionary, lang *Language) (*MoveResult, error) {
 	if err := m.checkPlacements(board); err != nil {
 		return nil, err
 	}
@@ -129,7 +174,7 @@ func (m Move) Validate(board *Board, dict Dictionary, lang *Language) (*MoveResu
 	}
 
 	if board.IsEmptyBoard() {
-		if !coversCenter(placements) {
+		if !coversCenter(board, placements) {
 			return nil, ErrFirstMoveMustCoverCenter
 		}
 	} else if !extendsExisting(words, newSquares) {
@@ -140,12 +185,13 @@ func (m Move) Validate(board *Board, dict Dictionary, lang *Language) (*MoveResu
 
 	for _, formedWord := range words {
 		ids := wordLetterIDs(formedWord)
-		if !dict.Contains(ids) {
+		if dict != nil && !dict.Contains(ids) {
 			return nil, &InvalidWordError{Word: wordString(formedWord, lang)}
 		}
 		formed := FormedWord{
 			Word:      wordString(formedWord, lang),
-			Score:     scoreWord(formedWord, newSquares, lang),
+			Letters:   ids,
+			Score:     scoreWord(board, formedWord, newSquares, lang),
 			StartRow:  formedWord[0].row,
 			StartCol:  formedWord[0].col,
 			Direction: formedWord.direction(),
@@ -205,7 +251,7 @@ func (m Move) checkPlacements(board *Board) error {
 	seen := make(map[[2]int]bool, len(m.Placements))
 
 	for _, p := range m.Placements {
-		if !InBounds(p.Row, p.Col) {
+		if !board.InBounds(p.Row, p.Col) {
 			return ErrOutOfBounds
 		}
 		if p.Tile.IsUnassignedBlank() {
@@ -312,9 +358,11 @@ func hasPlacement(placements []Placement, row, col int) bool {
 	return false
 }
 
-func coversCenter(placements []Placement) bool {
+func coversCenter(board *Board, placements []Placement) bool {
+	row, col := board.Center()
+
 	for _, p := range placements {
-		if p.Row == CenterRow && p.Col == CenterCol {
+		if p.Row == row && p.Col == col {
 			return true
 		}
 	}
@@ -352,7 +400,7 @@ func extractWord(tmp *Board, row, col int, dir Direction) word {
 		prevRow := startRow - dRow
 		prevCol := startCol - dCol
 
-		if !InBounds(prevRow, prevCol) || !tmp.At(prevRow, prevCol).Filled {
+		if !tmp.InBounds(prevRow, prevCol) || !tmp.At(prevRow, prevCol).Filled {
 			break
 		}
 
@@ -363,7 +411,7 @@ func extractWord(tmp *Board, row, col int, dir Direction) word {
 
 	curRow, curCol := startRow, startCol
 
-	for InBounds(curRow, curCol) && tmp.At(curRow, curCol).Filled {
+	for tmp.InBounds(curRow, curCol) && tmp.At(curRow, curCol).Filled {
 		out = append(out, wordTile{row: curRow, col: curCol, tile: tmp.At(curRow, curCol).Tile})
 		curRow += dRow
 		curCol += dCol
@@ -403,16 +451,10 @@ func wordLetterIDs(w word) []LetterID {
 }
 
 func wordString(w word, lang *Language) string {
-	runes := make([]rune, len(w))
-
-	for i, tile := range w {
-		runes[i] = lang.Rune(tile.tile.Letter)
-	}
-
-	return string(runes)
+	return lang.String(wordLetterIDs(w))
 }
 
-func scoreWord(w word, newSquares map[[2]int]bool, lang *Language) int {
+func scoreWord(board *Board, w word, newSquares map[[2]int]bool, lang *Language) int {
 	letterTotal := 0
 	wordMultiplier := 1
 
@@ -421,16 +463,9 @@ func scoreWord(w word, newSquares map[[2]int]bool, lang *Language) int {
 		key := [2]int{tile.row, tile.col}
 
 		if newSquares[key] {
-			switch standardPremium(tile.row, tile.col) {
-			case PremiumDoubleLetter:
-				base *= 2
-			case PremiumTripleLetter:
-				base *= 3
-			case PremiumDoubleWord:
-				wordMultiplier *= 2
-			case PremiumTripleWord:
-				wordMultiplier *= 3
-			}
+			premium := board.At(tile.row, tile.col).Premium
+			base *= premium.LetterMultiplier()
+			wordMultiplier *= premium.WordMultiplier()
 		}
 
 		letterTotal += base
@@ -27,7 +27,7 @@ import (
 	"testing"
 )
 
-func placeWord(t *testing.T, board *Board, lang *Language, word string, row, col int, dir Direction) {
+func placeWord(t testing.TB, board *Board, lang *Language, word string, row, col int, dir Direction) {
 	t.Helper()
 
 	ids, err := lang.NormalizeWord(word)
@@ -297,3 +297,73 @@ func TestPlacementOnFilledSquareRejected(t *testing.T) {
 		t.Errorf("expected ErrSquareOccupied, got %v", err)
 	}
 }
+
+func TestValidateLenientAcceptsPhony(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+	board := NewBoard()
+
+	move := Move{Placements: placementsFor(t, lang, "HORZE", 7, 7, Horizontal)}
+
+	var invalid *InvalidWordError
+	if _, err := move.Validate(board, dawg, lang); !errors.As(err, &invalid) {
+		t.Fatalf("expected InvalidWordError from Validate, got %v", err)
+	}
+
+	result, err := move.ValidateLenient(board, lang)
+	if err != nil {
+		t.Fatalf("expected lenient validation to accept HORZE, got %v", err)
+	}
+
+	phonies := result.Phonies(dawg)
+	if len(phonies) != 1 || phonies[0] != "HORZE" {
+		t.Errorf("expected phonies [HORZE], got %v", phonies)
+	}
+
+	if _, err := (Move{Placements: placementsFor(t, lang, "HORZE", 6, 7, Vertical)}).ValidateLenient(board, lang); err != nil {
+		t.Errorf("lenient validation should still score a center-covering play, got %v", err)
+	}
+}
+
+func TestValidateLenientKeepsGeometryRules(t *testing.T) {
+	_, lang := newTestDAWG(t)
+	board := NewBoard()
+
+	move := Move{Placements: placementsFor(t, lang, "ZZ", 0, 0, Horizontal)}
+
+	if _, err := move.ValidateLenient(board, lang); !errors.Is(err, ErrFirstMoveMustCoverCenter) {
+		t.Errorf("expected ErrFirstMoveMustCoverCenter, got %v", err)
+	}
+}
+
+func TestWithdrawRestoresBoard(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+	board := NewBoard()
+	placeWord(t, board, lang, "HORSE", 7, 7, Horizontal)
+
+	sID, _ := lang.ID('S')
+	move := Move{Placements: []Placement{{Row: 7, Col: 12, Tile: Tile{Letter: sID}}}}
+
+	if _, err := move.Validate(board, dawg, lang); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if err := move.Apply(board); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	tiles, err := move.Withdraw(board)
+	if err != nil {
+		t.Fatalf("withdraw: %v", err)
+	}
+
+	if len(tiles) != 1 || tiles[0].Letter != sID {
+		t.Errorf("expected the S back, got %+v", tiles)
+	}
+
+	if board.At(7, 12).Filled {
+		t.Error("square (7,12) should be empty after withdraw")
+	}
+
+	if _, err := move.Withdraw(board); err == nil {
+		t.Error("withdrawing twice should fail on the empty square")
+	}
+}
@@ -0,0 +1,204 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import "math"
+
+// Glicko-2 system constants. glickoScale converts between the familiar
+// 1500-centred scale and the Glicko-2 internal one; tau limits how fast
+// volatility can move.
+const (
+	glickoScale   = 173.7178
+	glickoTau     = 0.5
+	glickoEpsilon = 1e-6
+
+	// DefaultRating, DefaultDeviation and DefaultVolatility describe a
+	// player who has never played a rated game.
+	DefaultRating     = 1500
+	DefaultDeviation  = 350
+	DefaultVolatility = 0.06
+
+	// MinDeviation keeps a long-standing rating responsive.
+	MinDeviation = 30
+
+	// MarginScale is the winning margin, in points, worth half of the
+	// margin bonus in a pairwise result.
+	MarginScale = 50
+)
+
+// Rating is a Glicko-2 rating on the 1500-centred scale: R is the
+// rating, RD its deviation and Vol the volatility.
+type Rating struct {
+	R   float64
+	RD  float64
+	Vol float64
+}
+
+// NewRating returns the rating of a new player.
+func NewRating() Rating {
+	return Rating{R: DefaultRating, RD: DefaultDeviation, Vol: DefaultVolatility}
+}
+
+// botRatings anchor the pool: bots have a fixed, well-known strength
+// and are never updated, so beating an expert is worth more than
+// beating a beginner.
+var botRatings = [...]Rating{
+	Beginner:     {R: 1100, RD: 60, Vol: DefaultVolatility},
+	Intermediate: {R: 1450, RD: 60, Vol: DefaultVolatility},
+	Expert:       {R: 1800, RD: 60, Vol: DefaultVolatility},
+}
+
+// BotRating is the fixed rating of a bot at level.
+func BotRating(level Level) Rating {
+	if int(level) >= len(botRatings) {
+		level = Expert
+	}
+
+	return botRatings[level]
+}
+
+// Opponent is one pairwise result inside a rating period: the
+// opponent's rating before the period and the score against them, 1
+// for a win, 0 for a loss and 0.5 for a draw.
+type Opponent struct {
+	Rating Rating
+	Score  float64
+}
+
+// MarginScore turns a final-score margin into a pairwise result. A win
+// is worth between 0.75 and 1 depending on the margin, a loss the
+// mirror image, so the finishing order always dominates and a blowout
+// counts a little more than a squeaker.
+func MarginScore(margin int) float64 {
+	if margin == 0 {
+		return 0.5
+	}
+
+	bonus := 0.25 * math.Tanh(math.Abs(float64(margin))/MarginScale*math.Atanh(0.5))
+	if margin > 0 {
+		return 0.75 + bonus
+	}
+
+	return 0.25 - bonus
+}
+
+// GameOpponents decomposes a finished game into pairwise results: seat
+// i played every other seat j and scored MarginScore(scores[i] -
+// scores[j]) against j's rating. ratings and scores are indexed by
+// seat.
+func GameOpponents(ratings []Rating, scores []int) [][]Opponent {
+	out := make([][]Opponent, len(ratings))
+
+	for i := range ratings {
+		out[i] = make([]Opponent, 0, len(ratings)-1)
+		for j := range ratings {
+			if i == j {
+				continue
+			}
+			out[i] = append(out[i], Opponent{Rating: ratings[j], Score: MarginScore(scores[i] - scores[j])})
+		}
+	}
+
+	return out
+}
+
+// Update applies one rating period's results with the Glicko-2
+// algorithm (Glickman, "Example of the Glicko-2 system"). A period
+// with no opponents only widens the deviation.
+func (r Rating) Update(opponents []Opponent) Rating {
+	mu := (r.R - DefaultRating) / glickoScale
+	phi := r.RD / glickoScale
+
+	if len(opponents) == 0 {
+		phi = math.Sqrt(phi*phi + r.Vol*r.Vol)
+		return Rating{R: r.R, RD: clampDeviation(phi * glickoScale), Vol: r.Vol}
+	}
+
+	var invV, sum float64
+	for _, o := range opponents {
+		muJ := (o.Rating.R - DefaultRating) / glickoScale
+		g := glickoG(o.Rating.RD / glickoScale)
+		e := 1 / (1 + math.Exp(-g*(mu-muJ)))
+		invV += g * g * e * (1 - e)
+		sum += g * (o.Score - e)
+	}
+
+	v := 1 / invV
+	delta := v * sum
+	vol := newVolatility(phi, v, delta, r.Vol)
+
+	phiStar := math.Sqrt(phi*phi + vol*vol)
+	phiNew := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	muNew := mu + phiNew*phiNew*sum
+
+	return Rating{
+		R:   muNew*glickoScale + DefaultRating,
+		RD:  clampDeviation(phiNew * glickoScale),
+		Vol: vol,
+	}
+}
+
+func glickoG(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+// newVolatility solves for the new volatility with the Illinois
+// variant of regula falsi, step 5 of the Glicko-2 algorithm.
+func newVolatility(phi, v, delta, vol float64) float64 {
+	a := math.Log(vol * vol)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-d)/(2*d*d) - (x-a)/(glickoTau*glickoTau)
+	}
+
+	lo := a
+	var hi float64
+	if delta*delta > phi*phi+v {
+		hi = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*glickoTau) < 0 {
+			k++
+		}
+		hi = a - k*glickoTau
+	}
+
+	fLo, fHi := f(lo), f(hi)
+	for math.Abs(hi-lo) > glickoEpsilon {
+		c := lo + (lo-hi)*fLo/(fHi-fLo)
+		fC := f(c)
+		if fC*fHi <= 0 {
+			lo, fLo = hi, fHi
+		} else {
+			fLo /= 2
+		}
+		hi, fHi = c, fC
+	}
+
+	return math.Exp(lo / 2)
+}
+
+func clampDeviation(rd float64) float64 {
+	return min(max(rd, MinDeviation), DefaultDeviation)
+}
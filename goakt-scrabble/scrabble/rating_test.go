@@ -0,0 +1,105 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"math"
+	"testing"
+)
+
+// TestRatingUpdateGlickmanExample is the worked example from
+// Glickman's "Example of the Glicko-2 system".
+func TestRatingUpdateGlickmanExample(t *testing.T) {
+	player := Rating{R: 1500, RD: 200, Vol: 0.06}
+	got := player.Update([]Opponent{
+		{Rating: Rating{R: 1400, RD: 30, Vol: 0.06}, Score: 1},
+		{Rating: Rating{R: 1550, RD: 100, Vol: 0.06}, Score: 0},
+		{Rating: Rating{R: 1700, RD: 300, Vol: 0.06}, Score: 0},
+	})
+
+	if math.Abs(got.R-1464.06) > 0.01 {
+		t.Errorf("rating: got %.2f want 1464.06", got.R)
+	}
+	if math.Abs(got.RD-151.52) > 0.01 {
+		t.Errorf("deviation: got %.2f want 151.52", got.RD)
+	}
+	if math.Abs(got.Vol-0.05999) > 0.00001 {
+		t.Errorf("volatility: got %.5f want 0.05999", got.Vol)
+	}
+}
+
+func TestRatingUpdateWithoutGames(t *testing.T) {
+	player := Rating{R: 1700, RD: 50, Vol: 0.06}
+	got := player.Update(nil)
+
+	if got.R != player.R {
+		t.Errorf("rating moved without games: %.2f", got.R)
+	}
+	if got.RD <= player.RD {
+		t.Errorf("deviation should widen: got %.2f", got.RD)
+	}
+}
+
+func TestMarginScore(t *testing.T) {
+	if got := MarginScore(0); got != 0.5 {
+		t.Errorf("draw: got %v want 0.5", got)
+	}
+
+	narrow, wide := MarginScore(5), MarginScore(150)
+	if narrow <= 0.75 || wide <= narrow || wide > 1 {
+		t.Errorf("wins: narrow %v wide %v, want 0.75 < narrow < wide <= 1", narrow, wide)
+	}
+
+	if math.Abs(MarginScore(MarginScale)-0.875) > 1e-9 {
+		t.Errorf("MarginScale win: got %v want 0.875", MarginScore(MarginScale))
+	}
+
+	if math.Abs(MarginScore(-40)+MarginScore(40)-1) > 1e-9 {
+		t.Errorf("loss should mirror win: %v + %v != 1", MarginScore(-40), MarginScore(40))
+	}
+}
+
+func TestGameOpponentsFavoursStrongerOpposition(t *testing.T) {
+	newcomer := NewRating()
+	scores := []int{400, 300}
+
+	beatBeginner := newcomer.Update(GameOpponents([]Rating{newcomer, BotRating(Beginner)}, scores)[0])
+	beatExpert := newcomer.Update(GameOpponents([]Rating{newcomer, BotRating(Expert)}, scores)[0])
+
+	if beatExpert.R <= beatBeginner.R {
+		t.Errorf("beating an expert (%.1f) should gain more than beating a beginner (%.1f)", beatExpert.R, beatBeginner.R)
+	}
+
+	ratings := []Rating{newcomer, newcomer, newcomer}
+	opponents := GameOpponents(ratings, []int{350, 300, 200})
+	first := newcomer.Update(opponents[0])
+	second := newcomer.Update(opponents[1])
+	third := newcomer.Update(opponents[2])
+
+	if !(first.R > second.R && second.R > third.R) {
+		t.Errorf("ratings should follow finishing order: %.1f %.1f %.1f", first.R, second.R, third.R)
+	}
+	if len(opponents[1]) != 2 {
+		t.Errorf("expected 2 opponents per seat, got %d", len(opponents[1]))
+	}
+}
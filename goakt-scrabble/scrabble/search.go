@@ -0,0 +1,316 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strings"
+)
+
+// Wildcards. RackBlank marks a blank in a rack string; in a search
+// pattern PatternAny matches exactly one tile and PatternRun matches any
+// run of tiles, including none.
+const (
+	RackBlank  = '?'
+	PatternAny = '?'
+	PatternRun = '*'
+)
+
+// ErrBadPattern is returned by MatchPattern for a pattern with no tiles
+// or wildcards in it.
+var ErrBadPattern = errors.New("scrabble: empty search pattern")
+
+// ParseRack parses a rack string such as "AEINST?" into tiles, each "?"
+// being an unassigned blank. Letters are split into tiles the way
+// NormalizeWord splits a word, so "ch" in a Spanish rack is the CH tile.
+func ParseRack(lang *Language, s string) ([]Tile, error) {
+	var tiles []Tile
+
+	for i, part := range strings.Split(s, string(RackBlank)) {
+		if i > 0 {
+			tiles = append(tiles, BlankTile)
+		}
+
+		ids, err := lang.NormalizeWord(part)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			tiles = append(tiles, Tile{Letter: id})
+		}
+	}
+
+	return tiles, nil
+}
+
+// Anagrams returns the words that use every tile of rack, in
+// alphabetical order. Letters supplied by a blank are lowercase.
+func Anagrams(dawg *DAWG, lang *Language, rack []Tile) []string {
+	return searchRack(dawg, lang, rack, true)
+}
+
+// SubAnagrams returns every word that can be made from some or all of
+// rack's tiles, longest first and alphabetically within a length.
+// Letters supplied by a blank are lowercase.
+func SubAnagrams(dawg *DAWG, lang *Language, rack []Tile) []string {
+	return searchRack(dawg, lang, rack, false)
+}
+
+// rackHit is one word found by searchRack and its length in tiles.
+type rackHit struct {
+	word  string
+	tiles int
+}
+
+// searchRack walks the DAWG spending rack tiles. A real tile is always
+// spent before a blank on the same letter, so each word is found once,
+// with as few blanks as possible.
+func searchRack(dawg *DAWG, lang *Language, rack []Tile, exact bool) []string {
+	counts := make([]int, lang.AlphabetSize())
+	blanks := 0
+
+	for _, tile := range rack {
+		if tile.Blank {
+			blanks++
+			continue
+		}
+		counts[tile.Letter]++
+	}
+
+	var (
+		hits  []rackHit
+		faces []string
+		walk  func(node DAWGNode)
+	)
+
+	walk = func(node DAWGNode) {
+		used := len(faces)
+		if node.Terminal() && used >= MinWordLength && (!exact || used == len(rack)) {
+			hits = append(hits, rackHit{word: strings.Join(faces, ""), tiles: used})
+		}
+
+		node.Each(func(letter LetterID, next DAWGNode) bool {
+			face := lang.Letter(letter)
+
+			switch {
+			case counts[letter] > 0:
+				counts[letter]--
+				faces = append(faces, face)
+				walk(next)
+				counts[letter]++
+			case blanks > 0:
+				blanks--
+				faces = append(faces, strings.ToLower(face))
+				walk(next)
+				blanks++
+			default:
+				return true
+			}
+
+			faces = faces[:len(faces)-1]
+
+			return true
+		})
+	}
+
+	walk(dawg.Root())
+
+	slices.SortFunc(hits, func(a, b rackHit) int {
+		if a.tiles != b.tiles {
+			return b.tiles - a.tiles
+		}
+		return cmp.Compare(strings.ToUpper(a.word), strings.ToUpper(b.word))
+	})
+
+	out := make([]string, len(hits))
+	for i, hit := range hits {
+		out[i] = hit.word
+	}
+
+	return out
+}
+
+// patternToken is one element of a parsed search pattern: a wildcard,
+// or a tile when wildcard is zero.
+type patternToken struct {
+	wildcard rune
+	letter   LetterID
+}
+
+// MatchPattern returns up to limit dictionary words matching pattern,
+// in alphabetical order. "?" matches one tile and "*" any run of tiles,
+// so "?A?E*" finds CAKE, GATES and WATERED. A limit of zero or less
+// means no limit.
+func MatchPattern(dawg *DAWG, lang *Language, pattern string, limit int) ([]string, error) {
+	tokens, err := parsePattern(lang, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		out   []string
+		seen  = make(map[string]struct{})
+		word  []LetterID
+		match func(node DAWGNode, pos int) bool
+	)
+
+	// match reports false once limit is reached to stop the walk.
+	match = func(node DAWGNode, pos int) bool {
+		if pos == len(tokens) {
+			if !node.Terminal() || len(word) < MinWordLength {
+				return true
+			}
+			text := lang.String(word)
+			if _, dup := seen[text]; !dup {
+				seen[text] = struct{}{}
+				out = append(out, text)
+			}
+			return limit <= 0 || len(out) < limit
+		}
+
+		tok := tokens[pos]
+
+		step := func(letter LetterID, next DAWGNode, nextPos int) bool {
+			word = append(word, letter)
+			more := match(next, nextPos)
+			word = word[:len(word)-1]
+			return more
+		}
+
+		switch tok.wildcard {
+		case PatternRun:
+			if !match(node, pos+1) {
+				return false
+			}
+			more := true
+			node.Each(func(letter LetterID, next DAWGNode) bool {
+				more = step(letter, next, pos)
+				return more
+			})
+			return more
+		case PatternAny:
+			more := true
+			node.Each(func(letter LetterID, next DAWGNode) bool {
+				more = step(letter, next, pos+1)
+				return more
+			})
+			return more
+		default:
+			next, ok := node.Edge(tok.letter)
+			if !ok {
+				return true
+			}
+			return step(tok.letter, next, pos+1)
+		}
+	}
+
+	match(dawg.Root(), 0)
+	slices.Sort(out)
+
+	return out, nil
+}
+
+// parsePattern splits pattern into tiles and wildcards, merging
+// consecutive runs since "**" matches nothing "*" does not.
+func parsePattern(lang *Language, pattern string) ([]patternToken, error) {
+	var (
+		tokens  []patternToken
+		segment strings.Builder
+	)
+
+	flush := func() error {
+		ids, err := lang.NormalizeWord(segment.String())
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			tokens = append(tokens, patternToken{letter: id})
+		}
+		segment.Reset()
+		return nil
+	}
+
+	for _, r := range pattern {
+		if r != PatternAny && r != PatternRun {
+			segment.WriteRune(r)
+			continue
+		}
+
+		if err := flush(); err != nil {
+			return nil, err
+		}
+
+		if r == PatternRun && len(tokens) > 0 && tokens[len(tokens)-1].wildcard == PatternRun {
+			continue
+		}
+		tokens = append(tokens, patternToken{wildcard: r})
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, ErrBadPattern
+	}
+
+	return tokens, nil
+}
+
+// Hooks returns the tiles that can be put in front of word (front) or
+// after it (back) to make another dictionary word.
+func Hooks(dawg *DAWG, word []LetterID) (front, back []LetterID) {
+	dawg.Root().Each(func(letter LetterID, next DAWGNode) bool {
+		if end, ok := follow(next, word); ok && end.Terminal() {
+			front = append(front, letter)
+		}
+		return true
+	})
+
+	if end, ok := follow(dawg.Root(), word); ok {
+		end.Each(func(letter LetterID, next DAWGNode) bool {
+			if next.Terminal() {
+				back = append(back, letter)
+			}
+			return true
+		})
+	}
+
+	return front, back
+}
+
+// follow walks word from node, returning the node it ends on.
+func follow(node DAWGNode, word []LetterID) (DAWGNode, bool) {
+	for _, id := range word {
+		next, ok := node.Edge(id)
+		if !ok {
+			return DAWGNode{}, false
+		}
+		node = next
+	}
+
+	return node, true
+}
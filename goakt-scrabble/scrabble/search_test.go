@@ -0,0 +1,115 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAnagramsWithBlank(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+
+	rack, err := ParseRack(lang, "ho?")
+	if err != nil {
+		t.Fatalf("parse rack: %v", err)
+	}
+
+	if got, want := Anagrams(dawg, lang, rack), []string{"HOt"}; !slices.Equal(got, want) {
+		t.Errorf("anagrams: got %v want %v", got, want)
+	}
+}
+
+func TestSubAnagramsLongestFirst(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+
+	rack, err := ParseRack(lang, "HOT")
+	if err != nil {
+		t.Fatalf("parse rack: %v", err)
+	}
+
+	if got, want := SubAnagrams(dawg, lang, rack), []string{"HOT", "HO", "TO"}; !slices.Equal(got, want) {
+		t.Errorf("sub-anagrams: got %v want %v", got, want)
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+
+	cases := []struct {
+		pattern string
+		want    []string
+	}{
+		{"H?RSE*", []string{"HORSE", "HORSES"}},
+		{"?I", []string{"HI", "QI"}},
+		{"*T", []string{"AT", "BIT", "HOT", "IT", "JET", "SIT", "ZIT"}},
+		{"**IT**", []string{"BIT", "BITE", "IT", "SIT", "ZIT"}},
+	}
+
+	for _, tc := range cases {
+		got, err := MatchPattern(dawg, lang, tc.pattern, 0)
+		if err != nil {
+			t.Errorf("%q: %v", tc.pattern, err)
+			continue
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("%q: got %v want %v", tc.pattern, got, tc.want)
+		}
+	}
+
+	if got, _ := MatchPattern(dawg, lang, "*", 3); len(got) != 3 {
+		t.Errorf("limit 3: got %d words", len(got))
+	}
+
+	if _, err := MatchPattern(dawg, lang, "", 0); err == nil {
+		t.Error("expected an error for an empty pattern")
+	}
+}
+
+func TestHooks(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+
+	cases := []struct {
+		word        string
+		front, back string
+	}{
+		{"HORSE", "", "S"},
+		{"IT", "BSZ", ""},
+		{"HE", "", "Y"},
+	}
+
+	for _, tc := range cases {
+		word, err := lang.NormalizeWord(tc.word)
+		if err != nil {
+			t.Fatalf("normalize %q: %v", tc.word, err)
+		}
+
+		front, back := Hooks(dawg, word)
+		if got := lang.String(front); got != tc.front {
+			t.Errorf("%s front hooks: got %q want %q", tc.word, got, tc.front)
+		}
+		if got := lang.String(back); got != tc.back {
+			t.Errorf("%s back hooks: got %q want %q", tc.word, got, tc.back)
+		}
+	}
+}
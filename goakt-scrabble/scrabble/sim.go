@@ -0,0 +1,213 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"math/rand/v2"
+	"slices"
+	"time"
+)
+
+// Simulation defaults, used when a SimConfig field is zero.
+const (
+	DefaultSimCandidates = 10
+	DefaultSimPlies      = 2
+)
+
+// SimConfig tunes Simulate. Candidates is how many of the top moves by
+// equity are simulated; Plies is how many replies each playout looks
+// ahead (opponent, then self, …); Budget is the wall-clock time to spend.
+type SimConfig struct {
+	Candidates int
+	Plies      int
+	Budget     time.Duration
+	Leaves     *LeaveTable
+	Rng        *rand.Rand
+}
+
+// Simulate picks a move by Monte Carlo simulation. The top candidates by
+// equity are played out round-robin until the budget runs out: each
+// playout deals the opponent a random rack from unseen (the tiles in the
+// bag and on opponents' racks), plays greedy replies for Plies turns and
+// scores the resulting spread. The candidate with the best score plus
+// mean spread wins. Returns nil if there is no legal move.
+func Simulate(board *Board, rack *Rack, unseen []Tile, dawg *DAWG, lang *Language, cfg SimConfig) *ScoredMove {
+	moves := GenerateMoves(board, rack, dawg, lang)
+	if len(moves) == 0 {
+		return nil
+	}
+
+	candidates := cfg.Candidates
+	if candidates <= 0 {
+		candidates = DefaultSimCandidates
+	}
+	plies := cfg.Plies
+	if plies <= 0 {
+		plies = DefaultSimPlies
+	}
+	rng := cfg.Rng
+	if rng == nil {
+		rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+
+	equity := make([]float64, len(moves))
+	for i, m := range moves {
+		equity[i] = float64(m.Result.Score)
+		if cfg.Leaves != nil {
+			equity[i] = cfg.Leaves.Equity(rack, m)
+		}
+	}
+
+	order := make([]int, len(moves))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		switch {
+		case equity[a] > equity[b]:
+			return -1
+		case equity[a] < equity[b]:
+			return 1
+		}
+		return 0
+	})
+	order = order[:min(candidates, len(order))]
+
+	sums := make([]float64, len(order))
+	counts := make([]int, len(order))
+	deadline := time.Now().Add(cfg.Budget)
+
+	if len(order) > 1 && len(unseen) > 0 {
+		for i := 0; time.Now().Before(deadline); i++ {
+			c := i % len(order)
+			sums[c] += playout(board, rack, moves[order[c]], unseen, plies, dawg, lang, cfg.Leaves, rng)
+			counts[c]++
+		}
+	}
+
+	best, bestValue := -1, 0.0
+	for c, idx := range order {
+		value := equity[idx]
+		if counts[c] > 0 {
+			value = float64(moves[idx].Result.Score) + sums[c]/float64(counts[c])
+		}
+		if best < 0 || value > bestValue {
+			best, bestValue = idx, value
+		}
+	}
+
+	return &moves[best]
+}
+
+// playout plays candidate, deals random racks from unseen and alternates
+// greedy replies for plies turns, opponent first. It returns the spread
+// those replies produce from the mover's point of view, plus the
+// difference in leave value at the end when leaves is set.
+func playout(board *Board, rack *Rack, candidate ScoredMove, unseen []Tile, plies int, dawg *DAWG, lang *Language, leaves *LeaveTable, rng *rand.Rand) float64 {
+	tmp := board.Clone()
+	if err := candidate.Move.Apply(tmp); err != nil {
+		return 0
+	}
+
+	pool := slices.Clone(unseen)
+	rng.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+
+	draw := func(r *Rack) {
+		n := min(RackSize-len(r.tiles), len(pool))
+		r.tiles = append(r.tiles, pool[:n]...)
+		pool = pool[n:]
+	}
+
+	mine := &Rack{tiles: Leave(rack, candidate.Move)}
+	draw(mine)
+	theirs := &Rack{}
+	draw(theirs)
+
+	var spread float64
+	side, sign := theirs, -1.0
+
+	for range plies {
+		if reply := BestMove(tmp, side, dawg, lang); reply != nil {
+			_ = reply.Move.Apply(tmp)
+			side.tiles = Leave(side, reply.Move)
+			draw(side)
+			spread += sign * float64(reply.Result.Score)
+		}
+
+		if side == theirs {
+			side, sign = mine, 1.0
+		} else {
+			side, sign = theirs, -1.0
+		}
+	}
+
+	if leaves != nil {
+		spread += leaves.Value(mine.tiles) - leaves.Value(theirs.tiles)
+	}
+
+	return spread
+}
+
+// Unseen returns the tiles a player cannot see from their seat: the full
+// set for lang and the board's layout, less the tiles on board and on
+// rack. Placed blanks count as blanks. Those are the tiles in the bag and
+// on the opponents' racks.
+func Unseen(board *Board, rack *Rack, lang *Language) []Tile {
+	scale := board.Layout().BagScale
+	counts := make([]int, len(lang.Distribution))
+	for id, n := range lang.Distribution {
+		counts[id] = n * scale
+	}
+	blanks := lang.Blanks * scale
+
+	take := func(tile Tile) {
+		if tile.Blank {
+			blanks--
+			return
+		}
+		counts[tile.Letter]--
+	}
+
+	for row := range board.Size() {
+		for col := range board.Size() {
+			if sq := board.At(row, col); sq.Filled {
+				take(sq.Tile)
+			}
+		}
+	}
+	for _, tile := range rack.tiles {
+		take(tile)
+	}
+
+	out := make([]Tile, 0, lang.TotalTiles()*scale)
+	for id, n := range counts {
+		for range n {
+			out = append(out, Tile{Letter: LetterID(id)})
+		}
+	}
+	for range blanks {
+		out = append(out, BlankTile)
+	}
+
+	return out
+}
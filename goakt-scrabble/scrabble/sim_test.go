@@ -0,0 +1,94 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+func TestUnseenExcludesBoardAndRack(t *testing.T) {
+	lang := English()
+	board := NewBoard()
+	placeWord(t, board, lang, "HORSE", 7, 7, Horizontal)
+
+	rack := rackFromWord(t, lang, "QZ")
+	rack.tiles = append(rack.tiles, BlankTile)
+
+	unseen := Unseen(board, rack, lang)
+	if len(unseen) != 100-5-3 {
+		t.Fatalf("unseen: got %d tiles want 92", len(unseen))
+	}
+
+	zID, _ := lang.ID('Z')
+	blanks := 0
+	for _, tile := range unseen {
+		if !tile.Blank && tile.Letter == zID {
+			t.Error("the only Z is on our rack")
+		}
+		if tile.Blank {
+			blanks++
+		}
+	}
+
+	if blanks != 1 {
+		t.Errorf("expected 1 unseen blank, got %d", blanks)
+	}
+}
+
+func TestSimulatePicksLegalCandidate(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+	board := NewBoard()
+	placeWord(t, board, lang, "HORSE", 7, 7, Horizontal)
+	rack := rackFromWord(t, lang, "SITZAEO")
+
+	move := Simulate(board, rack, Unseen(board, rack, lang), dawg, lang, SimConfig{
+		Candidates: 4,
+		Budget:     30 * time.Millisecond,
+		Leaves:     NewLeaveTable(lang),
+		Rng:        rand.New(rand.NewPCG(1, 2)),
+	})
+	if move == nil {
+		t.Fatal("expected a simulated move")
+	}
+
+	if _, err := move.Move.Validate(board, dawg, lang); err != nil {
+		t.Errorf("simulated move is not legal: %v", err)
+	}
+}
+
+func TestSimulateWithoutBudgetFallsBackToEquity(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+	board := NewBoard()
+	placeWord(t, board, lang, "HORSE", 7, 7, Horizontal)
+	rack := rackFromWord(t, lang, "SITZAEO")
+	leaves := NewLeaveTable(lang)
+
+	got := Simulate(board, rack, nil, dawg, lang, SimConfig{Leaves: leaves})
+	want := ChooseMove(board, rack, dawg, lang, Expert, leaves, nil)
+
+	if got == nil || want == nil || leaves.Equity(rack, *got) != leaves.Equity(rack, *want) {
+		t.Errorf("expected the top-equity move, got %+v want %+v", got, want)
+	}
+}
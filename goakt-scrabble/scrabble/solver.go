@@ -0,0 +1,183 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"slices"
+	"time"
+)
+
+// maxEndgamePlies caps the iterative deepening in SolveEndgame. Two racks
+// of seven tiles empty in at most fourteen plays, plus passes.
+const maxEndgamePlies = 2*RackSize + 2
+
+// EndgameResult is SolveEndgame's answer. Move is nil when passing is
+// best. Spread is the final score difference the line guarantees the
+// player to move (their points minus the opponent's, including the
+// end-of-game rack adjustments). Exact is false when the time budget ran
+// out before the search reached the end of every line.
+type EndgameResult struct {
+	Move   *ScoredMove
+	Spread int
+	Exact  bool
+}
+
+// SolveEndgame searches a two-player endgame — bag empty, so the
+// opponent's rack is known — with alpha-beta minimax, deepening one ply
+// at a time until every line reaches the end of the game or the deadline
+// passes. A player who goes out gains the other's rack value and the
+// other loses it; two passes in a row end the game with both racks
+// counted against their holders, standing in for the six-scoreless-turns
+// rule.
+func SolveEndgame(board *Board, rack, opp *Rack, dawg *DAWG, lang *Language, deadline time.Time) EndgameResult {
+	s := &endgameSearch{dawg: dawg, lang: lang, deadline: deadline}
+
+	var (
+		result EndgameResult
+		solved bool
+	)
+
+	for depth := 1; depth <= maxEndgamePlies; depth++ {
+		s.horizon = false
+		move, spread, ok := s.root(board, rack.Tiles(), opp.Tiles(), depth)
+		if !ok {
+			break
+		}
+
+		result = EndgameResult{Move: move, Spread: spread, Exact: !s.horizon}
+		solved = true
+		if result.Exact {
+			break
+		}
+	}
+
+	// Out of time before even one ply: fall back to the top score.
+	if !solved {
+		if moves := s.orderedMoves(board, rack.Tiles()); len(moves) > 0 {
+			result.Move = &moves[0]
+			result.Spread = moves[0].Result.Score
+		}
+	}
+
+	return result
+}
+
+// endgameInf bounds the alpha-beta window; spreads never come close.
+const endgameInf = 1 << 30
+
+// endgameSearch is the state of one SolveEndgame call. horizon records
+// whether the current iteration cut any line short; expired whether the
+// deadline passed mid-iteration, which voids that iteration.
+type endgameSearch struct {
+	dawg     *DAWG
+	lang     *Language
+	deadline time.Time
+	horizon  bool
+	expired  bool
+}
+
+// root is negamax at the top level that also returns the best move, or
+// false if the deadline interrupted the iteration.
+func (s *endgameSearch) root(board *Board, me, opp []Tile, depth int) (*ScoredMove, int, bool) {
+	var best *ScoredMove
+	bestSpread := -s.negamax(board, opp, me, true, depth-1, -endgameInf, endgameInf)
+
+	for _, move := range s.orderedMoves(board, me) {
+		v := s.afterMove(board, me, opp, move, depth, bestSpread, endgameInf)
+		if v > bestSpread {
+			best, bestSpread = &move, v
+		}
+	}
+
+	if s.expired {
+		return nil, 0, false
+	}
+
+	return best, bestSpread, true
+}
+
+// negamax returns the best spread the side to move (me) can force from
+// here within depth plies. passed reports whether the previous turn was
+// a pass, in which case passing again ends the game.
+func (s *endgameSearch) negamax(board *Board, me, opp []Tile, passed bool, depth, alpha, beta int) int {
+	if s.expired || time.Now().After(s.deadline) {
+		s.expired = true
+		return 0
+	}
+
+	if depth == 0 {
+		s.horizon = true
+		return tilesValue(opp, s.lang) - tilesValue(me, s.lang)
+	}
+
+	var best int
+	if passed {
+		best = tilesValue(opp, s.lang) - tilesValue(me, s.lang)
+	} else {
+		best = -s.negamax(board, opp, me, true, depth-1, -beta, -alpha)
+	}
+
+	alpha = max(alpha, best)
+
+	for _, move := range s.orderedMoves(board, me) {
+		if alpha >= beta {
+			break
+		}
+		v := s.afterMove(board, me, opp, move, depth, alpha, beta)
+		best = max(best, v)
+		alpha = max(alpha, v)
+	}
+
+	return best
+}
+
+// afterMove scores playing move: going out ends the game with the
+// opponent's rack counted twice (their loss and the mover's gain);
+// otherwise the opponent replies.
+func (s *endgameSearch) afterMove(board *Board, me, opp []Tile, move ScoredMove, depth, alpha, beta int) int {
+	score := move.Result.Score
+	left := Leave(&Rack{tiles: me}, move.Move)
+
+	if len(left) == 0 {
+		return score + 2*tilesValue(opp, s.lang)
+	}
+
+	next := board.Clone()
+	if err := move.Move.Apply(next); err != nil {
+		return -endgameInf
+	}
+
+	return score - s.negamax(next, opp, left, false, depth-1, score-beta, score-alpha)
+}
+
+// orderedMoves generates the legal moves for rack tiles, highest score
+// first so alpha-beta cuts early.
+func (s *endgameSearch) orderedMoves(board *Board, tiles []Tile) []ScoredMove {
+	moves := GenerateMoves(board, &Rack{tiles: tiles}, s.dawg, s.lang)
+
+	slices.SortStableFunc(moves, func(a, b ScoredMove) int {
+		return b.Result.Score - a.Result.Score
+	})
+
+	return moves
+}
@@ -0,0 +1,75 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scrabble
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSolveEndgameGoesOut(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+	board := NewBoard()
+	placeWord(t, board, lang, "HORSE", 7, 7, Horizontal)
+
+	rack := rackFromWord(t, lang, "T")
+	opp := rackFromWord(t, lang, "QV")
+
+	result := SolveEndgame(board, rack, opp, dawg, lang, time.Now().Add(time.Second))
+	if result.Move == nil {
+		t.Fatal("expected the solver to go out with its T")
+	}
+
+	if !result.Exact {
+		t.Error("a one-tile endgame should be solved exactly")
+	}
+
+	// Best T play scores 3 (TO with T on a double letter); going out adds
+	// the opponent's QV twice.
+	want := result.Move.Result.Score + 2*(10+4)
+	if result.Spread != want {
+		t.Errorf("spread: got %d want %d", result.Spread, want)
+	}
+}
+
+func TestSolveEndgameSeesOpponentReply(t *testing.T) {
+	dawg, lang := newTestDAWG(t)
+	board := NewBoard()
+	placeWord(t, board, lang, "HORSE", 7, 7, Horizontal)
+
+	// ZQ has no play, so we must pass. The opponent then plays JO on the
+	// double letter for 17 rather than passing the game out, and both
+	// racks count at the end: -17 + (V=4 - ZQ=20) = -33.
+	rack := rackFromWord(t, lang, "ZQ")
+	opp := rackFromWord(t, lang, "JV")
+
+	result := SolveEndgame(board, rack, opp, dawg, lang, time.Now().Add(time.Second))
+
+	if result.Move != nil {
+		t.Errorf("expected a pass, got %+v", result.Move.Move)
+	}
+
+	if result.Spread != -33 || !result.Exact {
+		t.Errorf("got spread %d (exact %v) want -33 exact", result.Spread, result.Exact)
+	}
+}
@@ -46,6 +46,15 @@ type PlayerSessionActor struct {
 	name      string
 	roomCode  string
 	topicName string
+
+	// language is the room's, learned from JoinedEvent; a rehydrated
+	// room keeps its own whatever the browser asked for.
+	language string
+
+	// spectator is asked for by the gateway and settled by JoinedEvent:
+	// a full room or a game in progress seats nobody new. A spectator's
+	// StateEvents never carry a rack, even one under its own playerID.
+	spectator bool
 }
 
 var _ actor.Actor = (*PlayerSessionActor)(nil)
@@ -64,11 +73,19 @@ func (p *PlayerSessionActor) Receive(ctx *actor.ReceiveContext) {
 			PlayerID:    p.playerID,
 			Name:        p.name,
 			SessionName: ctx.Self().Name(),
+			Spectator:   p.spectator,
 		})
 
 	case *actor.SubscribeAck, *actor.UnsubscribeAck:
 
 	case *PlayerInput:
+		if msg.In.Type == InTypeLookup {
+			p.lookup(ctx, msg.In)
+			return
+		}
+		if p.spectator && msg.In.Type != InTypeChat {
+			return
+		}
 		ctx.Tell(p.room, msg)
 
 	case *closed:
@@ -88,43 +105,81 @@ func (p *PlayerSessionActor) encodeWS(ctx *actor.ReceiveContext) {
 	switch event := ctx.Message().(type) {
 	case *JoinedEvent:
 		target = event.For
+		p.language = event.Language
+		p.spectator = event.Spectator
 		payload = map[string]any{
 			"type":        OutTypeJoined,
 			"room":        event.Room,
 			"language":    event.Language,
 			"playerID":    event.PlayerID,
 			"owner":       event.Owner,
+			"spectator":   event.Spectator,
 			"profile":     event.Profile,
 			"leaderboard": event.Leaderboard,
+			"alphabet":    event.Alphabet,
+			"layouts":     event.Layouts,
 		}
 	case *StateEvent:
 		target = event.For
 		yours := event.PerRack[p.playerID]
-		if yours == nil {
+		if yours == nil || p.spectator {
 			yours = []string{}
 		}
 		payload = map[string]any{
-			"type":         OutTypeState,
-			"phase":        event.Phase,
-			"board":        event.Board,
-			"yourRack":     yours,
-			"players":      event.Players,
-			"currentID":    event.CurrentID,
-			"ownerID":      event.OwnerID,
-			"bagRemaining": event.BagRemaining,
-			"timerMs":      event.TimerMs,
+			"type":          OutTypeState,
+			"phase":         event.Phase,
+			"board":         event.Board,
+			"yourRack":      yours,
+			"players":       event.Players,
+			"currentID":     event.CurrentID,
+			"ownerID":       event.OwnerID,
+			"bagRemaining":  event.BagRemaining,
+			"timerMs":       event.TimerMs,
+			"challengeRule": event.ChallengeRule,
+			"challengeMs":   event.ChallengeMs,
+			"casual":        event.Casual,
+			"public":        event.Public,
+			"spectators":    event.Spectators,
+			"timeControl":   event.TimeControl,
+			"layout":        event.Layout,
+			"premiums":      event.Premiums,
+			"bagScale":      event.BagScale,
+			"variant":       event.Variant,
+			"lexicon":       event.Lexicon,
 		}
 	case *MoveEvent:
 		target = event.For
 		payload = map[string]any{
-			"type":       OutTypeMove,
-			"playerID":   event.PlayerID,
-			"name":       event.Name,
+			"type":        OutTypeMove,
+			"playerID":    event.PlayerID,
+			"name":        event.Name,
+			"placements":  event.Placements,
+			"words":       event.Words,
+			"score":       event.Score,
+			"newTotal":    event.NewTotal,
+			"bingo":       event.Bingo,
+			"provisional": event.Provisional,
+		}
+	case *ChallengeEvent:
+		target = event.For
+		payload = map[string]any{
+			"type":           OutTypeChallenge,
+			"challengerID":   event.ChallengerID,
+			"challengerName": event.ChallengerName,
+			"playerID":       event.PlayerID,
+			"name":           event.Name,
+			"phonies":        event.Phonies,
+			"withdrawn":      event.Withdrawn,
+			"score":          event.Score,
+			"newTotal":       event.NewTotal,
+		}
+	case *HintEvent:
+		target = event.For
+		payload = map[string]any{
+			"type":       OutTypeHint,
 			"placements": event.Placements,
 			"words":      event.Words,
 			"score":      event.Score,
-			"newTotal":   event.NewTotal,
-			"bingo":      event.Bingo,
 		}
 	case *ChatEvent:
 		target = event.For
@@ -141,12 +196,24 @@ func (p *PlayerSessionActor) encodeWS(ctx *actor.ReceiveContext) {
 		}
 	case *GameOverEvent:
 		target = event.For
+		if event.Record != "" {
+			gameArchiveFromExtension(ctx.ActorSystem()).Save(p.roomCode, event.GameNumber, []byte(event.Record))
+		}
 		payload = map[string]any{
 			"type":        OutTypeGameOver,
 			"winnerID":    event.WinnerID,
 			"winnerName":  event.WinnerName,
 			"scores":      event.Scores,
 			"leaderboard": event.Leaderboard,
+			"gameNumber":  event.GameNumber,
+		}
+	case *AnalysisEvent:
+		target = event.For
+		payload = map[string]any{
+			"type":       OutTypeAnalysis,
+			"gameNumber": event.GameNumber,
+			"turns":      event.Turns,
+			"lost":       event.Lost,
 		}
 	default:
 		ctx.Unhandled()
@@ -157,6 +224,42 @@ func (p *PlayerSessionActor) encodeWS(ctx *actor.ReceiveContext) {
 		return
 	}
 
+	p.write(ctx, payload)
+}
+
+// lookup answers a word-tool query from this node's Registry; the room
+// is not involved.
+func (p *PlayerSessionActor) lookup(ctx *actor.ReceiveContext, in WSIn) {
+	registry := registryFromExtension(ctx.ActorSystem())
+	if registry == nil {
+		return
+	}
+
+	bundle, err := registry.Get(p.language)
+	if err != nil {
+		p.write(ctx, map[string]any{"type": OutTypeError, "message": err.Error()})
+		return
+	}
+
+	result, err := lookup(bundle, in.Kind, in.Query, 0)
+	if err != nil {
+		p.write(ctx, map[string]any{"type": OutTypeError, "message": "lookup: " + err.Error()})
+		return
+	}
+
+	p.write(ctx, map[string]any{
+		"type":      OutTypeLookup,
+		"kind":      result.Kind,
+		"query":     result.Query,
+		"valid":     result.Valid,
+		"words":     result.Words,
+		"front":     result.Front,
+		"back":      result.Back,
+		"truncated": result.Truncated,
+	})
+}
+
+func (p *PlayerSessionActor) write(ctx *actor.ReceiveContext, payload any) {
 	data, err := json.Marshal(payload)
 	if err != nil {
 		ctx.Err(err)
@@ -0,0 +1,386 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/tochemey/goakt/v4/actor"
+	"github.com/tochemey/goakt/v4/extension"
+
+	"github.com/tochemey/goakt-examples/v2/goakt-scrabble/scrabble"
+)
+
+const (
+	RoomStoreExtensionID = "scrabble_room_store"
+
+	// roomSnapshotTTL is how long an abandoned game stays resumable.
+	// Older snapshots are treated as missing.
+	roomSnapshotTTL = 24 * time.Hour
+
+	// roomStoreTimeout bounds the store calls a room or the lobby makes
+	// from inside Receive.
+	roomStoreTimeout = 2 * time.Second
+
+	// rejoinWindow is how long a rehydrated room waits for a player to
+	// reconnect before shutting down again.
+	rejoinWindow = 5 * time.Minute
+)
+
+// roomSnapshot is everything a RoomActor needs to pick a game up again
+// on another node: the board, the bag, every seat's rack, score and
+// clock, and the game record so far. It is taken at the start of every
+// turn, so a restored game resumes at the start of the turn that was in
+// progress.
+type roomSnapshot struct {
+	// Seq increases with every snapshot a room takes. Saves run
+	// asynchronously, so stores keep the snapshot with the highest Seq.
+	Seq int64
+
+	Language       string
+	OwnerID        string
+	ChallengeRule  string
+	Casual         bool
+	Public         bool
+	TimeControl    string
+	Layout         string
+	Variant        string
+	Lexicon        string
+	GameNumber     int
+	Seats          []seatSnapshot
+	CurrentIdx     int
+	ScorelessTurns int
+	LostTurn       []string
+	TurnLeftMs     int64
+	Board          []scrabble.Placement
+	Bag            []scrabble.Tile
+	Record         *scrabble.GCGGame
+	RecordSeat     map[string]int
+}
+
+type seatSnapshot struct {
+	ID      string
+	Name    string
+	Score   int
+	Rack    []scrabble.Tile
+	Bot     bool
+	Level   scrabble.Level
+	ClockMs int64
+}
+
+// roomStore persists room snapshots by room code so a game survives the
+// loss of the node hosting its RoomActor. Like profileStore it ships as
+// memRoomStore and pgRoomStore, selected in main.go by DATABASE_URL.
+type roomStore interface {
+	extension.Extension
+	Load(ctx context.Context, code string) (snap roomSnapshot, found bool, err error)
+	Save(ctx context.Context, code string, snap roomSnapshot) error
+	Delete(ctx context.Context, code string) error
+}
+
+// memRoomStore keeps snapshots in a process-local map.
+//
+// A room rehydrated on the same pod resumes its game, but a snapshot
+// does not outlive the pod that saved it, so a rolling deploy still
+// loses games. Wire DATABASE_URL to use Postgres.
+type memRoomStore struct {
+	mu   sync.Mutex
+	data map[string]memRoomEntry
+}
+
+type memRoomEntry struct {
+	snap    roomSnapshot
+	savedAt time.Time
+}
+
+var _ roomStore = (*memRoomStore)(nil)
+
+func newMemRoomStore() *memRoomStore {
+	return &memRoomStore{data: make(map[string]memRoomEntry)}
+}
+
+func (s *memRoomStore) ID() string { return RoomStoreExtensionID }
+
+func (s *memRoomStore) Load(_ context.Context, code string) (roomSnapshot, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.data[code]
+	if !ok || time.Since(entry.savedAt) > roomSnapshotTTL {
+		return roomSnapshot{}, false, nil
+	}
+
+	return entry.snap, true, nil
+}
+
+func (s *memRoomStore) Save(_ context.Context, code string, snap roomSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.data[code]; ok && entry.snap.Seq >= snap.Seq {
+		return nil
+	}
+	s.data[code] = memRoomEntry{snap: snap, savedAt: time.Now()}
+
+	return nil
+}
+
+func (s *memRoomStore) Delete(_ context.Context, code string) error {
+	s.mu.Lock()
+	delete(s.data, code)
+	s.mu.Unlock()
+
+	return nil
+}
+
+func roomStoreFromExtension(system actor.ActorSystem) roomStore {
+	for _, ext := range system.Extensions() {
+		if ext.ID() == RoomStoreExtensionID {
+			if store, ok := ext.(roomStore); ok {
+				return store
+			}
+		}
+	}
+
+	return nil
+}
+
+// loadRoomSnapshot fetches the snapshot for code, treating a missing
+// store or a failed load as "no snapshot".
+func loadRoomSnapshot(ctx context.Context, system actor.ActorSystem, code string) (roomSnapshot, bool) {
+	store := roomStoreFromExtension(system)
+	if store == nil {
+		return roomSnapshot{}, false
+	}
+
+	loadCtx, cancel := context.WithTimeout(ctx, roomStoreTimeout)
+	defer cancel()
+
+	snap, ok, err := store.Load(loadCtx, code)
+	if err != nil {
+		system.Logger().Warnf("room snapshot load failed for %s: %v", code, err)
+		return roomSnapshot{}, false
+	}
+
+	return snap, ok
+}
+
+// saveSnapshot snapshots the game and hands the write to a PipeTo
+// goroutine so the store round-trip never blocks the mailbox.
+func (r *RoomActor) saveSnapshot(ctx *actor.ReceiveContext) {
+	store := roomStoreFromExtension(ctx.ActorSystem())
+	if store == nil || r.board == nil {
+		return
+	}
+
+	r.snapshotSeq++
+	code, snap := r.code, r.snapshot()
+
+	ctx.PipeTo(ctx.Self(), func() (any, error) {
+		saveCtx, cancel := context.WithTimeout(context.Background(), roomStoreTimeout)
+		defer cancel()
+
+		return nil, store.Save(saveCtx, code, snap)
+	})
+}
+
+// dropSnapshot removes the room's snapshot once its game has ended.
+func (r *RoomActor) dropSnapshot(ctx *actor.ReceiveContext) {
+	store := roomStoreFromExtension(ctx.ActorSystem())
+	if store == nil {
+		return
+	}
+
+	code := r.code
+
+	ctx.PipeTo(ctx.Self(), func() (any, error) {
+		deleteCtx, cancel := context.WithTimeout(context.Background(), roomStoreTimeout)
+		defer cancel()
+
+		return nil, store.Delete(deleteCtx, code)
+	})
+}
+
+func (r *RoomActor) snapshot() roomSnapshot {
+	snap := roomSnapshot{
+		Seq:            r.snapshotSeq,
+		Language:       r.language,
+		OwnerID:        r.ownerID,
+		ChallengeRule:  r.challengeRule,
+		Casual:         r.casual,
+		Public:         r.public,
+		TimeControl:    r.timeControl.String(),
+		Layout:         r.layout.Name,
+		Variant:        r.variant,
+		Lexicon:        r.bundle.Lexicon,
+		GameNumber:     r.gameNumber,
+		Seats:          make([]seatSnapshot, len(r.players)),
+		CurrentIdx:     r.currentIdx,
+		ScorelessTurns: r.scorelessTurns,
+		Bag:            r.bag.Tiles(),
+		RecordSeat:     maps.Clone(r.recordSeat),
+	}
+
+	for i, player := range r.players {
+		snap.Seats[i] = seatSnapshot{
+			ID:      player.id,
+			Name:    player.name,
+			Score:   player.score,
+			Bot:     player.bot,
+			Level:   player.botLevel,
+			ClockMs: player.clock.Remaining.Milliseconds(),
+		}
+		if player.rack != nil {
+			snap.Seats[i].Rack = player.rack.Tiles()
+		}
+	}
+
+	for id := range r.lostTurn {
+		snap.LostTurn = append(snap.LostTurn, id)
+	}
+
+	switch {
+	case r.pausedRemaining > 0:
+		snap.TurnLeftMs = r.pausedRemaining.Milliseconds()
+	case !r.turnDeadline.IsZero():
+		snap.TurnLeftMs = time.Until(r.turnDeadline).Milliseconds()
+	}
+
+	for row := range r.board.Size() {
+		for col := range r.board.Size() {
+			if sq := r.board.At(row, col); sq.Filled {
+				snap.Board = append(snap.Board, scrabble.Placement{Row: row, Col: col, Tile: sq.Tile})
+			}
+		}
+	}
+
+	if r.record != nil {
+		record := *r.record
+		record.Players = append([]scrabble.GCGPlayer(nil), r.record.Players...)
+		record.Events = append([]scrabble.GCGEvent(nil), r.record.Events...)
+		// The layout is saved by name and restored from the registry.
+		record.Layout = nil
+		snap.Record = &record
+	}
+
+	return snap
+}
+
+// restore rebuilds the game from snap. Seats come back without
+// sessions: players reattach by id when they reconnect. Bots are
+// respawned on this node.
+func (r *RoomActor) restore(ctx *actor.ReceiveContext, snap roomSnapshot) error {
+	lang := r.bundle.Lang
+
+	registry := registryFromExtension(ctx.ActorSystem())
+	if registry == nil {
+		return fmt.Errorf("room %s: no registry extension", r.code)
+	}
+
+	// A lexicon this node cannot load costs the game its wordlist, not
+	// the game itself: play goes on with the current one.
+	if snap.Lexicon != "" {
+		bundle, err := registry.Lexicon(ctx.Context(), snap.Lexicon)
+		if err != nil {
+			ctx.Logger().Warnf("room %s: lexicon %s: %v; using %s", r.code, snap.Lexicon, err, r.bundle.Lexicon)
+		} else {
+			r.useBundle(registry, bundle)
+		}
+	}
+
+	layout := scrabble.StandardLayout()
+	if snap.Layout != "" {
+		var err error
+		if layout, err = registry.Layout(snap.Layout); err != nil {
+			return err
+		}
+	}
+
+	board := scrabble.NewLayoutBoard(layout)
+	for _, p := range snap.Board {
+		if err := board.Place(p.Row, p.Col, p.Tile); err != nil {
+			return err
+		}
+	}
+
+	r.board = board
+	r.layout = layout
+	r.variant = cmp.Or(snap.Variant, VariantClassic)
+	r.bag = scrabble.RestoreBag(lang, snap.Bag, newRoomRNG())
+	r.snapshotSeq = snap.Seq
+	r.ownerID = snap.OwnerID
+	r.challengeRule = snap.ChallengeRule
+	r.casual = snap.Casual
+	r.public = snap.Public
+	r.timeControl, _ = scrabble.ParseTimeControl(snap.TimeControl)
+	r.gameNumber = snap.GameNumber
+	r.currentIdx = snap.CurrentIdx
+	r.scorelessTurns = snap.ScorelessTurns
+	r.pausedRemaining = time.Duration(max(snap.TurnLeftMs, time.Second.Milliseconds())) * time.Millisecond
+	r.record = nil
+	if snap.Record != nil {
+		// The store keeps its own copy of the snapshot, so copy the record
+		// out of it the same way snapshot copies it in.
+		record := *snap.Record
+		record.Players = append([]scrabble.GCGPlayer(nil), snap.Record.Players...)
+		record.Events = append([]scrabble.GCGEvent(nil), snap.Record.Events...)
+		record.Layout = layout
+		r.record = &record
+	}
+	r.recordSeat = maps.Clone(snap.RecordSeat)
+
+	r.lostTurn = make(map[string]struct{}, len(snap.LostTurn))
+	for _, id := range snap.LostTurn {
+		r.lostTurn[id] = struct{}{}
+	}
+
+	r.players = make([]*roomPlayer, 0, len(snap.Seats))
+	for _, seat := range snap.Seats {
+		player := &roomPlayer{
+			id:       seat.ID,
+			name:     seat.Name,
+			score:    seat.Score,
+			bot:      seat.Bot,
+			botLevel: seat.Level,
+			clock:    scrabble.Clock{Remaining: time.Duration(seat.ClockMs) * time.Millisecond},
+		}
+		if seat.Rack != nil {
+			player.rack = scrabble.NewRack()
+			player.rack.Add(seat.Rack)
+		}
+		if seat.Bot {
+			player.botPID = r.spawnBot(ctx, seat.ID)
+		}
+
+		r.players = append(r.players, player)
+	}
+
+	r.hadPlayer = true
+
+	return nil
+}
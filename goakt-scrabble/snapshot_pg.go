@@ -0,0 +1,128 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// pgRoomStore persists room snapshots in Postgres so a game in progress
+// survives the pod hosting its RoomActor and can be rehydrated by the
+// lobby on any other pod.
+type pgRoomStore struct {
+	pool *pgxpool.Pool
+}
+
+var _ roomStore = (*pgRoomStore)(nil)
+
+const roomSnapshotSchema = `
+CREATE TABLE IF NOT EXISTS room_snapshots (
+    code       TEXT        PRIMARY KEY,
+    seq        BIGINT      NOT NULL,
+    snapshot   JSONB       NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);`
+
+// newPgRoomStore connects to Postgres, runs the idempotent schema
+// migration, and returns a store ready for use. The caller owns
+// lifecycle: call Close on shutdown.
+func newPgRoomStore(ctx context.Context, dsn string) (*pgRoomStore, error) {
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parse DATABASE_URL: %w", err)
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+
+	if _, err := pool.Exec(ctx, roomSnapshotSchema); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("migrate: %w", err)
+	}
+
+	return &pgRoomStore{pool: pool}, nil
+}
+
+func (s *pgRoomStore) ID() string { return RoomStoreExtensionID }
+
+func (s *pgRoomStore) Close() {
+	if s.pool != nil {
+		s.pool.Close()
+	}
+}
+
+func (s *pgRoomStore) Load(ctx context.Context, code string) (roomSnapshot, bool, error) {
+	const q = `SELECT snapshot FROM room_snapshots WHERE code = $1 AND updated_at > now() - make_interval(secs => $2)`
+
+	var data []byte
+	err := s.pool.QueryRow(ctx, q, code, roomSnapshotTTL.Seconds()).Scan(&data)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return roomSnapshot{}, false, nil
+	case err != nil:
+		return roomSnapshot{}, false, err
+	}
+
+	var snap roomSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return roomSnapshot{}, false, fmt.Errorf("decode snapshot: %w", err)
+	}
+
+	return snap, true, nil
+}
+
+// Save upserts the snapshot unless a newer one (higher Seq) is already
+// stored, so an out-of-order write cannot roll a game back.
+func (s *pgRoomStore) Save(ctx context.Context, code string, snap roomSnapshot) error {
+	const q = `
+INSERT INTO room_snapshots (code, seq, snapshot, updated_at)
+VALUES ($1, $2, $3, now())
+ON CONFLICT (code) DO UPDATE SET
+    seq        = EXCLUDED.seq,
+    snapshot   = EXCLUDED.snapshot,
+    updated_at = now()
+WHERE room_snapshots.seq < EXCLUDED.seq;`
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("encode snapshot: %w", err)
+	}
+
+	_, err = s.pool.Exec(ctx, q, code, snap.Seq, data)
+
+	return err
+}
+
+func (s *pgRoomStore) Delete(ctx context.Context, code string) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM room_snapshots WHERE code = $1`, code)
+
+	return err
+}
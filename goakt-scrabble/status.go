@@ -0,0 +1,109 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tochemey/goakt/v4/actor"
+)
+
+// status is the room as the lobby's index sees it: enough for the room
+// browser to show, with no board or scores, so a move that changes
+// neither seats nor phase leaves it untouched.
+func (r *RoomActor) status(phase string) RoomStatus {
+	return RoomStatus{
+		Code:        r.code,
+		Name:        RoomActorPrefix + r.language + "." + strings.ToLower(r.code),
+		Phase:       phase,
+		Players:     len(r.players),
+		MaxPlayers:  MaxPlayers,
+		Spectators:  len(r.spectators),
+		Language:    r.language,
+		Variant:     r.variant,
+		Layout:      r.layout.Name,
+		TimeControl: r.timeControl.String(),
+		Casual:      r.casual,
+		Public:      r.public,
+		Joinable:    phase == PhaseWaiting && len(r.players) < MaxPlayers,
+	}
+}
+
+// reportStatus tells the lobby about a changed status. broadcastState
+// calls it on every state change, which covers every phase transition
+// and every join and leave.
+func (r *RoomActor) reportStatus(ctx *actor.ReceiveContext, phase string) {
+	status := r.status(phase)
+	if status == r.reported {
+		return
+	}
+	r.sendStatus(ctx, status)
+}
+
+func (r *RoomActor) sendStatus(ctx *actor.ReceiveContext, status RoomStatus) {
+	lobby, err := ctx.ActorSystem().ActorOf(ctx.Context(), LobbyActorName)
+	if err != nil {
+		ctx.Logger().Infof("room %s: lobby unavailable for status: %v", r.code, err)
+		return
+	}
+	r.reported = status
+	ctx.Tell(lobby, &status)
+}
+
+// reportStopped is PostStop's goodbye to the lobby's index. There is
+// no ReceiveContext left, so it goes out as a package-level Tell.
+func (r *RoomActor) reportStopped(ctx *actor.Context) {
+	if r.code == "" {
+		return // never got past PostStart
+	}
+
+	lobby, err := ctx.ActorSystem().ActorOf(context.Background(), LobbyActorName)
+	if err != nil {
+		return
+	}
+	_ = actor.Tell(context.Background(), lobby, &RoomStatus{
+		Code:    r.code,
+		Name:    RoomActorPrefix + r.language + "." + strings.ToLower(r.code),
+		Stopped: true,
+	})
+}
+
+// unhandled is every behavior's default case. A lobby rebuilding its
+// index after a failover may ask at any phase, so RefreshRoomStatus is
+// answered here rather than in each behavior.
+func (r *RoomActor) unhandled(ctx *actor.ReceiveContext) {
+	switch ctx.Message().(type) {
+	case *RefreshRoomStatus:
+		status := r.reported
+		if status.Code == "" {
+			status = r.status(PhaseWaiting)
+		}
+		r.sendStatus(ctx, status)
+
+	case *actor.SubscribeAck:
+
+	default:
+		ctx.Unhandled()
+	}
+}
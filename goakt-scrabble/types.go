@@ -22,7 +22,11 @@
 
 package main
 
-import "time"
+import (
+	"time"
+
+	"github.com/tochemey/goakt-examples/v2/goakt-scrabble/scrabble"
+)
 
 const (
 	MinPlayers     = 2
@@ -31,15 +35,26 @@ const (
 	GameOverSecs   = 30
 	BotMoveDelayMs = 700
 
-	turnDuration = TurnSeconds * time.Second
+	// BotThinkMs is the part of an expert bot's move delay it spends
+	// searching (simulation or endgame solving) rather than waiting.
+	BotThinkMs = 500
+
+	// ChallengeWindowSecs is how long opponents have to challenge a play
+	// under the double-challenge rule before it stands.
+	ChallengeWindowSecs = 15
 
-	LobbyActorName       = "lobby"
-	RoomActorPrefix      = "room."
-	SessionActorPrefix   = "session."
-	BotActorPrefix       = "bot."
-	RoomTopicPrefix      = "room."
-	GrainPrefix          = "scrabble.profile."
-	LeaderboardKeyPrefix = "scrabble.wins."
+	turnDuration    = TurnSeconds * time.Second
+	challengeWindow = ChallengeWindowSecs * time.Second
+
+	LobbyActorName     = "lobby"
+	RoomActorPrefix    = "room."
+	SessionActorPrefix = "session."
+	BotActorPrefix     = "bot."
+	LexiconActorPrefix = "lexicon."
+	RoomTopicPrefix    = "room."
+	LexiconTopic       = "scrabble.lexicons"
+	LobbyTopic         = "scrabble.lobby"
+	GrainPrefix        = "scrabble.profile."
 )
 
 const (
@@ -49,31 +64,60 @@ const (
 	PhaseGameOver = "gameOver"
 )
 
+// Challenge rules. Under ChallengeVoid a play forming any word outside
+// the dictionary is rejected outright; under ChallengeDouble it is
+// accepted provisionally and opponents may challenge it. A successful
+// challenge withdraws the play; a failed one costs the challenger a turn.
+const (
+	ChallengeVoid   = "void"
+	ChallengeDouble = "double"
+)
+
+// Word variants. Under VariantClabbers a word stands if any anagram of
+// it is in the dictionary.
+const (
+	VariantClassic  = "classic"
+	VariantClabbers = "clabbers"
+)
+
 const (
-	InTypeStart     = "start"
-	InTypeAddBot    = "addBot"
-	InTypeRemoveBot = "removeBot"
-	InTypePlace     = "place"
-	InTypeExchange  = "exchange"
-	InTypePass      = "pass"
-	InTypePause     = "pause"
-	InTypeResume    = "resume"
-	InTypeChat      = "chat"
-	InTypePlayAgain = "playAgain"
+	InTypeStart      = "start"
+	InTypeAddBot     = "addBot"
+	InTypeRemoveBot  = "removeBot"
+	InTypeSetRule    = "setRule"
+	InTypeSetCasual  = "setCasual"
+	InTypeSetClock   = "setClock"
+	InTypeSetVariant = "setVariant"
+	InTypeSetPublic  = "setPublic"
+	InTypePlace      = "place"
+	InTypeExchange   = "exchange"
+	InTypePass       = "pass"
+	InTypeChallenge  = "challenge"
+	InTypeAccept     = "accept"
+	InTypePause      = "pause"
+	InTypeResume     = "resume"
+	InTypeChat       = "chat"
+	InTypePlayAgain  = "playAgain"
+	InTypeHint       = "hint"
+	InTypeLookup     = "lookup"
 )
 
 const (
-	OutTypeJoined   = "joined"
-	OutTypeState    = "state"
-	OutTypeMove     = "move"
-	OutTypeChat     = "chat"
-	OutTypeError    = "error"
-	OutTypeGameOver = "gameOver"
+	OutTypeJoined    = "joined"
+	OutTypeState     = "state"
+	OutTypeMove      = "move"
+	OutTypeChallenge = "challenge"
+	OutTypeChat      = "chat"
+	OutTypeError     = "error"
+	OutTypeGameOver  = "gameOver"
+	OutTypeHint      = "hint"
+	OutTypeLookup    = "lookup"
+	OutTypeAnalysis  = "analysis"
 )
 
 // PlacementWire is one tile placement from the browser. Letter is the
-// uppercase rune the player chose for this tile; Blank true means the
-// rack tile is a blank assigned to that letter.
+// uppercase tile face the player chose ("A", or a digraph such as "LL");
+// Blank true means the rack tile is a blank assigned to that letter.
 type PlacementWire struct {
 	Row    int    `json:"row"`
 	Col    int    `json:"col"`
@@ -82,24 +126,41 @@ type PlacementWire struct {
 }
 
 // WSIn is the single inbound envelope. Type discriminates which fields
-// are populated.
+// are populated. Kind and Query carry a word-tool lookup, which the
+// session answers itself. Clock is a time control such as "25+0", or
+// empty for an untimed game. Board and Variant pick the board layout and
+// the word variant.
 type WSIn struct {
 	Type       string          `json:"type"`
 	Placements []PlacementWire `json:"placements,omitempty"`
 	Indices    []int           `json:"indices,omitempty"`
 	Text       string          `json:"text,omitempty"`
 	Seat       int             `json:"seat,omitempty"`
+	Rule       string          `json:"rule,omitempty"`
+	Level      string          `json:"level,omitempty"`
+	Casual     bool            `json:"casual,omitempty"`
+	Public     bool            `json:"public,omitempty"`
+	Kind       string          `json:"kind,omitempty"`
+	Query      string          `json:"query,omitempty"`
+	Clock      string          `json:"clock,omitempty"`
+	Board      string          `json:"board,omitempty"`
+	Variant    string          `json:"variant,omitempty"`
 }
 
 // PlayerView is one entry in the public player list. RackSize is the
 // number of tiles the player still holds; the rack contents themselves
-// are sent only to that player via StateEvent.YourRack.
+// are sent only to that player via StateEvent.YourRack. Level is the
+// bot's difficulty and empty for humans. ClockMs is what is left of the
+// player's time bank in a timed game, negative in overtime; the current
+// player's is taken when the event is built.
 type PlayerView struct {
 	ID       string `json:"id"`
 	Name     string `json:"name"`
 	Score    int    `json:"score"`
 	RackSize int    `json:"rackSize"`
 	Bot      bool   `json:"bot"`
+	Level    string `json:"level,omitempty"`
+	ClockMs  int    `json:"clockMs"`
 }
 
 // ScoreEntry is one row of a final scoreboard.
@@ -109,20 +170,34 @@ type ScoreEntry struct {
 	Score    int    `json:"score"`
 }
 
-// LeaderboardEntry is one row of the cluster-wide CRDT leaderboard.
+// LeaderboardEntry is one row of a language's rating leaderboard.
+// Rating and Deviation are the player's Glicko-2 rating and its
+// deviation, rounded; Games counts their rated games.
 type LeaderboardEntry struct {
-	PlayerID string `json:"playerID"`
-	Name     string `json:"name"`
-	Wins     int64  `json:"wins"`
+	PlayerID  string `json:"playerID"`
+	Name      string `json:"name"`
+	Rating    int    `json:"rating"`
+	Deviation int    `json:"deviation"`
+	Games     int    `json:"games"`
 }
 
-// ProfileView is the persistent slice of a player's stats.
+// ProfileView is the persistent slice of a player's stats. Ratings
+// holds the rounded rating for each language the player is rated in.
 type ProfileView struct {
-	PlayerID    string `json:"playerID"`
-	Name        string `json:"name"`
-	GamesPlayed int    `json:"gamesPlayed"`
-	Wins        int    `json:"wins"`
-	TotalScore  int    `json:"totalScore"`
+	PlayerID    string         `json:"playerID"`
+	Name        string         `json:"name"`
+	GamesPlayed int            `json:"gamesPlayed"`
+	Wins        int            `json:"wins"`
+	TotalScore  int            `json:"totalScore"`
+	Ratings     map[string]int `json:"ratings,omitempty"`
+}
+
+// LetterWire describes one tile of the room's language. The unassigned
+// blank is listed last with Letter "?" and zero points.
+type LetterWire struct {
+	Letter string `json:"letter"`
+	Points int    `json:"points"`
+	Count  int    `json:"count"`
 }
 
 // FormedWordWire is one word formed by a move.
@@ -131,40 +206,92 @@ type FormedWordWire struct {
 	Score int    `json:"score"`
 }
 
+// JoinedEvent welcomes a session to its room. Layouts names the board
+// layouts the owner may choose between.
 type JoinedEvent struct {
 	For         string             `json:"-"`
 	Room        string             `json:"room"`
 	Language    string             `json:"language"`
 	PlayerID    string             `json:"playerID"`
 	Owner       bool               `json:"owner"`
+	Spectator   bool               `json:"spectator"`
 	Profile     ProfileView        `json:"profile"`
 	Leaderboard []LeaderboardEntry `json:"leaderboard"`
+	Alphabet    []LetterWire       `json:"alphabet"`
+	Layouts     []string           `json:"layouts"`
 }
 
 // StateEvent is the per-player full snapshot. Rack content is in the
 // PerRack map keyed by playerID; the session forwards only the entry
-// matching its own playerID.
+// matching its own playerID, and spectators get none. Casual rooms offer
+// hints and do not count towards profiles or the leaderboard.
+// TimeControl is empty for an untimed game, whose turns are limited by
+// TimerMs instead of the players' clocks. Layout names the board layout
+// and Premiums spells it out, one string per row in the grid notation
+// of scrabble.ParseLayout; BagScale is how many tile sets fill its bag.
+// Lexicon names the wordlist version the room plays with; a game keeps
+// its lexicon even if the language's is swapped while it runs.
 type StateEvent struct {
-	For          string              `json:"-"`
-	Phase        string              `json:"phase"`
-	Board        [][]string          `json:"board"`
-	Players      []PlayerView        `json:"players"`
-	CurrentID    string              `json:"currentID"`
-	OwnerID      string              `json:"ownerID"`
-	BagRemaining int                 `json:"bagRemaining"`
-	TimerMs      int                 `json:"timerMs"`
-	PerRack      map[string][]string `json:"-"`
+	For           string              `json:"-"`
+	Phase         string              `json:"phase"`
+	Board         [][]string          `json:"board"`
+	Players       []PlayerView        `json:"players"`
+	CurrentID     string              `json:"currentID"`
+	OwnerID       string              `json:"ownerID"`
+	BagRemaining  int                 `json:"bagRemaining"`
+	TimerMs       int                 `json:"timerMs"`
+	ChallengeRule string              `json:"challengeRule"`
+	ChallengeMs   int                 `json:"challengeMs"`
+	Casual        bool                `json:"casual"`
+	Public        bool                `json:"public"`
+	Spectators    int                 `json:"spectators"`
+	TimeControl   string              `json:"timeControl"`
+	Layout        string              `json:"layout"`
+	Premiums      []string            `json:"premiums"`
+	BagScale      int                 `json:"bagScale"`
+	Variant       string              `json:"variant"`
+	Lexicon       string              `json:"lexicon"`
+	PerRack       map[string][]string `json:"-"`
 }
 
+// MoveEvent announces a play. Provisional is true under the
+// double-challenge rule until the challenge window closes; a
+// ChallengeEvent follows if anyone challenges it.
 type MoveEvent struct {
+	For         string           `json:"-"`
+	PlayerID    string           `json:"playerID"`
+	Name        string           `json:"name"`
+	Placements  []PlacementWire  `json:"placements"`
+	Words       []FormedWordWire `json:"words"`
+	Score       int              `json:"score"`
+	NewTotal    int              `json:"newTotal"`
+	Bingo       bool             `json:"bingo"`
+	Provisional bool             `json:"provisional"`
+}
+
+// ChallengeEvent is the ruling on a challenged play. Phonies lists the
+// words struck down; when it is non-empty the play was withdrawn and
+// Score points were taken back, otherwise the play stands and the
+// challenger loses their next turn.
+type ChallengeEvent struct {
+	For            string   `json:"-"`
+	ChallengerID   string   `json:"challengerID"`
+	ChallengerName string   `json:"challengerName"`
+	PlayerID       string   `json:"playerID"`
+	Name           string   `json:"name"`
+	Phonies        []string `json:"phonies"`
+	Withdrawn      bool     `json:"withdrawn"`
+	Score          int      `json:"score"`
+	NewTotal       int      `json:"newTotal"`
+}
+
+// HintEvent is the best-scoring play for the recipient's rack, sent on
+// request in casual rooms.
+type HintEvent struct {
 	For        string           `json:"-"`
-	PlayerID   string           `json:"playerID"`
-	Name       string           `json:"name"`
 	Placements []PlacementWire  `json:"placements"`
 	Words      []FormedWordWire `json:"words"`
 	Score      int              `json:"score"`
-	NewTotal   int              `json:"newTotal"`
-	Bingo      bool             `json:"bingo"`
 }
 
 type ChatEvent struct {
@@ -178,20 +305,38 @@ type ErrorEvent struct {
 	Message string `json:"message"`
 }
 
+// GameOverEvent announces the final scores. Record carries the game's
+// GCG text so each session can archive it on its own node; the browser
+// downloads it from /games/<room>/<gameNumber>.
 type GameOverEvent struct {
 	For         string             `json:"-"`
 	WinnerID    string             `json:"winnerID"`
 	WinnerName  string             `json:"winnerName"`
 	Scores      []ScoreEntry       `json:"scores"`
 	Leaderboard []LeaderboardEntry `json:"leaderboard"`
+	GameNumber  int                `json:"gameNumber"`
+	Record      string             `json:"-"`
 }
 
 // JoinOrCreate is the gateway's Ask to the LobbyActor singleton.
+// Watch asks to spectate an existing room; the lobby never creates one
+// for it. QuickPlay (with no Room) asks for the fullest public room in
+// Language that still has a free seat, and creates a public one if
+// there is none. Public lists a newly created room in the room browser.
+//
+// Saved reports that the roomStore holds a snapshot for Room, and
+// SavedLanguage the language of its game. The gateway looks them up
+// before asking, so the singleton lobby never waits on the store.
 type JoinOrCreate struct {
-	Room       string
-	Language   string
-	PlayerID   string
-	PlayerName string
+	Room          string
+	Language      string
+	PlayerID      string
+	PlayerName    string
+	Watch         bool
+	Public        bool
+	QuickPlay     bool
+	Saved         bool
+	SavedLanguage string
 }
 
 type JoinOrCreateResult struct {
@@ -200,17 +345,85 @@ type JoinOrCreateResult struct {
 	Err      string
 }
 
+// ConfigureRoom is the lobby's Tell to a room it has just created. A
+// room restored from a snapshot keeps its own settings and ignores it.
+type ConfigureRoom struct {
+	Public bool
+}
+
+// RoomStatus is a room's report to the LobbyActor: sent whenever its
+// phase, seats, spectators or settings change, again on
+// RefreshRoomStatus, and with Stopped set from PostStop. The lobby's
+// room index is built from these reports alone, which is what lets a
+// new lobby rebuild it after a singleton failover.
+type RoomStatus struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Phase       string `json:"phase"`
+	Players     int    `json:"players"`
+	MaxPlayers  int    `json:"maxPlayers"`
+	Spectators  int    `json:"spectators"`
+	Language    string `json:"language"`
+	Variant     string `json:"variant"`
+	Layout      string `json:"layout"`
+	TimeControl string `json:"timeControl"`
+	Casual      bool   `json:"casual"`
+	Public      bool   `json:"public"`
+	Joinable    bool   `json:"joinable"`
+	Stopped     bool   `json:"stopped"`
+}
+
+// RefreshRoomStatus is published on LobbyTopic by a starting lobby;
+// every room answers with its RoomStatus.
+type RefreshRoomStatus struct{}
+
+// ListRooms asks the lobby for its index of public rooms, optionally
+// narrowed to one language. The reply is a RoomList.
+type ListRooms struct {
+	Language string
+}
+
+type RoomList struct {
+	Rooms []RoomStatus
+}
+
 // PlayerHello is the session's first message to the RoomActor.
+// Spectator asks to watch without taking a seat.
 type PlayerHello struct {
 	PlayerID    string
 	Name        string
 	SessionName string
+	Spectator   bool
 }
 
 type GoodbyePlayer struct {
 	SessionName string
 }
 
+// TurnAnalysisWire is one turn of the post-game analysis: the play
+// made, the best play the move generator found for the same rack, and
+// the points left on the table. Played is empty for an exchange or a
+// pass, Best is empty when the rack had no legal play.
+type TurnAnalysisWire struct {
+	Turn      int    `json:"turn"`
+	PlayerID  string `json:"playerID"`
+	Name      string `json:"name"`
+	Played    string `json:"played"`
+	Score     int    `json:"score"`
+	Best      string `json:"best"`
+	BestScore int    `json:"bestScore"`
+	Lost      int    `json:"lost"`
+}
+
+// AnalysisEvent follows GameOverEvent once the finished game has been
+// analysed. Lost totals each player's points left on the table.
+type AnalysisEvent struct {
+	For        string             `json:"-"`
+	GameNumber int                `json:"gameNumber"`
+	Turns      []TurnAnalysisWire `json:"turns"`
+	Lost       []ScoreEntry       `json:"lost"`
+}
+
 type PlayerInput struct {
 	PlayerID string
 	In       WSIn
@@ -224,15 +437,32 @@ type BotPlay struct {
 }
 
 // YourTurn is the room → bot tell with the current board/rack snapshot
-// the bot should base its move on.
+// the bot should base its move on, and the seat's difficulty level.
+// ThinkMs is the search budget for an expert; BagRemaining and Opponents
+// tell it whether the endgame can be solved exactly. Lexicon is the
+// game's wordlist, which may be older than the registry's current one.
 type YourTurn struct {
-	BotID string
-	Board [][]string
-	Rack  []string
+	BotID        string
+	Level        string
+	Layout       string
+	Lexicon      string
+	Board        [][]string
+	Rack         []string
+	BagRemaining int
+	Opponents    int
+	ThinkMs      int
+}
+
+// ReloadLexicon is published on LexiconTopic after an admin swaps a
+// language's lexicon, so every node's LexiconActor loads it too.
+type ReloadLexicon struct {
+	Language string
+	Lexicon  string
 }
 
 // Room-internal scheduled messages.
 type turnTimeout struct{}
+type challengeTimeout struct{}
 type shutdownRoom struct{}
 
 // Profile-grain wire payloads.
@@ -241,3 +471,31 @@ type RecordGame struct {
 	WonThisGame bool
 	GameScore   int
 }
+
+// PlayerRating is a player's rating in one language and the number of
+// rated games behind it.
+type PlayerRating struct {
+	Rating scrabble.Rating
+	Games  int
+}
+
+// GetRating asks a profile grain for its rating in Language. The grain
+// replies with a *RatingReply, a new player's rating if it has none.
+type GetRating struct {
+	Language string
+}
+
+// RateGame applies one finished game to a profile grain's rating in
+// Language. Opponents carry their ratings from before the game; the
+// grain updates its own current rating, so games finishing at once on
+// different nodes are applied one after the other. The grain replies
+// with a *RatingReply.
+type RateGame struct {
+	Language  string
+	Opponents []scrabble.Opponent
+}
+
+type RatingReply struct {
+	Name   string
+	Rating PlayerRating
+}
@@ -1,19 +1,27 @@
 // goakt-scrabble browser client
 // Vanilla TypeScript. All wire shapes match goakt-scrabble/types.go.
 
-interface PlayerView { id: string; name: string; score: number; rackSize: number; bot: boolean; }
+interface PlayerView { id: string; name: string; score: number; rackSize: number; bot: boolean; level?: string; clockMs: number; }
 interface FormedWord { word: string; score: number; }
 interface PlacementWire { row: number; col: number; letter: string; blank?: boolean; }
 interface ScoreEntry { playerID: string; name: string; score: number; }
-interface LeaderboardEntry { playerID: string; name: string; wins: number; }
+interface LeaderboardEntry { playerID: string; name: string; rating: number; deviation: number; games: number; }
+interface LetterInfo { letter: string; points: number; count: number; }
 
-interface JoinedMsg { type: "joined"; room: string; language: string; playerID: string; owner: boolean; profile: any; leaderboard: LeaderboardEntry[] | null; }
-interface StateMsg { type: "state"; phase: string; board: string[][]; yourRack: string[]; players: PlayerView[]; currentID: string; ownerID: string; bagRemaining: number; timerMs: number; }
-interface MoveMsg { type: "move"; playerID: string; name: string; placements: PlacementWire[]; words: FormedWord[]; score: number; newTotal: number; bingo: boolean; }
+interface JoinedMsg { type: "joined"; room: string; language: string; playerID: string; owner: boolean; spectator: boolean; profile: any; leaderboard: LeaderboardEntry[] | null; alphabet: LetterInfo[] | null; layouts: string[] | null; }
+interface StateMsg { type: "state"; phase: string; board: string[][]; yourRack: string[]; players: PlayerView[]; currentID: string; ownerID: string; bagRemaining: number; timerMs: number; challengeRule: string; challengeMs: number; casual: boolean; public: boolean; spectators: number; timeControl: string; layout: string; premiums: string[] | null; bagScale: number; variant: string; lexicon: string; }
+interface MoveMsg { type: "move"; playerID: string; name: string; placements: PlacementWire[]; words: FormedWord[]; score: number; newTotal: number; bingo: boolean; provisional: boolean; }
+interface ChallengeMsg { type: "challenge"; challengerID: string; challengerName: string; playerID: string; name: string; phonies: string[] | null; withdrawn: boolean; score: number; newTotal: number; }
 interface ChatMsg { type: "chat"; from: string; text: string; }
 interface ErrorMsg { type: "error"; message: string; }
-interface GameOverMsg { type: "gameOver"; winnerID: string; winnerName: string; scores: ScoreEntry[]; leaderboard: LeaderboardEntry[] | null; }
-type Msg = JoinedMsg | StateMsg | MoveMsg | ChatMsg | ErrorMsg | GameOverMsg;
+interface GameOverMsg { type: "gameOver"; winnerID: string; winnerName: string; scores: ScoreEntry[]; leaderboard: LeaderboardEntry[] | null; gameNumber: number; }
+interface HintMsg { type: "hint"; placements: PlacementWire[]; words: FormedWord[]; score: number; }
+interface TurnAnalysis { turn: number; playerID: string; name: string; played: string; score: number; best: string; bestScore: number; lost: number; }
+interface AnalysisMsg { type: "analysis"; gameNumber: number; turns: TurnAnalysis[] | null; lost: ScoreEntry[] | null; }
+interface RoomStatus { code: string; phase: string; players: number; maxPlayers: number; spectators: number; language: string; variant: string; layout: string; timeControl: string; casual: boolean; joinable: boolean; }
+interface JoinChoice { name: string; room: string; lang: string; watch: boolean; quick: boolean; public: boolean; }
+interface LookupMsg { type: "lookup"; kind: string; query: string; valid: boolean; words: string[] | null; front: string[] | null; back: string[] | null; truncated: boolean; }
+type Msg = JoinedMsg | StateMsg | MoveMsg | ChallengeMsg | ChatMsg | ErrorMsg | GameOverMsg | HintMsg | LookupMsg | AnalysisMsg;
 
 interface Pending { rackIdx: number; row: number; col: number; letter: string; blank: boolean; }
 
@@ -39,15 +47,33 @@ interface Drag {
   rackHint: boolean;
 }
 
-const BOARD_SIZE = 15;
-const CENTER = 7;
 const LAST_MOVE_HIGHLIGHT_MS = 6000;
 const DRAG_THRESHOLD = 6; // px of pointer travel before a press becomes a drag
 
 // Internal SVG units — actual rendered size is controlled by CSS.
 const CELL_UNIT = 40;
-const BOARD_UNIT = CELL_UNIT * BOARD_SIZE;
 
+// The standard 15x15 board in the server's layout grid notation, used
+// until the room's first "state" message names its own.
+const STANDARD_PREMIUMS = [
+  "T..d...T...d..T",
+  ".D...t...t...D.",
+  "..D...d.d...D..",
+  "d..D...d...D..d",
+  "....D.....D....",
+  ".t...t...t...t.",
+  "..d...d.d...d..",
+  "T..d...*...d..T",
+  "..d...d.d...d..",
+  ".t...t...t...t.",
+  "....D.....D....",
+  "d..D...d...D..d",
+  "..D...d.d...D..",
+  ".D...t...t...D.",
+  "T..d...T...d..T",
+];
+
+// English defaults until the room's "joined" message delivers its alphabet.
 const POINT_VALUES_EN: Record<string, number> = {
   A: 1, B: 3, C: 3, D: 2, E: 1, F: 4, G: 2, H: 4, I: 1, J: 8, K: 5, L: 1, M: 3,
   N: 1, O: 1, P: 3, Q: 10, R: 1, S: 1, T: 1, U: 1, V: 4, W: 4, X: 8, Y: 4, Z: 10,
@@ -59,12 +85,35 @@ const DISTRIBUTION_EN: Record<string, number> = {
   "?": 2,
 };
 
+const LANGUAGES: [string, string][] = [
+  ["en", "English"], ["fr", "Français"], ["es", "Español"], ["de", "Deutsch"], ["nl", "Nederlands"],
+];
+
+let pointValues: Record<string, number> = { ...POINT_VALUES_EN };
+let distribution: Record<string, number> = { ...DISTRIBUTION_EN };
+let alphabet: string[] = Object.keys(POINT_VALUES_EN);
+
+function applyAlphabet(letters: LetterInfo[]) {
+  pointValues = {};
+  distribution = {};
+  alphabet = [];
+  for (const l of letters) {
+    distribution[l.letter] = l.count;
+    if (l.letter === "?") continue;
+    pointValues[l.letter] = l.points;
+    alphabet.push(l.letter);
+  }
+}
+
 const state = {
   ws: null as WebSocket | null,
   playerID: "",
   name: "",
   language: "en",
   owner: false,
+  watch: false,
+  spectator: false,
+  spectators: 0,
   roomCode: "",
   phase: "waiting",
   board: [] as string[][],
@@ -78,8 +127,32 @@ const state = {
   exchangeSelection: new Set<number>(),
   selectedRackIdx: -1,
   turnDeadlineMs: 0,
+  challengeRule: "void",
+  casual: false,
+  // public lists the room in the lobby's room browser. Before the first
+  // state it is the join form's choice, sent when creating a room; quick
+  // asks the lobby for any open public room instead.
+  public: false,
+  quick: false,
+  // timeControl is "" for untimed games; clocks are the players' as of
+  // stateAtMs, and the current player's keeps running from there.
+  timeControl: "",
+  stateAtMs: 0,
+  // layout names the board; premiums spells it out row by row and
+  // bagScale is how many tile sets its bag holds.
+  layout: "standard",
+  layouts: ["standard"] as string[],
+  premiums: STANDARD_PREMIUMS,
+  bagScale: 1,
+  variant: "classic",
+  lexicon: "",
+  botLevel: "expert",
+  challengeDeadlineMs: 0,
   gameOverShown: false,
+  gameNumber: 0,
+  reconnects: 0,
   leaderboard: [] as LeaderboardEntry[],
+  analysis: null as AnalysisMsg | null,
   log: [] as LogEntry[],
   lastMove: null as { placements: PlacementWire[]; expiresAt: number } | null,
 };
@@ -89,20 +162,21 @@ let suppressClickUntil = 0;
 
 // ------------------------------------------------------------ premium squares
 
-type Premium = "" | "DL" | "TL" | "DW" | "TW" | "C";
+type Premium = "" | "DL" | "TL" | "QL" | "DW" | "TW" | "QW" | "C";
+
+function boardSize(): number {
+  return state.premiums.length;
+}
 
 function premiumAt(row: number, col: number): Premium {
-  if (row === CENTER && col === CENTER) return "C";
-  let r = row, c = col;
-  if (r > 7) r = 14 - r;
-  if (c > 7) c = 14 - c;
-  if (r > c) [r, c] = [c, r];
-  const key = `${r},${c}`;
-  switch (key) {
-    case "0,0": case "0,7": return "TW";
-    case "1,1": case "2,2": case "3,3": case "4,4": case "7,7": return "DW";
-    case "1,5": case "5,5": return "TL";
-    case "0,3": case "2,6": case "3,7": case "6,6": return "DL";
+  switch (state.premiums[row]?.[col]) {
+    case "d": return "DL";
+    case "t": return "TL";
+    case "q": return "QL";
+    case "D": return "DW";
+    case "T": return "TW";
+    case "Q": return "QW";
+    case "*": case "+": return "C";
   }
   return "";
 }
@@ -111,8 +185,10 @@ function premiumColor(p: Premium): string {
   switch (p) {
     case "DL": return "var(--dl)";
     case "TL": return "var(--tl)";
+    case "QL": return "var(--ql)";
     case "DW": return "var(--dw)";
     case "TW": return "var(--tw)";
+    case "QW": return "var(--qw)";
     case "C":  return "var(--center)";
     default:   return "var(--cell)";
   }
@@ -122,8 +198,10 @@ function premiumLabel(p: Premium): string {
   switch (p) {
     case "DL": return "DL";
     case "TL": return "TL";
+    case "QL": return "QL";
     case "DW": return "DW";
     case "TW": return "TW";
+    case "QW": return "QW";
     case "C":  return "★";
     default:   return "";
   }
@@ -155,7 +233,7 @@ function svg(tag: string, attrs: Record<string, string | number> = {}, ...childr
 
 function tilePoints(letter: string, blank: boolean): number {
   if (blank) return 0;
-  return POINT_VALUES_EN[letter.toUpperCase()] ?? 0;
+  return pointValues[letter.toUpperCase()] ?? 0;
 }
 
 function parseTileWire(raw: string): { letter: string; blank: boolean; empty: boolean } {
@@ -171,10 +249,12 @@ function parseTileWire(raw: string): { letter: string; blank: boolean; empty: bo
 // Everything else (bag + opponents' racks) is unknown — and that's exactly
 // what we surface, since that's what real Scrabble players track.
 function unseenBreakdown(): Record<string, number> {
-  const counts: Record<string, number> = { ...DISTRIBUTION_EN };
+  const counts: Record<string, number> = {};
+  for (const [letter, n] of Object.entries(distribution)) counts[letter] = n * state.bagScale;
 
-  for (let r = 0; r < BOARD_SIZE; r++) {
-    for (let c = 0; c < BOARD_SIZE; c++) {
+  const size = boardSize();
+  for (let r = 0; r < size; r++) {
+    for (let c = 0; c < size; c++) {
       const raw = state.board[r]?.[c];
       if (!raw) continue;
       const t = parseTileWire(raw);
@@ -208,7 +288,10 @@ function render() {
 function renderHeader() {
   $("roomCode").textContent = state.roomCode || "—";
   $("langCode").textContent = state.language || "en";
+  $("langCode").title = state.lexicon ? `Lexicon ${state.lexicon}` : "";
   $("bagBadge").textContent = String(state.bagRemaining);
+  $("watchersPill").hidden = state.spectators === 0;
+  $("watchers").textContent = String(state.spectators);
 }
 
 function renderPlay() {
@@ -220,7 +303,11 @@ function renderPlay() {
     return;
   }
 
-  play.append(renderBoardFrame(), renderRack(), renderControls());
+  if (state.spectator) {
+    play.append(renderBoardFrame(), el("p", { class: "spectator-note" }, "You are watching this game."));
+  } else {
+    play.append(renderBoardFrame(), renderRack(), renderControls());
+  }
 
   if (state.phase === "gameOver" && !state.gameOverShown) {
     state.gameOverShown = true;
@@ -231,7 +318,9 @@ function renderPlay() {
 function renderLobbyCard(): HTMLElement {
   const card = el("div", { class: "lobby-card" });
   card.append(el("h2", {}, "Room " + state.roomCode));
-  if (state.owner) {
+  if (state.spectator) {
+    card.append(el("p", {}, "You are watching. The game appears here once the host starts it."));
+  } else if (state.owner) {
     card.append(el("p", {}, `You're the host. ${state.players.length < 2 ? "Add a bot or invite a friend (share the room code) to start." : "Add more bots or press Start to begin."}`));
   } else {
     card.append(el("p", {}, "Waiting for the host to start the game…"));
@@ -246,7 +335,9 @@ function renderBoardFrame(): HTMLElement {
 }
 
 function renderBoard(): SVGElement {
-  const root = svg("svg", { viewBox: `0 0 ${BOARD_UNIT} ${BOARD_UNIT}`, preserveAspectRatio: "xMidYMid meet" });
+  const size = boardSize();
+  const unit = CELL_UNIT * size;
+  const root = svg("svg", { viewBox: `0 0 ${unit} ${unit}`, preserveAspectRatio: "xMidYMid meet" });
 
   type Cell = { letter: string; blank: boolean; pending: boolean; recent: boolean };
   const view: Cell[][] = [];
@@ -255,9 +346,9 @@ function renderBoard(): SVGElement {
     for (const p of state.lastMove.placements) recent.add(`${p.row},${p.col}`);
   }
 
-  for (let r = 0; r < BOARD_SIZE; r++) {
+  for (let r = 0; r < size; r++) {
     view[r] = [];
-    for (let c = 0; c < BOARD_SIZE; c++) {
+    for (let c = 0; c < size; c++) {
       const raw = state.board[r]?.[c] ?? "";
       const parsed = parseTileWire(raw);
       view[r][c] = {
@@ -272,8 +363,8 @@ function renderBoard(): SVGElement {
     view[p.row][p.col] = { letter: p.letter, blank: p.blank, pending: true, recent: false };
   }
 
-  for (let r = 0; r < BOARD_SIZE; r++) {
-    for (let c = 0; c < BOARD_SIZE; c++) {
+  for (let r = 0; r < size; r++) {
+    for (let c = 0; c < size; c++) {
       const x = c * CELL_UNIT;
       const y = r * CELL_UNIT;
       const cell = view[r][c];
@@ -426,6 +517,21 @@ function renderControls(): HTMLElement {
     return wrap;
   }
 
+  if (state.challengeDeadlineMs > 0) {
+    const mover = state.players.find(p => p.id === state.currentID);
+    if (state.currentID === state.playerID) {
+      wrap.append(el("span", { class: "waiting-badge" }, "Waiting for challenges…"));
+      return wrap;
+    }
+    wrap.append(el("span", { class: "turn-badge" }, `Challenge ${mover ? mover.name : ""}'s play?`));
+    const challenge = el("button", { class: "primary" }, "Challenge");
+    challenge.addEventListener("click", () => send({ type: "challenge" }));
+    const accept = el("button", {}, "Accept");
+    accept.addEventListener("click", () => send({ type: "accept" }));
+    wrap.append(challenge, accept);
+    return wrap;
+  }
+
   if (myTurn) {
     wrap.append(el("span", { class: "turn-badge" }, "YOUR TURN"));
   } else if (state.phase === "playing") {
@@ -468,6 +574,13 @@ function renderControls(): HTMLElement {
   pause.addEventListener("click", () => send({ type: "pause" }));
 
   wrap.append(submit, recall, shuffle, exchange, pass, pause);
+
+  if (state.casual) {
+    const hint = el("button", { title: "Show the best-scoring play (casual rooms only)" }, "💡 Hint");
+    if (!myTurn) hint.setAttribute("disabled", "");
+    hint.addEventListener("click", () => send({ type: "hint" }));
+    wrap.append(hint);
+  }
   return wrap;
 }
 
@@ -478,10 +591,13 @@ function renderPlayers() {
     const p = state.players[i];
     const li = el("li", { class: p.id === state.currentID ? "current" : "" });
     li.append(el("span", { class: "name" }, p.name));
-    if (p.bot) li.append(el("span", { class: "bot-tag" }, "BOT"));
+    if (p.bot) li.append(el("span", { class: "bot-tag", title: p.level ?? "" }, p.level ? `BOT · ${p.level}` : "BOT"));
     if (state.phase === "playing" || state.phase === "gameOver") {
       li.append(el("span", { class: "rack-count" }, `· ${p.rackSize} tiles`));
     }
+    if (state.timeControl && state.phase !== "waiting") {
+      li.append(el("span", { class: "clock", "data-id": p.id }, formatClock(liveClockMs(p))));
+    }
     li.append(el("span", { class: "score" }, String(p.score)));
     if (state.phase === "waiting" && state.owner && p.bot) {
       const seat = i;
@@ -497,13 +613,52 @@ function renderLobbyActions() {
   const wrap = $("lobbyActions");
   wrap.innerHTML = "";
   if (state.phase !== "waiting" || !state.owner) return;
+  const rule = el("select", { title: "Challenge rule" }) as HTMLSelectElement;
+  rule.append(el("option", { value: "void" }, "Void (invalid words rejected)"));
+  rule.append(el("option", { value: "double" }, "Double challenge"));
+  rule.value = state.challengeRule;
+  rule.addEventListener("change", () => send({ type: "setRule", rule: rule.value }));
+  const casual = el("label", { title: "Casual rooms allow hints and are not ranked" });
+  const casualBox = el("input", { type: "checkbox" }) as HTMLInputElement;
+  casualBox.checked = state.casual;
+  casualBox.addEventListener("change", () => send({ type: "setCasual", casual: casualBox.checked }));
+  casual.append(casualBox, " Casual");
+  const listed = el("label", { title: "Public rooms are listed in the room browser and filled by quick play" });
+  const listedBox = el("input", { type: "checkbox" }) as HTMLInputElement;
+  listedBox.checked = state.public;
+  listedBox.addEventListener("change", () => send({ type: "setPublic", public: listedBox.checked }));
+  listed.append(listedBox, " Public");
+  const clock = el("select", { title: "Time control: minutes per player + seconds added per move" }) as HTMLSelectElement;
+  for (const [value, label] of [["", "Untimed"], ["25+0", "25 min"], ["15+10", "15 min + 10s"], ["10+5", "10 min + 5s"], ["5+3", "5 min + 3s"]]) {
+    clock.append(el("option", { value }, label));
+  }
+  clock.value = state.timeControl;
+  clock.addEventListener("change", () => send({ type: "setClock", clock: clock.value }));
+  const board = el("select", { title: "Board layout" }) as HTMLSelectElement;
+  for (const name of state.layouts) {
+    board.append(el("option", { value: name }, name[0].toUpperCase() + name.slice(1)));
+  }
+  board.value = state.layout;
+  const variant = el("select", { title: "Clabbers accepts any anagram of a valid word" }) as HTMLSelectElement;
+  variant.append(el("option", { value: "classic" }, "Classic"));
+  variant.append(el("option", { value: "clabbers" }, "Clabbers"));
+  variant.value = state.variant;
+  const setVariant = () => send({ type: "setVariant", board: board.value, variant: variant.value });
+  board.addEventListener("change", setVariant);
+  variant.addEventListener("change", setVariant);
+  const level = el("select", { title: "Bot level" }) as HTMLSelectElement;
+  for (const name of ["beginner", "intermediate", "expert"]) {
+    level.append(el("option", { value: name }, name[0].toUpperCase() + name.slice(1)));
+  }
+  level.value = state.botLevel;
+  level.addEventListener("change", () => { state.botLevel = level.value; });
   const addBot = el("button", {}, "+ Add Bot");
   if (state.players.length >= 4) addBot.setAttribute("disabled", "");
-  addBot.addEventListener("click", () => send({ type: "addBot" }));
+  addBot.addEventListener("click", () => send({ type: "addBot", level: level.value }));
   const start = el("button", { class: "primary" }, "Start Game");
   if (state.players.length < 2) start.setAttribute("disabled", "");
   start.addEventListener("click", () => send({ type: "start" }));
-  wrap.append(addBot, start);
+  wrap.append(rule, casual, listed, clock, board, variant, level, addBot, start);
 }
 
 function renderBag() {
@@ -517,8 +672,7 @@ function renderBag() {
   const wrap = $("bagLetters");
   wrap.innerHTML = "";
   const counts = unseenBreakdown();
-  const letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ".split("");
-  letters.push("?");
+  const letters = [...alphabet, "?"];
 
   for (const ch of letters) {
     const n = counts[ch] ?? 0;
@@ -834,8 +988,7 @@ function pickBlankLetter(): Promise<string | null> {
       resolve(letter);
     };
 
-    for (let code = "A".charCodeAt(0); code <= "Z".charCodeAt(0); code++) {
-      const ch = String.fromCharCode(code);
+    for (const ch of alphabet) {
       const btn = el("button", { type: "button" }, ch);
       btn.addEventListener("click", () => done(ch));
       grid.append(btn);
@@ -852,7 +1005,7 @@ function pickBlankLetter(): Promise<string | null> {
   });
 }
 
-function showJoinModal(defaults: { name: string; room: string; lang: string }): Promise<{ name: string; room: string; lang: string }> {
+function showJoinModal(defaults: { name: string; room: string; lang: string; watch: boolean }): Promise<JoinChoice> {
   return new Promise(resolve => {
     const card = el("div", { class: "modal-card" });
     card.append(el("h2", {}, "Join a game"));
@@ -877,28 +1030,61 @@ function showJoinModal(defaults: { name: string; room: string; lang: string }):
     const langCol = el("div", {});
     langCol.append(el("label", { htmlFor: "join-lang" }, "Language"));
     const langSelect = el("select", { id: "join-lang" }) as HTMLSelectElement;
-    langSelect.append(el("option", { value: "en" }, "English"));
+    for (const [code, label] of LANGUAGES) {
+      langSelect.append(el("option", { value: code }, label));
+    }
     langSelect.value = defaults.lang;
     langCol.append(langSelect);
     row.append(langCol);
     form.append(row);
 
+    const watch = el("label", { class: "watch-toggle", title: "Spectators see the board and scores but no racks" });
+    const watchBox = el("input", { type: "checkbox", id: "join-watch" }) as HTMLInputElement;
+    watchBox.checked = defaults.watch;
+    roomInput.required = watchBox.checked;
+    watchBox.addEventListener("change", () => { roomInput.required = watchBox.checked; });
+    watch.append(watchBox, " Watch only (needs a room code)");
+    form.append(watch);
+
+    const listed = el("label", { class: "watch-toggle", title: "Public rooms are listed below and filled by quick play" });
+    const listedBox = el("input", { type: "checkbox", id: "join-public" }) as HTMLInputElement;
+    listedBox.checked = true;
+    listed.append(listedBox, " Public (a new room is listed for anyone to join)");
+    form.append(listed);
+
     const actions = el("div", { class: "actions" });
+    const quick = el("button", { type: "button", title: "Take a seat in the fullest open room in this language, or open a new one" }, "⚡ Quick play");
     const submit = el("button", { type: "submit", class: "primary" }, "Join");
-    actions.append(submit);
+    actions.append(quick, submit);
     form.append(actions);
 
+    const browser = el("div", { class: "room-browser" });
+    form.append(browser);
+    const refreshRooms = () => void renderRoomBrowser(browser, langSelect.value, (code, watchIt) => {
+      roomInput.value = code;
+      watchBox.checked = watchIt;
+      form.requestSubmit();
+    });
+    const poll = setInterval(refreshRooms, ROOM_BROWSER_POLL_MS);
+    langSelect.addEventListener("change", refreshRooms);
+    refreshRooms();
+
     let resolved = false;
-    form.addEventListener("submit", (e) => {
-      e.preventDefault();
+    const finish = (quickPlay: boolean) => {
       if (resolved) return;
       const name = nameInput.value.trim() || "Player";
-      const room = roomInput.value.trim().toUpperCase();
+      const room = quickPlay ? "" : roomInput.value.trim().toUpperCase();
       const lang = langSelect.value;
       resolved = true;
+      clearInterval(poll);
       closeModal(backdrop);
-      resolve({ name, room, lang });
+      resolve({ name, room, lang, watch: !quickPlay && watchBox.checked && room !== "", quick: quickPlay, public: listedBox.checked });
+    };
+    form.addEventListener("submit", (e) => {
+      e.preventDefault();
+      finish(false);
     });
+    quick.addEventListener("click", () => finish(true));
 
     card.append(form);
     const backdrop = showModal(card);
@@ -906,6 +1092,40 @@ function showJoinModal(defaults: { name: string; room: string; lang: string }):
   });
 }
 
+const ROOM_BROWSER_POLL_MS = 3000;
+
+// renderRoomBrowser lists the lobby's public rooms in one language. A
+// room with a free seat can be joined; a game already under way can be
+// watched.
+async function renderRoomBrowser(wrap: HTMLElement, lang: string, pick: (code: string, watch: boolean) => void) {
+  let rooms: RoomStatus[] = [];
+  try {
+    rooms = (await (await fetch(`/rooms?lang=${encodeURIComponent(lang)}`)).json()).rooms || [];
+  } catch {
+    return; // keep the last list; the next poll may reach the lobby
+  }
+
+  wrap.innerHTML = "";
+  wrap.append(el("label", {}, "Open rooms"));
+  if (rooms.length === 0) {
+    wrap.append(el("p", {}, "No public rooms yet — create one or try quick play."));
+    return;
+  }
+  for (const r of rooms) {
+    const rules = [r.layout, r.variant, r.timeControl || "untimed", r.casual ? "casual" : "rated"].join(" · ");
+    const row = el("div", { class: "room" + (r.joinable ? "" : " full") });
+    row.append(
+      el("b", {}, r.code),
+      el("span", {}, `${r.players}/${r.maxPlayers}${r.spectators ? ` +${r.spectators} 👁` : ""}`),
+      el("span", { class: "muted" }, `${r.phase} · ${rules}`),
+    );
+    const action = el("button", { type: "button" }, r.joinable ? "Join" : "Watch");
+    action.addEventListener("click", () => pick(r.code, !r.joinable));
+    row.append(action);
+    wrap.append(row);
+  }
+}
+
 function showGameOverOverlay() {
   const sorted = [...state.players].sort((a, b) => b.score - a.score);
   const winnerID = sorted[0]?.id;
@@ -926,26 +1146,77 @@ function showGameOverOverlay() {
   card.append(tbl);
 
   if (state.leaderboard.length > 0) {
-    card.append(el("h3", { style: "margin: 20px 0 8px; font-size: 12px; color: var(--muted); letter-spacing: 1px; text-transform: uppercase;" }, "All-time leaderboard"));
+    card.append(el("h3", { style: "margin: 20px 0 8px; font-size: 12px; color: var(--muted); letter-spacing: 1px; text-transform: uppercase;" }, `Top ratings · ${state.language.toUpperCase()}`));
     const lbtbl = el("table", { class: "gameover-table" });
     for (const entry of state.leaderboard.slice(0, 5)) {
       const row = el("tr");
       row.append(el("td", {}, entry.name || entry.playerID));
-      row.append(el("td", { class: "num" }, `${entry.wins} win${entry.wins === 1 ? "" : "s"}`));
+      row.append(el("td", { class: "num", title: `±${entry.deviation} after ${entry.games} rated game${entry.games === 1 ? "" : "s"}` }, String(entry.rating)));
       lbtbl.append(row);
     }
     card.append(lbtbl);
   }
 
+  card.append(el("div", { id: "analysis" }));
+
   const actions = el("div", { class: "actions" });
-  const playAgain = el("button", { class: "primary" }, "Play Again");
-  playAgain.addEventListener("click", () => { closeModal(backdrop); state.gameOverShown = false; send({ type: "playAgain" }); });
   const close = el("button", {}, "Close");
   close.addEventListener("click", () => closeModal(backdrop));
-  actions.append(playAgain, close);
+  if (!state.spectator) {
+    const playAgain = el("button", { class: "primary" }, "Play Again");
+    playAgain.addEventListener("click", () => { closeModal(backdrop); state.gameOverShown = false; send({ type: "playAgain" }); });
+    actions.append(playAgain);
+  }
+  if (state.gameNumber > 0) {
+    const record = el("button", {}, "Download GCG");
+    const url = `/games/${encodeURIComponent(state.roomCode)}/${state.gameNumber}`;
+    record.addEventListener("click", () => { window.location.href = url; });
+    actions.append(record);
+  }
+  actions.append(close);
   card.append(actions);
 
   const backdrop = showModal(card);
+  renderAnalysis();
+}
+
+// renderAnalysis fills the game-over overlay's analysis section: each
+// turn next to the best play the server found for the same rack, and
+// the points every player left on the table. The analysis usually
+// arrives a moment after the overlay opens.
+function renderAnalysis() {
+  const wrap = document.getElementById("analysis");
+  const analysis = state.analysis;
+  if (!wrap || !analysis) return;
+  wrap.innerHTML = "";
+
+  const heading = "margin: 20px 0 8px; font-size: 12px; color: var(--muted); letter-spacing: 1px; text-transform: uppercase;";
+  wrap.append(el("h3", { style: heading }, "Points left on the table"));
+  const totals = el("table", { class: "gameover-table" });
+  for (const entry of analysis.lost || []) {
+    const row = el("tr");
+    row.append(el("td", {}, entry.name));
+    row.append(el("td", { class: "num" }, String(entry.score)));
+    totals.append(row);
+  }
+  wrap.append(totals);
+
+  const turns = el("div", { class: "analysis-turns" });
+  const tbl = el("table", { class: "gameover-table analysis-table" });
+  const head = el("tr");
+  for (const label of ["#", "Player", "Played", "Best", "Lost"]) head.append(el("th", {}, label));
+  tbl.append(head);
+  for (const t of analysis.turns || []) {
+    const row = el("tr", { class: t.lost > 0 ? "missed" : "" });
+    row.append(el("td", { class: "num" }, String(t.turn)));
+    row.append(el("td", {}, t.name));
+    row.append(el("td", {}, `${t.played || "—"} ${t.score}`));
+    row.append(el("td", {}, t.best ? `${t.best} ${t.bestScore}` : "—"));
+    row.append(el("td", { class: "num" }, t.lost > 0 ? `−${t.lost}` : ""));
+    tbl.append(row);
+  }
+  turns.append(tbl);
+  wrap.append(turns);
 }
 
 // ------------------------------------------------------------ ws + msgs
@@ -960,9 +1231,13 @@ function handle(msg: Msg) {
     case "joined":
       state.playerID = msg.playerID;
       state.roomCode = msg.room;
+      state.reconnects = 0;
       state.language = msg.language;
       state.owner = msg.owner;
+      state.spectator = msg.spectator;
+      if (msg.alphabet) applyAlphabet(msg.alphabet);
       if (msg.leaderboard) state.leaderboard = msg.leaderboard;
+      if (msg.layouts?.length) state.layouts = msg.layouts;
       render();
       break;
     case "state":
@@ -974,8 +1249,23 @@ function handle(msg: Msg) {
       state.ownerID = msg.ownerID;
       state.bagRemaining = msg.bagRemaining;
       state.turnDeadlineMs = msg.timerMs > 0 ? Date.now() + msg.timerMs : 0;
-      state.owner = state.playerID === msg.ownerID;
-      if (msg.phase !== "gameOver") state.gameOverShown = false;
+      state.challengeRule = msg.challengeRule || "void";
+      state.casual = msg.casual;
+      state.public = msg.public;
+      state.timeControl = msg.timeControl || "";
+      state.layout = msg.layout || "standard";
+      state.premiums = msg.premiums?.length ? msg.premiums : STANDARD_PREMIUMS;
+      state.bagScale = msg.bagScale || 1;
+      state.variant = msg.variant || "classic";
+      state.lexicon = msg.lexicon || "";
+      state.stateAtMs = Date.now();
+      state.spectators = msg.spectators;
+      state.challengeDeadlineMs = msg.challengeMs > 0 ? Date.now() + msg.challengeMs : 0;
+      state.owner = state.playerID === msg.ownerID && !state.spectator;
+      if (msg.phase !== "gameOver") {
+        state.gameOverShown = false;
+        state.analysis = null;
+      }
       state.pending = state.pending.filter(p => !state.board[p.row]?.[p.col]);
       render();
       break;
@@ -1000,6 +1290,16 @@ function handle(msg: Msg) {
       render();
       break;
     }
+    case "challenge": {
+      const text = msg.withdrawn
+        ? `${msg.challengerName} challenged ${msg.name}: ${(msg.phonies || []).join(", ")} withdrawn (−${msg.score})`
+        : `${msg.challengerName} challenged ${msg.name}: the play stands, ${msg.challengerName} loses a turn`;
+      state.log.push({ kind: "event", text });
+      state.challengeDeadlineMs = 0;
+      if (msg.withdrawn) state.lastMove = null;
+      render();
+      break;
+    }
     case "chat":
       state.log.push({ kind: "event", text: `${msg.from} ${msg.text}` });
       render();
@@ -1010,19 +1310,116 @@ function handle(msg: Msg) {
       break;
     case "gameOver":
       if (msg.leaderboard) state.leaderboard = msg.leaderboard;
+      state.gameNumber = msg.gameNumber;
+      break;
+    case "hint":
+      applyHint(msg.placements);
+      state.log.push({ kind: "event", text: `Hint: ${msg.words.map(w => w.word).join(", ")} for ${msg.score}` });
+      render();
+      break;
+    case "lookup":
+      renderLookupResult(msg);
+      break;
+    case "analysis":
+      state.analysis = msg;
+      renderAnalysis();
       break;
   }
 }
 
+// applyHint lays a hinted play out as pending tiles, drawn from the rack,
+// so the player can submit it as is or recall it.
+function applyHint(placements: PlacementWire[]) {
+  const used = new Set<number>();
+  const pending: Pending[] = [];
+  for (const p of placements) {
+    const want = p.blank ? "?" : p.letter;
+    const idx = state.yourRack.findIndex((t, i) => !used.has(i) && t === want);
+    if (idx < 0) return;
+    used.add(idx);
+    pending.push({ rackIdx: idx, row: p.row, col: p.col, letter: p.letter, blank: !!p.blank });
+  }
+  state.pending = pending;
+  state.selectedRackIdx = -1;
+}
+
+// ------------------------------------------------------------ word tools
+
+function setupLookup() {
+  const form = $("lookupForm") as HTMLFormElement;
+  form.addEventListener("submit", e => {
+    e.preventDefault();
+    const kind = ($("lookupKind") as HTMLSelectElement).value;
+    const query = ($("lookupQuery") as HTMLInputElement).value.trim();
+    if (query) send({ type: "lookup", kind, query });
+  });
+}
+
+function renderLookupResult(msg: LookupMsg) {
+  const out = $("lookupResult");
+  out.innerHTML = "";
+  switch (msg.kind) {
+    case "word":
+      out.append(el("div", { class: msg.valid ? "lookup-ok" : "lookup-bad" }, `${msg.query} is ${msg.valid ? "valid" : "not valid"}`));
+      return;
+    case "hooks": {
+      const front = (msg.front || []).join(" ") || "—";
+      const back = (msg.back || []).join(" ") || "—";
+      out.append(el("div", {}, `${front} · ${msg.query} · ${back}`));
+      return;
+    }
+  }
+  const words = msg.words || [];
+  if (words.length === 0) {
+    out.append(el("div", { class: "lookup-bad" }, "No words found."));
+    return;
+  }
+  out.append(el("div", { class: "lookup-words" }, words.join(" ")));
+  if (msg.truncated) out.append(el("div", { class: "lookup-bad" }, `First ${words.length} shown.`));
+}
+
 // ------------------------------------------------------------ timer tick
 
+// liveClockMs runs the current player's clock on from the last state.
+// It is stopped while a challenge window is open.
+function liveClockMs(p: PlayerView): number {
+  if (state.phase !== "playing" || p.id !== state.currentID || state.challengeDeadlineMs > 0) return p.clockMs;
+  return p.clockMs - (Date.now() - state.stateAtMs);
+}
+
+// formatClock shows a bank as m:ss, and overtime as -m:ss.
+function formatClock(ms: number): string {
+  const total = Math.ceil(Math.abs(ms) / 1000);
+  const m = Math.floor(total / 60);
+  const s = total % 60;
+  return `${ms < 0 ? "-" : ""}${m}:${String(s).padStart(2, "0")}`;
+}
+
+function tickClocks() {
+  for (const p of state.players) {
+    const span = document.querySelector<HTMLElement>(`.players .clock[data-id="${CSS.escape(p.id)}"]`);
+    if (!span) continue;
+    const ms = liveClockMs(p);
+    span.textContent = formatClock(ms);
+    span.className = "clock" + (ms < 0 ? " over" : "");
+  }
+}
+
 function tickTimer() {
   const t = $("timer");
+  if (state.timeControl) tickClocks();
   if (state.phase === "paused") {
     t.textContent = "⏸";
     t.className = "timer warn";
     return;
   }
+  const current = state.players.find(p => p.id === state.currentID);
+  if (state.timeControl && state.phase === "playing" && current) {
+    const ms = liveClockMs(current);
+    t.textContent = formatClock(ms);
+    t.className = "timer" + (ms < 0 ? " crit" : ms <= 60_000 ? " warn" : "");
+    return;
+  }
   if (state.turnDeadlineMs === 0 || state.phase !== "playing") {
     t.textContent = "";
     t.className = "timer";
@@ -1045,18 +1442,19 @@ function getOrCreatePlayerID(): string {
   return id;
 }
 
-function connectWS(name: string, room: string, lang: string) {
-  state.name = name;
-  state.language = lang;
-  state.playerID = getOrCreatePlayerID();
-  sessionStorage.setItem("scrabble.name", name);
-
-  $("app").hidden = false;
+// Reconnect attempts after the socket drops (e.g. the pod serving it
+// restarts). The room keeps the seat, so rejoining by code resumes the game.
+const MAX_RECONNECTS = 5;
 
+function openSocket(room: string) {
   const url = new URL(window.location.href);
   url.protocol = url.protocol === "https:" ? "wss:" : "ws:";
   url.pathname = "/ws";
-  url.search = new URLSearchParams({ name, id: state.playerID, room, lang }).toString();
+  const params = new URLSearchParams({ name: state.name, id: state.playerID, room, lang: state.language });
+  if (state.watch) params.set("watch", "1");
+  if (!room && state.quick) params.set("quick", "1");
+  if (!room && state.public) params.set("public", "1");
+  url.search = params.toString();
 
   const ws = new WebSocket(url.toString());
   state.ws = ws;
@@ -1064,9 +1462,31 @@ function connectWS(name: string, room: string, lang: string) {
     try { handle(JSON.parse(e.data)); } catch { /* ignore */ }
   });
   ws.addEventListener("close", () => {
-    state.log.push({ kind: "event", text: "disconnected", error: true });
+    if (state.roomCode && state.reconnects < MAX_RECONNECTS) {
+      const delay = 1000 * 2 ** state.reconnects;
+      state.reconnects++;
+      state.log.push({ kind: "event", text: `disconnected — reconnecting in ${delay / 1000}s`, error: true });
+      setTimeout(() => openSocket(state.roomCode), delay);
+    } else {
+      state.log.push({ kind: "event", text: "disconnected", error: true });
+    }
     render();
   });
+}
+
+function connectWS(name: string, room: string, lang: string, watch: boolean, create: { quick?: boolean; public?: boolean } = {}) {
+  state.name = name;
+  state.language = lang;
+  state.watch = watch;
+  state.quick = create.quick ?? false;
+  state.public = create.public ?? false;
+  state.playerID = getOrCreatePlayerID();
+  sessionStorage.setItem("scrabble.name", name);
+
+  $("app").hidden = false;
+
+  openSocket(room);
+  setupLookup();
 
   document.addEventListener("pointermove", onDocPointerMove);
   document.addEventListener("pointerup", onDocPointerUp);
@@ -1081,15 +1501,16 @@ async function init() {
   const urlName = params.get("name") ?? "";
   const urlRoom = params.get("room") ?? "";
   const urlLang = params.get("lang") ?? "en";
+  const urlWatch = params.get("watch") === "1" && urlRoom !== "";
 
   if (urlName) {
-    connectWS(urlName, urlRoom, urlLang);
+    connectWS(urlName, urlRoom, urlLang, urlWatch);
     return;
   }
 
   const cached = sessionStorage.getItem("scrabble.name") ?? "";
-  const choice = await showJoinModal({ name: cached, room: urlRoom, lang: urlLang });
-  connectWS(choice.name, choice.room, choice.lang);
+  const choice = await showJoinModal({ name: cached, room: urlRoom, lang: urlLang, watch: urlWatch });
+  connectWS(choice.name, choice.room, choice.lang, choice.watch, choice);
 }
 
 init();
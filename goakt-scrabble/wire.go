@@ -25,7 +25,6 @@ package main
 import (
 	"fmt"
 	"strings"
-	"unicode"
 
 	"github.com/tochemey/goakt-examples/v2/goakt-scrabble/scrabble"
 )
@@ -38,14 +37,14 @@ const (
 	unassignedBlank = "?"
 )
 
-// boardToWire converts the engine board to the 15x15 string grid the
-// browser renders. Empty squares are "".
+// boardToWire converts the engine board to the square string grid the
+// browser renders, 15x15 on the standard layout. Empty squares are "".
 func boardToWire(board *scrabble.Board, lang *scrabble.Language) [][]string {
-	out := make([][]string, scrabble.BoardSize)
+	out := make([][]string, board.Size())
 
-	for row := range scrabble.BoardSize {
-		out[row] = make([]string, scrabble.BoardSize)
-		for col := range scrabble.BoardSize {
+	for row := range board.Size() {
+		out[row] = make([]string, board.Size())
+		for col := range board.Size() {
 			sq := board.At(row, col)
 			if !sq.Filled {
 				continue
@@ -57,10 +56,10 @@ func boardToWire(board *scrabble.Board, lang *scrabble.Language) [][]string {
 	return out
 }
 
-func emptyBoardWire() [][]string {
-	out := make([][]string, scrabble.BoardSize)
-	for row := range scrabble.BoardSize {
-		out[row] = make([]string, scrabble.BoardSize)
+func emptyBoardWire(size int) [][]string {
+	out := make([][]string, size)
+	for row := range size {
+		out[row] = make([]string, size)
 	}
 
 	return out
@@ -84,7 +83,7 @@ func tileToWire(tile scrabble.Tile, lang *scrabble.Language) string {
 		return unassignedBlank
 	}
 
-	letter := string(lang.Rune(tile.Letter))
+	letter := lang.Letter(tile.Letter)
 
 	if tile.Blank {
 		return blankPrefix + letter
@@ -103,12 +102,7 @@ func toEnginePlacements(wires []PlacementWire, lang *scrabble.Language) ([]scrab
 			return nil, fmt.Errorf("placement %d has empty letter", i)
 		}
 
-		runes := []rune(strings.ToUpper(wire.Letter))
-		if len(runes) != 1 {
-			return nil, fmt.Errorf("placement %d letter %q must be a single character", i, wire.Letter)
-		}
-
-		id, ok := lang.ID(unicode.ToUpper(runes[0]))
+		id, ok := lang.Lookup(strings.TrimSpace(wire.Letter))
 		if !ok {
 			return nil, fmt.Errorf("placement %d letter %q not in alphabet", i, wire.Letter)
 		}
@@ -149,3 +143,20 @@ func formedWords(result *scrabble.MoveResult) []FormedWordWire {
 
 	return out
 }
+
+// alphabetToWire lists the language's tiles for the client's point
+// labels, unseen-tile tracker and blank picker.
+func alphabetToWire(lang *scrabble.Language) []LetterWire {
+	out := make([]LetterWire, 0, lang.AlphabetSize()+1)
+
+	for id := range lang.AlphabetSize() {
+		letter := scrabble.LetterID(id)
+		out = append(out, LetterWire{
+			Letter: lang.Letter(letter),
+			Points: lang.PointValue(letter),
+			Count:  lang.Distribution[letter],
+		})
+	}
+
+	return append(out, LetterWire{Letter: unassignedBlank, Count: lang.Blanks})
+}
@@ -28,6 +28,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/coder/websocket"
@@ -62,12 +64,12 @@ const matchResolveDeadline = 2 * time.Second
 // requestMatch asks the cluster matchmaker singleton for a fresh match and
 // resolves it to a usable *PID. Encapsulated here so the WS handler stays
 // readable. May return a remote PID — Tells will be routed transparently.
-func requestMatch(ctx context.Context, system actor.ActorSystem) (*actor.PID, string, error) {
+func requestMatch(ctx context.Context, system actor.ActorSystem, create *CreateMatch) (*actor.PID, string, error) {
 	mm, err := system.ActorOf(ctx, MatchmakerActorName)
 	if err != nil {
 		return nil, "", fmt.Errorf("locate matchmaker: %w", err)
 	}
-	reply, err := actor.Ask(ctx, mm, &CreateMatch{}, matchCreateTimeout)
+	reply, err := actor.Ask(ctx, mm, create, matchCreateTimeout)
 	if err != nil {
 		return nil, "", fmt.Errorf("matchmaker.CreateMatch: %w", err)
 	}
@@ -92,14 +94,41 @@ func requestMatch(ctx context.Context, system actor.ActorSystem) (*actor.PID, st
 	return nil, "", fmt.Errorf("resolve match %s: %w", created.MatchName, lookupErr)
 }
 
+// findGhost looks up the replay to race: the top-scoring one for "best",
+// otherwise the one with that id. Nil if there is none — the game is
+// then played without a ghost.
+func findGhost(ctx context.Context, system actor.ActorSystem, id string) *Replay {
+	store := replayStoreFromExtension(system)
+	if store == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, replayStoreTimeout)
+	defer cancel()
+
+	if id == "best" {
+		top, err := store.Top(ctx, 1)
+		if err != nil || len(top) == 0 {
+			return nil
+		}
+		return top[0]
+	}
+
+	replay, ok, err := store.Get(ctx, id)
+	if err != nil || !ok {
+		return nil
+	}
+	return replay
+}
+
 // wsHandler upgrades the request to a WebSocket and, for that connection,
 // asks the cluster matchmaker for a fresh match, then spawns a local
 // PlayerSessionActor that owns the connection. The reader loop here exists
 // only because conn.Read blocks — it's the boundary between a blocking I/O
 // API and the actor world. When the WS closes, the session unsubscribes;
-// the match observes that via Watch / *Terminated and self-stops, taking
-// its scheduled tick with it.
-func wsHandler(system actor.ActorSystem, logger log.Logger) http.HandlerFunc {
+// the match observes that via Watch / *Terminated and self-stops, and
+// the node's ticker drops it the same way.
+func wsHandler(system actor.ActorSystem, leaderboard *Leaderboard, logger log.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
 			InsecureSkipVerify: true, // Phase 2.1: no origin auth yet
@@ -109,7 +138,40 @@ func wsHandler(system actor.ActorSystem, logger log.Logger) http.HandlerFunc {
 			return
 		}
 
-		match, matchName, err := requestMatch(r.Context(), system)
+		// ?id= is the player's identity, kept by the browser; finished
+		// games are recorded to that profile under ?name=. A connection
+		// without one plays anonymously under a fresh id.
+		q := r.URL.Query()
+		playerID := strings.TrimSpace(q.Get("id"))
+		if playerID == "" {
+			playerID = uuid.NewString()
+		}
+		name := strings.TrimSpace(q.Get("name"))
+		if name == "" {
+			name = "Player-" + playerID[:min(6, len(playerID))]
+		}
+		leaderboard.RememberName(playerID, name)
+
+		// ?mode=versus&players=N queues the player for an N-player game
+		// (2 when unset); anything else is solo. ?preview=N picks the
+		// preview queue length (0..6, default 5; the match clamps it).
+		create := &CreateMatch{Mode: ModeSolo}
+		if q.Get("mode") == ModeVersus {
+			create.Mode = ModeVersus
+			create.Players, _ = strconv.Atoi(q.Get("players"))
+		}
+		preview := previewDefault
+		if n, err := strconv.Atoi(q.Get("preview")); err == nil {
+			preview = n
+		}
+		// ?ghost=best races the best game in this node's replay store;
+		// ?ghost=<id> a given one. Solo only.
+		var ghost *Replay
+		if id := q.Get("ghost"); id != "" && create.Mode == ModeSolo {
+			ghost = findGhost(r.Context(), system, id)
+		}
+
+		match, matchName, err := requestMatch(r.Context(), system, create)
 		if err != nil {
 			_ = conn.Close(websocket.StatusInternalError, "matchmaker unavailable")
 			logger.Errorf("ws %v", err)
@@ -120,7 +182,14 @@ func wsHandler(system actor.ActorSystem, logger log.Logger) http.HandlerFunc {
 		// match name — that name belongs to the cluster and may have been
 		// assigned by a remote matchmaker.
 		sessionName := sessionActorPrefix + uuid.NewString()
-		session := &PlayerSessionActor{match: match, conn: conn}
+		session := &PlayerSessionActor{
+			match:    match,
+			conn:     conn,
+			playerID: playerID,
+			name:     name,
+			preview:  preview,
+			ghost:    ghost,
+		}
 		sessionPID, err := system.Spawn(r.Context(), sessionName, session, actor.WithLongLived())
 		if err != nil {
 			_ = match.Shutdown(r.Context())
@@ -132,7 +201,7 @@ func wsHandler(system actor.ActorSystem, logger log.Logger) http.HandlerFunc {
 		if match.IsRemote() {
 			matchLoc = "remote@" + match.Path().HostPort()
 		}
-		logger.Infof("ws connected: session=%s match=%s (%s)", sessionName, matchName, matchLoc)
+		logger.Infof("ws connected: player=%q session=%s match=%s (%s)", name, sessionName, matchName, matchLoc)
 
 		// Reader loop: blocks on Read until the client disconnects. Inbound
 		// frames are parsed and forwarded to the session as typed actor
@@ -0,0 +1,438 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tochemey/goakt/v4/actor"
+	"github.com/tochemey/goakt/v4/crdt"
+	"github.com/tochemey/goakt/v4/extension"
+)
+
+const (
+	leaderboardOpTimeout   = 2 * time.Second
+	LeaderboardExtensionID = "tetris_leaderboard"
+
+	// bestsKeyPrefix indexes the players' bests of each period:
+	// "tetris.bests.all", "tetris.bests.day.2026-10-16",
+	// "tetris.bests.week.2026-W42".
+	bestsKeyPrefix = "tetris.bests."
+
+	// periodsKey lists the day and week keys that have an index, so the
+	// ones whose period has ended can be found and deleted.
+	periodsKey = "tetris.bests.periods"
+
+	// topPlayers is how many rows GET /leaderboard returns.
+	topPlayers = 10
+)
+
+// periods are the leaderboard periods a game counts towards.
+var periods = []string{PeriodAll, PeriodDay, PeriodWeek}
+
+// periodKey names the period containing t: "all", "day.2026-10-16" or
+// "week.2026-W42". Keys change when the day or week does, which is all
+// it takes to start a fresh board.
+func periodKey(period string, t time.Time) string {
+	t = t.UTC()
+	switch period {
+	case PeriodDay:
+		return "day." + t.Format(time.DateOnly)
+	case PeriodWeek:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("week.%d-W%02d", year, week)
+	default:
+		return PeriodAll
+	}
+}
+
+// Leaderboard ranks players by their best game of a period. The bests
+// are kept by each player's PlayerProfileGrain, whose single activation
+// serialises every update however many nodes finish games at once. Each
+// new best is also added, as a JSON-encoded LeaderboardEntry, to a CRDT
+// ORSet per period, so a board is read from the set alone without
+// activating any grain. Falls back to a process-local index if the
+// cluster's CRDT replicator is unavailable.
+//
+// A set holds one entry per player: a new best replaces the one it
+// beats. Once a day or week has ended its index is deleted from the
+// replicator, so only the current day, the current week and the
+// all-time board are kept.
+type Leaderboard struct {
+	system actor.ActorSystem
+
+	mu       sync.Mutex
+	fallback map[string]map[string]LeaderboardEntry
+	names    map[string]string
+}
+
+var _ extension.Extension = (*Leaderboard)(nil)
+
+func NewLeaderboard() *Leaderboard {
+	return &Leaderboard{
+		fallback: make(map[string]map[string]LeaderboardEntry),
+		names:    make(map[string]string),
+	}
+}
+
+func (l *Leaderboard) Bind(system actor.ActorSystem) { l.system = system }
+
+func (l *Leaderboard) ID() string { return LeaderboardExtensionID }
+
+func leaderboardFromExtension(system actor.ActorSystem) *Leaderboard {
+	for _, ext := range system.Extensions() {
+		if ext.ID() == LeaderboardExtensionID {
+			if leaderboard, ok := ext.(*Leaderboard); ok {
+				return leaderboard
+			}
+		}
+	}
+
+	return nil
+}
+
+// RememberName records the latest known display name for a player so
+// that Top has one for players whose profile has none yet.
+func (l *Leaderboard) RememberName(playerID, name string) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	l.names[playerID] = name
+	l.mu.Unlock()
+}
+
+// RecordGame adds a finished game to the player's profile, then to the
+// index of every period in which it is the player's best.
+func (l *Leaderboard) RecordGame(ctx context.Context, playerID, name string, result Result) error {
+	if l == nil || l.system == nil {
+		return nil
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, leaderboardOpTimeout)
+	defer cancel()
+
+	l.RememberName(playerID, name)
+
+	view, err := l.askProfile(cctx, playerID, &RecordGame{Name: name, Result: result})
+	if err != nil {
+		return fmt.Errorf("record game of %s: %w", playerID, err)
+	}
+
+	now := time.Now()
+	for _, period := range periods {
+		if view.best(period) != result {
+			continue
+		}
+
+		entry := LeaderboardEntry{PlayerID: playerID, Name: view.Name, Result: result}
+		if err := l.index(cctx, periodKey(period, now), entry); err != nil {
+			return err
+		}
+	}
+
+	return l.expire(cctx, now)
+}
+
+// Top returns the top-n players of the current period, best score
+// first.
+func (l *Leaderboard) Top(ctx context.Context, period string, n int) ([]LeaderboardEntry, error) {
+	if l == nil || l.system == nil {
+		return nil, nil
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, leaderboardOpTimeout)
+	defer cancel()
+
+	bests, err := l.bests(cctx, periodKey(period, time.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]LeaderboardEntry, 0, len(bests))
+	for _, entry := range bests {
+		if entry.Name == "" {
+			entry.Name = l.nameFor(entry.PlayerID)
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		return entries[i].PlayerID < entries[j].PlayerID
+	})
+
+	if n > 0 && len(entries) > n {
+		entries = entries[:n]
+	}
+
+	return entries, nil
+}
+
+func (l *Leaderboard) askProfile(ctx context.Context, playerID string, msg any) (*ProfileView, error) {
+	ident, err := profileGrain(ctx, l.system, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := l.system.AskGrain(ctx, ident, msg, leaderboardOpTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	view, ok := resp.(*ProfileView)
+	if !ok {
+		return nil, fmt.Errorf("unexpected profile reply type %T", resp)
+	}
+
+	return view, nil
+}
+
+// index adds a player's new best to a period's set, removing the best
+// it beats.
+func (l *Leaderboard) index(ctx context.Context, key string, entry LeaderboardEntry) error {
+	if l.system.Replicator() == nil {
+		l.mu.Lock()
+		if l.fallback[key] == nil {
+			l.fallback[key] = make(map[string]LeaderboardEntry)
+		}
+		if best, ok := l.fallback[key][entry.PlayerID]; !ok || entry.Score > best.Score {
+			l.fallback[key][entry.PlayerID] = entry
+		}
+		l.mu.Unlock()
+		return nil
+	}
+
+	element, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	addUpd := &crdt.Update{
+		Key:     crdt.ORSetKey(bestsKeyPrefix + key),
+		Initial: crdt.NewORSet(),
+		Modify: func(d crdt.ReplicatedData) crdt.ReplicatedData {
+			set := d.(*crdt.ORSet)
+			for _, raw := range set.Elements() {
+				if old, ok := raw.(string); ok && entryPlayer(old) == entry.PlayerID {
+					set = set.Remove(old)
+				}
+			}
+			return set.Add(l.nodeID(), string(element))
+		},
+	}
+
+	if _, err := actor.Ask(ctx, l.system.Replicator(), addUpd, leaderboardOpTimeout); err != nil {
+		return fmt.Errorf("crdt index best: %w", err)
+	}
+
+	if key == PeriodAll {
+		return nil
+	}
+
+	periodUpd := &crdt.Update{
+		Key:     crdt.ORSetKey(periodsKey),
+		Initial: crdt.NewORSet(),
+		Modify: func(d crdt.ReplicatedData) crdt.ReplicatedData {
+			return d.(*crdt.ORSet).Add(l.nodeID(), key)
+		},
+	}
+
+	if _, err := actor.Ask(ctx, l.system.Replicator(), periodUpd, leaderboardOpTimeout); err != nil {
+		return fmt.Errorf("crdt list period: %w", err)
+	}
+
+	return nil
+}
+
+// expire deletes the index of every day and week that has ended by now.
+func (l *Leaderboard) expire(ctx context.Context, now time.Time) error {
+	current := make(map[string]bool, len(periods))
+	for _, period := range periods {
+		current[periodKey(period, now)] = true
+	}
+
+	if l.system.Replicator() == nil {
+		l.mu.Lock()
+		for key := range l.fallback {
+			if !current[key] {
+				delete(l.fallback, key)
+			}
+		}
+		l.mu.Unlock()
+		return nil
+	}
+
+	resp, err := actor.Ask(ctx, l.system.Replicator(), &crdt.Get{Key: crdt.ORSetKey(periodsKey)}, leaderboardOpTimeout)
+	if err != nil {
+		return fmt.Errorf("crdt get periods: %w", err)
+	}
+
+	setResp, ok := resp.(*crdt.GetResponse)
+	if !ok || setResp.Data == nil {
+		return nil
+	}
+
+	var ended []string
+	for _, raw := range setResp.Data.(*crdt.ORSet).Elements() {
+		if key, ok := raw.(string); ok && !current[key] {
+			ended = append(ended, key)
+		}
+	}
+	if len(ended) == 0 {
+		return nil
+	}
+
+	for _, key := range ended {
+		if _, err := actor.Ask(ctx, l.system.Replicator(), &crdt.Delete{Key: crdt.ORSetKey(bestsKeyPrefix + key)}, leaderboardOpTimeout); err != nil {
+			return fmt.Errorf("crdt delete %s: %w", key, err)
+		}
+	}
+
+	periodUpd := &crdt.Update{
+		Key:     crdt.ORSetKey(periodsKey),
+		Initial: crdt.NewORSet(),
+		Modify: func(d crdt.ReplicatedData) crdt.ReplicatedData {
+			set := d.(*crdt.ORSet)
+			for _, key := range ended {
+				set = set.Remove(key)
+			}
+			return set
+		},
+	}
+
+	if _, err := actor.Ask(ctx, l.system.Replicator(), periodUpd, leaderboardOpTimeout); err != nil {
+		return fmt.Errorf("crdt unlist periods: %w", err)
+	}
+
+	return nil
+}
+
+// bests returns each player's best of a period, keyed by player id.
+// Two nodes indexing the same player at once can leave both entries in
+// the set until the next update; the higher score is the newer.
+func (l *Leaderboard) bests(ctx context.Context, key string) (map[string]LeaderboardEntry, error) {
+	if l.system.Replicator() == nil {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		return maps.Clone(l.fallback[key]), nil
+	}
+
+	resp, err := actor.Ask(ctx, l.system.Replicator(), &crdt.Get{Key: crdt.ORSetKey(bestsKeyPrefix + key)}, leaderboardOpTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("crdt get bests: %w", err)
+	}
+
+	setResp, ok := resp.(*crdt.GetResponse)
+	if !ok || setResp.Data == nil {
+		return nil, nil
+	}
+
+	bests := make(map[string]LeaderboardEntry)
+	for _, raw := range setResp.Data.(*crdt.ORSet).Elements() {
+		element, ok := raw.(string)
+		if !ok {
+			continue
+		}
+
+		var entry LeaderboardEntry
+		if err := json.Unmarshal([]byte(element), &entry); err != nil {
+			return nil, fmt.Errorf("decode best: %w", err)
+		}
+
+		if best, ok := bests[entry.PlayerID]; !ok || entry.Score > best.Score {
+			bests[entry.PlayerID] = entry
+		}
+	}
+
+	return bests, nil
+}
+
+// entryPlayer returns the player id of a JSON-encoded LeaderboardEntry.
+func entryPlayer(element string) string {
+	var entry LeaderboardEntry
+	if err := json.Unmarshal([]byte(element), &entry); err != nil {
+		return ""
+	}
+
+	return entry.PlayerID
+}
+
+func (l *Leaderboard) nameFor(pid string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if name, ok := l.names[pid]; ok {
+		return name
+	}
+
+	return pid
+}
+
+func (l *Leaderboard) nodeID() string {
+	if l.system == nil {
+		return "local"
+	}
+
+	return fmt.Sprintf("%s:%d", l.system.Host(), l.system.Port())
+}
+
+// leaderboardHandler serves GET /leaderboard?period=day|week|all (all
+// when unset): the period's top players as JSON.
+func leaderboardHandler(leaderboard *Leaderboard) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		period := r.URL.Query().Get("period")
+		switch period {
+		case "":
+			period = PeriodAll
+		case PeriodDay, PeriodWeek, PeriodAll:
+		default:
+			http.Error(w, "unknown period", http.StatusBadRequest)
+			return
+		}
+
+		entries, err := leaderboard.Top(r.Context(), period, topPlayers)
+		if err != nil {
+			http.Error(w, "leaderboard unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		if entries == nil {
+			entries = []LeaderboardEntry{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"period": period, "entries": entries})
+	}
+}
@@ -72,20 +72,41 @@ var (
 	discoveryPort = flag.Int("discovery-port", 9001, "Gossip port used by the static discovery provider")
 	peersPort     = flag.Int("peers-port", 9002, "Cluster peer state-sync port")
 	peers         = flag.String("peers", "", "Comma-separated host:discoveryPort list of cluster bootstrap peers; defaults to this node only")
+	databaseURL   = flag.String("database-url", "", "Postgres DSN for the profile store (defaults to $DATABASE_URL; in-memory fallback if unset)")
 )
 
+const profileStoreInitTimeout = 10 * time.Second
+
 func main() {
 	flag.Parse()
 	ctx := context.Background()
 	logger := log.DefaultLogger
 
-	system, err := buildActorSystem(logger)
+	profiles, closeProfiles, err := buildProfileStore(ctx, logger)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	defer closeProfiles()
+
+	replays := newMemReplayStore()
+	leaderboard := NewLeaderboard()
+	ticker := NewTicker()
+	system, err := buildActorSystem(logger, replays, profiles, leaderboard, ticker)
 	if err != nil {
 		logger.Fatal(err)
 	}
 	if err := system.Start(ctx); err != nil {
 		logger.Fatal(err)
 	}
+	leaderboard.Bind(system)
+
+	// One ticker per node drives every match placed here. Spawn it
+	// now rather than with the first match; a match a peer placed here
+	// before this line, as it may once the node has joined, spawns it
+	// itself.
+	if _, err := ticker.PID(ctx, system); err != nil {
+		logger.Fatal(err)
+	}
 
 	// Singleton matchmaker. One per cluster — even when scaling to N
 	// pods, exactly one MatchFactory runs across them. Every node calls
@@ -105,7 +126,11 @@ func main() {
 	}
 
 	mux := http.NewServeMux()
-	mux.HandleFunc("/ws", wsHandler(system, logger))
+	mux.HandleFunc("/ws", wsHandler(system, leaderboard, logger))
+	mux.HandleFunc("GET /leaderboard", leaderboardHandler(leaderboard))
+	mux.HandleFunc("GET /players/{id}", profileHandler(system))
+	mux.HandleFunc("GET /replays", replaysHandler(replays))
+	mux.HandleFunc("GET /replays/{id}", replayHandler(replays))
 	mux.Handle("/", http.FileServer(http.FS(web)))
 
 	addr := fmt.Sprintf(":%d", *httpPort)
@@ -133,10 +158,44 @@ func main() {
 	_ = system.Stop(shutCtx)
 }
 
+// buildProfileStore picks a profile-store backend. If --database-url
+// (or $DATABASE_URL) is set, it connects to Postgres, runs the schema
+// migration, and returns a pgProfileStore + its Close function.
+// Otherwise it returns the in-memory store. A non-empty DSN that fails
+// to connect is a hard error; we don't silently degrade to in-memory.
+func buildProfileStore(ctx context.Context, logger log.Logger) (profileStore, func(), error) {
+	dsn := databaseDSN()
+	if dsn == "" {
+		logger.Info("profile store: using in-memory backend (set DATABASE_URL for Postgres)")
+		return newMemProfileStore(), func() {}, nil
+	}
+
+	initCtx, cancel := context.WithTimeout(ctx, profileStoreInitTimeout)
+	defer cancel()
+
+	pg, err := newPgProfileStore(initCtx, dsn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("postgres profile store: %w", err)
+	}
+
+	logger.Info("profile store: using Postgres backend")
+
+	return pg, pg.Close, nil
+}
+
+func databaseDSN() string {
+	if dsn := strings.TrimSpace(*databaseURL); dsn != "" {
+		return dsn
+	}
+
+	return os.Getenv("DATABASE_URL")
+}
+
 // buildActorSystem assembles the cluster-aware ActorSystem: remote
 // (with the serializers our cross-node message types need), discovery,
-// and the cluster config that registers our actor kinds.
-func buildActorSystem(logger log.Logger) (actor.ActorSystem, error) {
+// the cluster config that registers our actor kinds, and the replay,
+// profile, leaderboard and ticker extensions.
+func buildActorSystem(logger log.Logger, replays replayStore, profiles profileStore, leaderboard *Leaderboard, ticker *Ticker) (actor.ActorSystem, error) {
 	cbor := remote.NewCBORSerializer()
 	remoteCfg := remoting.NewConfig(*bindHost, *remotingPort,
 		// Messages that cross the wire when a match lands on a remote
@@ -148,6 +207,17 @@ func buildActorSystem(logger log.Logger) (actor.ActorSystem, error) {
 		remote.WithSerializers((*Snapshot)(nil), cbor),
 		remote.WithSerializers((*CreateMatch)(nil), cbor),
 		remote.WithSerializers((*MatchCreated)(nil), cbor),
+		remote.WithSerializers((*JoinQueue)(nil), cbor),
+		remote.WithSerializers((*LeaveQueue)(nil), cbor),
+		remote.WithSerializers((*QueueStatus)(nil), cbor),
+		remote.WithSerializers((*VersusStart)(nil), cbor),
+		remote.WithSerializers((*VersusFrame)(nil), cbor),
+		remote.WithSerializers((*Garbage)(nil), cbor),
+		remote.WithSerializers((*GameRecorded)(nil), cbor),
+		remote.WithSerializers((*RaceGhost)(nil), cbor),
+		remote.WithSerializers((*GetProfile)(nil), cbor),
+		remote.WithSerializers((*RecordGame)(nil), cbor),
+		remote.WithSerializers((*ProfileView)(nil), cbor),
 	)
 
 	discoConfig := &static.Config{Hosts: peerList(*peers, *bindHost, *discoveryPort)}
@@ -163,12 +233,16 @@ func buildActorSystem(logger log.Logger) (actor.ActorSystem, error) {
 		WithWriteTimeout(3*time.Second).
 		// Only kinds that may be spawned via SpawnOn / SpawnSingleton go
 		// here. PlayerSessionActor stays node-local and is *not* listed.
-		WithKinds(new(MatchActor), new(MatchFactory))
+		WithKinds(new(MatchActor), new(MatchFactory)).
+		// CRDT replication backs the leaderboard's per-period player
+		// index. Defaults are fine for a demo.
+		WithCRDT()
 
 	return actor.NewActorSystem(systemName,
 		actor.WithLogger(logger),
 		actor.WithRemote(remoteCfg),
 		actor.WithCluster(clusterCfg),
+		actor.WithExtensions(replays, profiles, leaderboard, ticker),
 	)
 }
 
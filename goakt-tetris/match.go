@@ -24,18 +24,20 @@ package main
 
 import (
 	"math/rand/v2"
+	"slices"
 	"time"
 
 	"github.com/tochemey/goakt/v4/actor"
 )
 
 const (
-	tickInterval = 16 * time.Millisecond // 60 Hz — keeps input snappy
+	// keyframeEvery is how many frames go by between keyframes; the
+	// frames in between carry only what changed. See broadcast.
+	keyframeEvery = 60
 
-	// schedRefPrefix is combined with the match actor's own name to produce
-	// a globally-unique reference for its tick schedule. (`Schedule`
-	// references collide system-wide, so this MUST be unique per match.)
-	schedRefPrefix = "tick."
+	// ghostFrameTicks throttles a raced ghost's board in delta frames to
+	// the rate versus opponents report theirs at.
+	ghostFrameTicks = versusFrameTicks
 
 	// Gravity rate in ticks per piece drop. Higher level = faster drop.
 	gravityStart  = 50 // ~0.83 s/drop at level 1
@@ -47,6 +49,30 @@ const (
 
 	// hardDropBonus is added to score per cell traveled on a hard drop.
 	hardDropBonus = 2
+
+	// Lock delay: a piece resting on the stack locks after lockDelayTicks.
+	// Each successful move or rotation restarts the timer, but only
+	// lockResetLimit times until the piece reaches a new lowest row —
+	// enough to slide a piece into place, not enough to stall forever.
+	lockDelayTicks = 30 // 0.5 s
+	lockResetLimit = 15
+
+	// Pieces shown in the preview queue; players pick with ?preview=.
+	previewDefault = 5
+	previewMax     = 6
+
+	// spawnX puts a new piece's pivot left of centre, the guideline
+	// spawn columns: I on 3–6, O on 4–5, the rest on 3–5.
+	spawnX = BoardW/2 - 1
+)
+
+// Guideline line-clear scores, indexed by lines cleared and multiplied
+// by level. A back-to-back Tetris or T-spin scores half again, and each
+// lock extending a combo adds 50 × combo.
+var (
+	lineScores  = [...]int{0, 100, 300, 500, 800}
+	tspinScores = [...]int{400, 800, 1200, 1600}
+	miniScores  = [...]int{100, 200, 400, 400}
 )
 
 // Tetromino kind indices into pieceShapes. Used for the wire payload and
@@ -61,54 +87,123 @@ const (
 	kindL
 )
 
-// pieceShapes — base orientation of each tetromino, as 4 (dx, dy) cell
-// offsets from the piece's pivot. Server and client share the same table
-// (mirrored in main.js) so the wire payload only needs piece kind + rot.
-var pieceShapes = [7][4][2]int{
-	{{-1, 0}, {0, 0}, {1, 0}, {2, 0}},  // I
-	{{0, 0}, {1, 0}, {0, 1}, {1, 1}},   // O
-	{{-1, 0}, {0, 0}, {1, 0}, {0, 1}},  // T
-	{{0, 0}, {1, 0}, {-1, 1}, {0, 1}},  // S
-	{{-1, 0}, {0, 0}, {0, 1}, {1, 1}},  // Z
-	{{-1, 0}, {0, 0}, {1, 0}, {1, 1}},  // J
-	{{-1, 0}, {0, 0}, {1, 0}, {-1, 1}}, // L
+// pieceShapes — every tetromino in each of its four SRS orientations
+// (spawn, R, 2, L — clockwise), as 4 (dx, dy) cell offsets from the
+// piece's pivot, y pointing down. J, L, S, T and Z turn about a cell;
+// I and O turn about a point between cells, so their orientations are
+// spelled out rather than derived. The kick tables in srs.go assume
+// exactly these positions. Server and client share the same table
+// (mirrored in main.ts) so the wire payload only needs piece kind + rot.
+var pieceShapes = [7][4][4][2]int{
+	{ // I
+		{{-1, 0}, {0, 0}, {1, 0}, {2, 0}},
+		{{1, -1}, {1, 0}, {1, 1}, {1, 2}},
+		{{-1, 1}, {0, 1}, {1, 1}, {2, 1}},
+		{{0, -1}, {0, 0}, {0, 1}, {0, 2}},
+	},
+	{ // O
+		{{0, 0}, {1, 0}, {0, 1}, {1, 1}},
+		{{0, 0}, {1, 0}, {0, 1}, {1, 1}},
+		{{0, 0}, {1, 0}, {0, 1}, {1, 1}},
+		{{0, 0}, {1, 0}, {0, 1}, {1, 1}},
+	},
+	{ // T
+		{{0, -1}, {-1, 0}, {0, 0}, {1, 0}},
+		{{0, -1}, {0, 0}, {1, 0}, {0, 1}},
+		{{-1, 0}, {0, 0}, {1, 0}, {0, 1}},
+		{{0, -1}, {-1, 0}, {0, 0}, {0, 1}},
+	},
+	{ // S
+		{{0, -1}, {1, -1}, {-1, 0}, {0, 0}},
+		{{0, -1}, {0, 0}, {1, 0}, {1, 1}},
+		{{0, 0}, {1, 0}, {-1, 1}, {0, 1}},
+		{{-1, -1}, {-1, 0}, {0, 0}, {0, 1}},
+	},
+	{ // Z
+		{{-1, -1}, {0, -1}, {0, 0}, {1, 0}},
+		{{1, -1}, {0, 0}, {1, 0}, {0, 1}},
+		{{-1, 0}, {0, 0}, {0, 1}, {1, 1}},
+		{{0, -1}, {-1, 0}, {0, 0}, {-1, 1}},
+	},
+	{ // J
+		{{-1, -1}, {-1, 0}, {0, 0}, {1, 0}},
+		{{0, -1}, {1, -1}, {0, 0}, {0, 1}},
+		{{-1, 0}, {0, 0}, {1, 0}, {1, 1}},
+		{{0, -1}, {0, 0}, {-1, 1}, {0, 1}},
+	},
+	{ // L
+		{{1, -1}, {-1, 0}, {0, 0}, {1, 0}},
+		{{0, -1}, {0, 0}, {0, 1}, {1, 1}},
+		{{-1, 0}, {0, 0}, {1, 0}, {-1, 1}},
+		{{-1, -1}, {0, -1}, {0, 0}, {0, 1}},
+	},
 }
 
-// pieceCells returns the cell offsets for a piece in a given rotation by
-// applying 90° clockwise rotation `rot` times to the base shape. (Each
-// rotation step maps (dx,dy) → (-dy, dx).) The O piece (kind == 1) is
-// intentionally never rotated — callers gate this.
+// pieceCells returns the cell offsets for a piece in a given rotation.
 func pieceCells(kind, rot int) [4][2]int {
-	cells := pieceShapes[kind]
-	for i := 0; i < rot; i++ {
-		for j := range cells {
-			cells[j] = [2]int{-cells[j][1], cells[j][0]}
-		}
-	}
-	return cells
+	return pieceShapes[kind][rot]
 }
 
-// MatchActor owns one Tetris game: the board, the active piece, the next
-// piece, the score/lines/level, and the gravity countdown. Tick advances
-// the gravity countdown; once it hits zero the piece drops by 1 row (or
-// locks if it can't). PlayerInput messages mutate the piece position.
+// MatchActor owns one Tetris game: the board, the active piece, the
+// preview queue and hold slot, the score/lines/level, and the gravity
+// countdown. Ticks come from the node's TickerActor (ticker.go); each
+// step advances the gravity countdown, and once it hits zero the piece
+// drops by 1 row. A piece that can't drop any further locks when
+// its lock delay runs out. PlayerInput messages mutate the piece
+// position. A versus match also carries a versusState; see versus.go.
 type MatchActor struct {
 	grid     [BoardH][BoardW]int8
 	piece    PieceState
-	nextKind int
+	queue    []int // upcoming kinds, nearest first; see nextPiece
+	preview  int   // how much of queue the player is shown
+	hold     int   // held kind, or -1
+	canHold  bool  // false from a hold until the next lock
 	score    int
 	lines    int
 	level    int
+	combo    int  // consecutive clearing locks minus one; -1 = none
+	b2b      bool // the last clear was a Tetris or a T-spin
 	gameOver bool
 
-	softDrop bool
-	paused   bool
-	gravity  int // ticks/drop
-	fallTick int // ticks until next fall
-
-	tickN    int
-	subs     []*actor.PID
-	schedRef string // reference id for our tick schedule
+	softDrop   bool
+	paused     bool
+	lastRotate bool // the piece's last successful move was a rotation
+	lastKick   int  // which SRS kick test that rotation passed
+	gravity    int  // ticks/drop
+	fallTick   int  // ticks until next fall
+	lockTicks  int  // ticks until a grounded piece locks
+	lockResets int  // lock delay restarts since lowestY
+	lowestY    int  // deepest row the piece's pivot has reached
+
+	// rng deals the pieces from seed. Solo matches seed it at random; the
+	// matches of a versus game share a seed so every player sees the
+	// same pieces, and a ghost race uses the ghost's.
+	seed uint64
+	rng  *rand.Rand
+	vs   *versusState // nil in solo play
+
+	// rec records the game in progress; replayID names the last one
+	// recorded. ghost is the recorded game being raced, if any.
+	rec      *Replay
+	replayID string
+	ghost    *ghost
+
+	tickN  int
+	subs   []*actor.PID
+	ticker *actor.PID // the node's TickerActor, watched; see subscribeTicker
+
+	// ack is the highest input sequence number applied, echoed in every
+	// frame so the client can drop the inputs it predicted. The rest is
+	// what the subscribers were last sent: sent is the grid they hold,
+	// last the other fields of the last frame, and keyframe forces the
+	// next frame to carry everything.
+	ack       uint32
+	sent      [BoardH][BoardW]int8
+	last      frameState
+	keyframe  bool
+	sinceKey  int
+	ghostSent int  // ghost tick of the last ghost view sent
+	ghostDone bool // whether that view was Out
 
 	// hadSubscriber prevents the actor from self-stopping during the brief
 	// window between PostStart and the owner's first Subscribe message.
@@ -119,11 +214,11 @@ var _ actor.Actor = (*MatchActor)(nil)
 
 func (*MatchActor) PreStart(*actor.Context) error { return nil }
 
-// PostStop cancels the recurring tick so no further messages are scheduled
-// to a stopped actor (they'd end up in the dead-letter queue).
+// PostStop leaves a versus game. The ticker needs no word: it watches
+// the match and drops it on Terminated.
 func (m *MatchActor) PostStop(ctx *actor.Context) error {
-	if m.schedRef != "" {
-		_ = ctx.ActorSystem().CancelSchedule(m.schedRef)
+	if m.vs != nil {
+		m.versusStop(ctx)
 	}
 	return nil
 }
@@ -131,17 +226,23 @@ func (m *MatchActor) PostStop(ctx *actor.Context) error {
 func (m *MatchActor) Receive(ctx *actor.ReceiveContext) {
 	switch msg := ctx.Message().(type) {
 	case *actor.PostStart:
+		m.deal(rand.Uint64())
+		if players := versusPlayers(ctx.Self().Name()); players > 0 {
+			m.vs = &versusState{self: ctx.Self().Name(), players: players}
+		}
+		m.preview = previewDefault
 		m.reset()
-		m.schedRef = schedRefPrefix + ctx.Self().Name()
-		if err := ctx.ActorSystem().Schedule(ctx.Context(), &tick{}, ctx.Self(),
-			tickInterval, actor.WithReference(m.schedRef)); err != nil {
-			ctx.Err(err)
+		if m.vs == nil {
+			m.startReplay(ModeSolo)
 		}
+		m.subscribeTicker(ctx)
 
 	case *Subscribe:
+		m.preview = min(max(msg.Preview, 0), previewMax)
 		sender := ctx.Sender()
 		m.subs = append(m.subs, sender)
 		m.hadSubscriber = true
+		m.keyframe = true
 		ctx.Watch(sender) // self-stop if the subscriber dies
 
 	case *Unsubscribe:
@@ -151,20 +252,53 @@ func (m *MatchActor) Receive(ctx *actor.ReceiveContext) {
 		m.maybeShutdown(ctx)
 
 	case *actor.Terminated:
+		if m.ticker != nil && m.ticker.Path().Equals(msg.ActorPath()) {
+			ctx.Logger().Warnf("%s: ticker stopped; subscribing to a new one", ctx.Self().Name())
+			m.subscribeTicker(ctx)
+			return
+		}
 		m.removeSubscriberByPath(msg.ActorPath())
 		m.maybeShutdown(ctx)
 
 	case *PlayerInput:
+		m.ack = max(m.ack, msg.Seq)
 		m.handleInput(msg.Action)
+		if m.vs != nil {
+			m.versusAfter(ctx)
+		}
+
+	case *RaceGhost:
+		// Ghost races are solo. The board restarts on the ghost's seed so
+		// both games are dealt the same pieces from the same tick.
+		if m.vs == nil {
+			m.ghost = newGhost(&msg.Replay)
+			m.restart()
+		}
 
 	case *tick:
-		if !m.gameOver && !m.paused {
-			m.step()
+		// A late tick carries several steps; the board catches up on all
+		// of them and the subscribers see only the result.
+		for range msg.steps {
+			if m.vs != nil {
+				m.versusTick(ctx)
+			}
+			if m.playing() {
+				m.step()
+				if m.ghost != nil {
+					m.ghost.step()
+				}
+			}
+			if m.vs != nil {
+				m.versusAfter(ctx)
+			}
 		}
+		m.finishReplay(ctx)
 		m.broadcast(ctx)
 
 	default:
-		ctx.Unhandled()
+		if !m.handleVersus(ctx) {
+			ctx.Unhandled()
+		}
 	}
 }
 
@@ -174,26 +308,50 @@ func (m *MatchActor) reset() {
 			m.grid[r][c] = 0
 		}
 	}
-	m.nextKind = rand.IntN(7)
+	m.tickN = 0
+	m.keyframe = true
+	m.replayID = ""
+	m.queue = nil
+	m.hold = -1
+	m.canHold = true
 	m.score = 0
 	m.lines = 0
 	m.level = 1
+	m.combo = -1
+	m.b2b = false
 	m.gameOver = false
 	m.softDrop = false
 	m.paused = false
 	m.gravity = gravityStart
 	m.fallTick = m.gravity
-	m.spawnPiece()
+	m.spawnPiece(m.nextPiece())
 }
 
-func (m *MatchActor) spawnPiece() {
+// nextPiece takes the next kind off the queue. The queue is topped up a
+// whole bag at a time — the seven pieces in shuffled order — so no kind
+// is ever more than 12 pieces away and the preview always has enough to
+// show.
+func (m *MatchActor) nextPiece() int {
+	for len(m.queue) <= previewMax {
+		m.queue = append(m.queue, m.rng.Perm(7)...)
+	}
+	kind := m.queue[0]
+	m.queue = m.queue[1:]
+	return kind
+}
+
+func (m *MatchActor) spawnPiece(kind int) {
 	m.piece = PieceState{
-		Kind: m.nextKind,
+		Kind: kind,
 		Rot:  0,
-		X:    BoardW / 2,
+		X:    spawnX,
 		Y:    1,
 	}
-	m.nextKind = rand.IntN(7)
+	m.lastRotate = false
+	m.lastKick = 0
+	m.lockTicks = lockDelayTicks
+	m.lockResets = 0
+	m.lowestY = m.piece.Y
 	// If the new piece overlaps an existing block, the stack reached the
 	// top — game over.
 	if !m.canPlace(m.piece) {
@@ -219,11 +377,30 @@ func (m *MatchActor) canPlace(p PieceState) bool {
 	return true
 }
 
+// playing reports whether the board is running: not over, not paused,
+// and in versus play, not waiting for opponents or counting down.
+func (m *MatchActor) playing() bool {
+	if m.gameOver || m.paused {
+		return false
+	}
+	return m.vs == nil || m.vs.live()
+}
+
+// step advances one tick: gravity while the piece can fall, the lock
+// delay once it can't.
 func (m *MatchActor) step() {
 	m.tickN++
+	if m.grounded() {
+		m.lockTicks--
+		if m.lockTicks <= 0 {
+			m.lockPiece()
+		}
+		return
+	}
+
 	m.fallTick--
 	if m.fallTick <= 0 {
-		m.tryFall()
+		m.fall()
 		m.fallTick = m.currentGravity()
 	}
 }
@@ -235,19 +412,46 @@ func (m *MatchActor) currentGravity() int {
 	return m.gravity
 }
 
-func (m *MatchActor) tryFall() {
+// grounded reports whether the piece is resting on the stack or floor.
+func (m *MatchActor) grounded() bool {
 	next := m.piece
 	next.Y++
-	if m.canPlace(next) {
-		m.piece = next
-		return
+	return !m.canPlace(next)
+}
+
+// fall moves the piece down a row if it can. Reaching a new lowest row
+// gives the lock delay and its move resets back in full.
+func (m *MatchActor) fall() bool {
+	next := m.piece
+	next.Y++
+	if !m.canPlace(next) {
+		return false
+	}
+	m.piece = next
+	m.lastRotate = false
+	if m.piece.Y > m.lowestY {
+		m.lowestY = m.piece.Y
+		m.lockTicks = lockDelayTicks
+		m.lockResets = 0
+	}
+	return true
+}
+
+// moveReset restarts the lock delay after a successful move or rotation,
+// while the piece has resets left.
+func (m *MatchActor) moveReset() {
+	if m.lockResets < lockResetLimit {
+		m.lockTicks = lockDelayTicks
+		m.lockResets++
 	}
-	m.lockPiece()
 }
 
 // lockPiece writes the active piece into the grid, clears any full rows,
-// scores them, levels up if appropriate, and spawns the next piece.
+// scores them, levels up if appropriate, and spawns the next piece. In
+// versus play the clear also becomes an attack, or, if nothing cleared,
+// the pending garbage comes in.
 func (m *MatchActor) lockPiece() {
+	spin := m.tSpin()
 	cells := pieceCells(m.piece.Kind, m.piece.Rot)
 	for _, c := range cells {
 		x := m.piece.X + c[0]
@@ -257,16 +461,49 @@ func (m *MatchActor) lockPiece() {
 		}
 	}
 	cleared := m.clearLines()
-	// Classic scoring: 1=100, 2=300, 3=500, 4=800, multiplied by level.
-	scoreFor := [...]int{0, 100, 300, 500, 800}
-	m.score += scoreFor[cleared] * m.level
+	b2b := m.scoreClear(cleared, spin)
 	m.lines += cleared
 	m.level = 1 + m.lines/10
 	m.gravity = gravityStart - (m.level-1)*gravityPerLvl
 	if m.gravity < gravityMin {
 		m.gravity = gravityMin
 	}
-	m.spawnPiece()
+	if m.vs != nil {
+		m.scoreAttack(cleared, spin, b2b)
+	}
+	m.canHold = true
+	m.spawnPiece(m.nextPiece())
+}
+
+// scoreClear scores a lock and carries the combo and back-to-back
+// chains, which a clear extends and a lock that clears nothing breaks
+// (combo) or leaves alone (back-to-back). It reports whether this clear
+// was a back-to-back.
+func (m *MatchActor) scoreClear(cleared int, spin tspinKind) bool {
+	points := lineScores[cleared]
+	switch spin {
+	case tspinFull:
+		points = tspinScores[cleared]
+	case tspinMini:
+		points = miniScores[cleared]
+	}
+
+	b2b := false
+	if cleared == 0 {
+		m.combo = -1
+	} else {
+		difficult := cleared == 4 || spin != tspinNone
+		b2b = difficult && m.b2b
+		if b2b {
+			points = points * 3 / 2
+		}
+		m.b2b = difficult
+		m.combo++
+		points += 50 * m.combo
+	}
+
+	m.score += points * m.level
+	return b2b
 }
 
 // clearLines collapses any fully-filled rows toward the bottom and returns
@@ -303,10 +540,13 @@ func (m *MatchActor) clearLines() int {
 }
 
 func (m *MatchActor) handleInput(action string) {
+	m.record(ReplayEvent{Action: action})
+
 	// Pause is always honored (except after game-over — there's nothing
-	// running to pause). All other inputs are gated on !paused.
+	// running to pause). All other inputs are gated on !paused. A versus
+	// game has opponents to answer to and can't be paused.
 	if action == ActionPause {
-		if !m.gameOver {
+		if !m.gameOver && m.vs == nil {
 			m.paused = !m.paused
 		}
 		return
@@ -314,12 +554,12 @@ func (m *MatchActor) handleInput(action string) {
 
 	if m.gameOver {
 		if action == ActionRestart {
-			m.reset()
+			m.restart()
 		}
 		return
 	}
 
-	if m.paused {
+	if !m.playing() {
 		return
 	}
 
@@ -329,15 +569,26 @@ func (m *MatchActor) handleInput(action string) {
 	case ActionRight:
 		m.tryShift(1)
 	case ActionRotate:
-		if m.piece.Kind == kindO { // O piece has no meaningful rotation
+		if m.rotate(1) {
+			m.moveReset()
+		}
+	case ActionRotateCCW:
+		if m.rotate(-1) {
+			m.moveReset()
+		}
+	case ActionHold:
+		// One hold per piece: the slot is locked until the next piece
+		// locks, or holding could cycle through pieces forever.
+		if !m.canHold {
 			return
 		}
-
-		next := m.piece
-		next.Rot = (next.Rot + 1) % 4
-		if m.canPlace(next) {
-			m.piece = next
+		held := m.hold
+		m.hold = m.piece.Kind
+		if held < 0 {
+			held = m.nextPiece()
 		}
+		m.spawnPiece(held)
+		m.canHold = false
 	case ActionSoftDrop:
 		m.softDrop = true
 		if m.fallTick > softDropTicks {
@@ -346,13 +597,7 @@ func (m *MatchActor) handleInput(action string) {
 	case ActionSoftDropEnd:
 		m.softDrop = false
 	case ActionHardDrop:
-		for {
-			next := m.piece
-			next.Y++
-			if !m.canPlace(next) {
-				break
-			}
-			m.piece = next
+		for m.fall() {
 			m.score += hardDropBonus
 		}
 		m.lockPiece()
@@ -360,11 +605,33 @@ func (m *MatchActor) handleInput(action string) {
 	}
 }
 
+// restart starts the next game after game over: a fresh deal and a new
+// recording in solo play, with the ghost (if racing one) from the top;
+// back into the queue in versus play.
+func (m *MatchActor) restart() {
+	if m.vs != nil {
+		m.vs.leave()
+		m.reset()
+		return
+	}
+
+	seed := rand.Uint64()
+	if m.ghost != nil {
+		seed = m.ghost.replay.Seed
+		m.ghost = newGhost(m.ghost.replay)
+	}
+	m.deal(seed)
+	m.reset()
+	m.startReplay(ModeSolo)
+}
+
 func (m *MatchActor) tryShift(dx int) {
 	next := m.piece
 	next.X += dx
 	if m.canPlace(next) {
 		m.piece = next
+		m.lastRotate = false
+		m.moveReset()
 	}
 }
 
@@ -394,6 +661,34 @@ func (m *MatchActor) removeSubscriberByPath(path actor.Path) {
 	}
 }
 
+// subscribeTicker registers the match with the node's TickerActor,
+// spawning one if none is running, and watches it: a ticker that stops
+// would otherwise leave the match waiting for ticks that never come,
+// so its Terminated brings the match back here. Without ticks the board
+// never moves, so if no ticker can be had the match stops rather than
+// leave the player in front of a frozen game.
+func (m *MatchActor) subscribeTicker(ctx *actor.ReceiveContext) {
+	m.ticker = nil
+
+	ticker := tickerFromExtension(ctx.ActorSystem())
+	if ticker == nil {
+		ctx.Logger().Errorf("%s: no ticker extension on this node", ctx.Self().Name())
+		ctx.Shutdown()
+		return
+	}
+
+	pid, err := ticker.PID(ctx.Context(), ctx.ActorSystem())
+	if err != nil {
+		ctx.Logger().Errorf("%s: %v", ctx.Self().Name(), err)
+		ctx.Shutdown()
+		return
+	}
+
+	m.ticker = pid
+	ctx.Tell(pid, &tickSubscribe{})
+	ctx.Watch(pid)
+}
+
 // maybeShutdown stops the match once its owning subscriber has gone away.
 // The hadSubscriber guard prevents the actor from killing itself during
 // the brief window between PostStart and the owner's first Subscribe.
@@ -422,25 +717,127 @@ func (m *MatchActor) ghostY() int {
 	}
 }
 
+// broadcast sends the subscribers a frame. A keyframe carries the
+// whole game; the frames between carry only the grid rows that changed
+// since the last frame, and the opponents and ghost only when those
+// moved. A tick that changed nothing sends nothing at all, so a paused
+// or finished game goes quiet between keyframes.
+//
+// Keyframes come every keyframeEvery frames and whenever the client may
+// hold nothing to apply a delta to: a new subscriber, a restart, a new
+// versus game.
 func (m *MatchActor) broadcast(ctx *actor.ReceiveContext) {
+	key := m.keyframe || m.sinceKey >= keyframeEvery
 	snap := &Snapshot{
 		Tick:     m.tickN,
 		T:        time.Now().UnixMilli(),
-		Grid:     m.gridCopy(),
+		Key:      key,
+		Ack:      m.ack,
 		Piece:    m.piece,
 		GhostY:   m.ghostY(),
-		Next:     m.nextKind,
+		Next:     slices.Clone(m.queue[:m.preview]),
+		Hold:     m.hold,
+		CanHold:  m.canHold,
 		Score:    m.score,
 		Lines:    m.lines,
 		Level:    m.level,
 		GameOver: m.gameOver,
 		Paused:   m.paused,
+		Mode:     ModeSolo,
+		Replay:   m.replayID,
+	}
+	if g := m.ghost; g != nil && (key || g.board.tickN-m.ghostSent >= ghostFrameTicks || g.done() != m.ghostDone) {
+		snap.Ghost = g.view()
+		m.ghostSent = g.board.tickN
+		m.ghostDone = snap.Ghost.Out
+	}
+	if m.vs != nil {
+		m.versusSnapshot(snap, key)
+	}
+
+	if key {
+		snap.Grid = m.gridCopy()
+		m.sent = m.grid
+		m.keyframe = false
+		m.sinceKey = 0
+	} else {
+		snap.Rows = m.changedRows()
+		if len(snap.Rows) == 0 && snap.Ghost == nil && snap.Opponents == nil && stateOf(snap) == m.last {
+			return
+		}
+		m.sinceKey++
 	}
+
+	m.last = stateOf(snap)
 	for _, sub := range m.subs {
 		ctx.Tell(sub, snap)
 	}
 }
 
+// changedRows returns the grid rows that differ from what the
+// subscribers hold, and marks them sent.
+func (m *MatchActor) changedRows() []GridRow {
+	var rows []GridRow
+	for y := range m.grid {
+		if m.grid[y] != m.sent[y] {
+			rows = append(rows, GridRow{Y: y, Cells: slices.Clone(m.grid[y][:])})
+			m.sent[y] = m.grid[y]
+		}
+	}
+	return rows
+}
+
+// frameState is the part of a frame that every frame repeats, in a
+// comparable form, so broadcast can tell whether a tick changed any of
+// it. Tick and T are left out: they move whether or not anything else
+// does.
+type frameState struct {
+	piece     PieceState
+	ghostY    int
+	next      [previewMax]int
+	preview   int
+	hold      int
+	canHold   bool
+	score     int
+	lines     int
+	level     int
+	gameOver  bool
+	paused    bool
+	waiting   bool
+	queued    int
+	countdown int
+	pending   int
+	attack    string
+	place     int
+	replay    string
+	ack       uint32
+}
+
+func stateOf(snap *Snapshot) frameState {
+	state := frameState{
+		piece:     snap.Piece,
+		ghostY:    snap.GhostY,
+		preview:   len(snap.Next),
+		hold:      snap.Hold,
+		canHold:   snap.CanHold,
+		score:     snap.Score,
+		lines:     snap.Lines,
+		level:     snap.Level,
+		gameOver:  snap.GameOver,
+		paused:    snap.Paused,
+		waiting:   snap.Waiting,
+		queued:    snap.Queued,
+		countdown: snap.Countdown,
+		pending:   snap.Pending,
+		attack:    snap.Attack,
+		place:     snap.Place,
+		replay:    snap.Replay,
+		ack:       snap.Ack,
+	}
+	copy(state.next[:], snap.Next)
+	return state
+}
+
 func (m *MatchActor) gridCopy() [][]int8 {
 	g := make([][]int8, BoardH)
 	for r := 0; r < BoardH; r++ {
@@ -23,19 +23,40 @@
 package main
 
 import (
+	"math/rand/v2"
+	"slices"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/tochemey/goakt/v4/actor"
 )
 
+// queueEntryTTL drops a queued versus match that stopped re-sending
+// JoinQueue — its LeaveQueue was lost, or its node died.
+const queueEntryTTL = 15 * time.Second
+
 // MatchFactory is a cluster-singleton actor that mints a fresh MatchActor
 // per request and places it on whichever cluster node currently has the
 // lightest load. Single-node setups still go through this path so the
 // 2.1 → 2.2 transition (one node → many) requires no code change here.
 //
-// The factory holds *no* per-match state — it's a stateless dispatcher.
-// One singleton per cluster is enough because spawning a new match is a
-// cheap, infrequent operation (once per WS connection).
-type MatchFactory struct{}
+// The factory holds no per-match state. Its only state is the versus
+// queues: one per game size, holding the matches whose players are
+// waiting for opponents. Once a queue has enough players the factory
+// links them with VersusStart and forgets them; the game itself runs
+// between the matches. The queues are soft state — waiting matches
+// re-send JoinQueue every few seconds, so a factory rebuilt by
+// singleton failover fills them again. One singleton per cluster is
+// enough because spawning a new match is a cheap, infrequent operation
+// (once per WS connection).
+type MatchFactory struct {
+	queues map[int][]queueEntry
+}
+
+type queueEntry struct {
+	match string
+	seen  time.Time
+}
 
 var _ actor.Actor = (*MatchFactory)(nil)
 
@@ -43,17 +64,24 @@ func (*MatchFactory) PreStart(*actor.Context) error { return nil }
 func (*MatchFactory) PostStop(*actor.Context) error { return nil }
 
 func (f *MatchFactory) Receive(ctx *actor.ReceiveContext) {
-	switch ctx.Message().(type) {
+	switch msg := ctx.Message().(type) {
 	case *actor.PostStart:
+		// The kind registry instantiates the singleton with new(), so the
+		// map is made here rather than in a constructor.
+		f.queues = make(map[int][]queueEntry)
 		ctx.Logger().Infof("matchmaker ready on %s", ctx.Self().Path().HostPort())
 
 	case *CreateMatch:
 		name := matchActorPrefix + uuid.NewString()
+		if msg.Mode == ModeVersus {
+			name = versusMatchName(min(max(msg.Players, VersusMinPlayers), VersusMaxPlayers), uuid.NewString())
+		}
 		// LeastLoad places new matches on the least-loaded peer; in single-
 		// node mode this trivially picks the local node. WithRelocationDisabled
 		// keeps a match pinned to its origin node — relocating an in-flight
 		// game's state to another node would just drop it on the floor, so
-		// we prefer to let the match die with its host.
+		// we prefer to let the match die with its host. The matches of one
+		// versus game may well land on different nodes.
 		pid, err := ctx.ActorSystem().SpawnOn(ctx.Context(), name, &MatchActor{},
 			actor.WithLongLived(),
 			actor.WithPlacement(actor.LeastLoad),
@@ -70,7 +98,73 @@ func (f *MatchFactory) Receive(ctx *actor.ReceiveContext) {
 		ctx.Logger().Infof("matchmaker: spawned %s (%s)", name, placement)
 		ctx.Response(&MatchCreated{MatchName: name})
 
+	case *JoinQueue:
+		f.joinQueue(ctx, msg)
+
+	case *LeaveQueue:
+		for players, queue := range f.queues {
+			if i := slices.IndexFunc(queue, func(e queueEntry) bool { return e.match == msg.Match }); i >= 0 {
+				f.queues[players] = slices.Delete(queue, i, i+1)
+				f.queueStatus(ctx, players)
+			}
+		}
+
 	default:
 		ctx.Unhandled()
 	}
 }
+
+// joinQueue adds or refreshes a waiting match and starts a game once
+// its queue holds enough players, longest-waiting first.
+func (f *MatchFactory) joinQueue(ctx *actor.ReceiveContext, msg *JoinQueue) {
+	if msg.Players < VersusMinPlayers || msg.Players > VersusMaxPlayers {
+		return
+	}
+
+	now := time.Now()
+	queue := slices.DeleteFunc(f.queues[msg.Players], func(e queueEntry) bool {
+		return now.Sub(e.seen) > queueEntryTTL
+	})
+	if i := slices.IndexFunc(queue, func(e queueEntry) bool { return e.match == msg.Match }); i >= 0 {
+		queue[i].seen = now
+	} else {
+		queue = append(queue, queueEntry{match: msg.Match, seen: now})
+	}
+	f.queues[msg.Players] = queue
+
+	if len(queue) < msg.Players {
+		f.queueStatus(ctx, msg.Players)
+		return
+	}
+
+	matches := make([]string, msg.Players)
+	for i, e := range queue[:msg.Players] {
+		matches[i] = e.match
+	}
+	f.queues[msg.Players] = slices.Delete(queue, 0, msg.Players)
+
+	start := &VersusStart{Game: uuid.NewString(), Seed: rand.Uint64(), Opponents: matches}
+	for _, name := range matches {
+		f.tell(ctx, name, start)
+	}
+	ctx.Logger().Infof("matchmaker: versus game %s: %v", start.Game, matches)
+	f.queueStatus(ctx, msg.Players)
+}
+
+// queueStatus tells every match in a queue how full it is.
+func (f *MatchFactory) queueStatus(ctx *actor.ReceiveContext, players int) {
+	queue := f.queues[players]
+	status := &QueueStatus{Queued: len(queue), Players: players}
+	for _, e := range queue {
+		f.tell(ctx, e.match, status)
+	}
+}
+
+func (f *MatchFactory) tell(ctx *actor.ReceiveContext, match string, msg any) {
+	pid, err := ctx.ActorSystem().ActorOf(ctx.Context(), match)
+	if err != nil {
+		ctx.Logger().Warnf("matchmaker: locate %s: %v", match, err)
+		return
+	}
+	ctx.Tell(pid, msg)
+}
@@ -0,0 +1,244 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tochemey/goakt/v4/actor"
+	"github.com/tochemey/goakt/v4/extension"
+)
+
+const ProfileStoreExtensionID = "tetris_profile_store"
+
+// profileSnapshot is a player's persisted profile. Bests holds the best
+// game of each leaderboard period the player has played in, keyed by
+// periodKey; a day or week that has ended is dropped on the next game.
+type profileSnapshot struct {
+	Name       string
+	Games      int
+	TotalLines int
+	Bests      map[string]Result
+}
+
+// profileStore is the backing for PlayerProfileGrain. Two implementations
+// ship in this repo: memProfileStore (process-local map) and pgProfileStore
+// (Postgres). Selection happens in main.go based on DATABASE_URL.
+type profileStore interface {
+	extension.Extension
+	Load(ctx context.Context, id string) (snap profileSnapshot, found bool, err error)
+	Save(ctx context.Context, id string, snap profileSnapshot) error
+}
+
+// memProfileStore keeps profiles in a process-local map.
+//
+// In cluster mode every node gets its own copy, and a profile grain
+// reactivated on another node starts from that node's copy — usually
+// none. Wire DATABASE_URL to use Postgres for profiles that survive
+// a node.
+type memProfileStore struct {
+	mu   sync.Mutex
+	data map[string]profileSnapshot
+}
+
+var _ profileStore = (*memProfileStore)(nil)
+
+func newMemProfileStore() *memProfileStore {
+	return &memProfileStore{data: make(map[string]profileSnapshot)}
+}
+
+func (s *memProfileStore) ID() string { return ProfileStoreExtensionID }
+
+func (s *memProfileStore) Load(_ context.Context, id string) (profileSnapshot, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap, ok := s.data[id]
+
+	return snap, ok, nil
+}
+
+func (s *memProfileStore) Save(_ context.Context, id string, snap profileSnapshot) error {
+	snap.Bests = maps.Clone(snap.Bests)
+
+	s.mu.Lock()
+	s.data[id] = snap
+	s.mu.Unlock()
+
+	return nil
+}
+
+func profileStoreFromExtension(system actor.ActorSystem) profileStore {
+	for _, ext := range system.Extensions() {
+		if ext.ID() == ProfileStoreExtensionID {
+			if store, ok := ext.(profileStore); ok {
+				return store
+			}
+		}
+	}
+
+	return nil
+}
+
+// profileGrain returns the identity of a player's PlayerProfileGrain,
+// activating it on some node of the cluster if need be.
+func profileGrain(ctx context.Context, system actor.ActorSystem, playerID string) (*actor.GrainIdentity, error) {
+	store := profileStoreFromExtension(system)
+
+	return system.GrainIdentity(ctx, GrainPrefix+playerID,
+		func(_ context.Context) (actor.Grain, error) {
+			return &PlayerProfileGrain{store: store}, nil
+		})
+}
+
+// PlayerProfileGrain is one virtual actor per player id. Its single
+// activation is the only writer of the player's bests, so two games
+// finishing at once on different nodes can't lose one another's result.
+type PlayerProfileGrain struct {
+	store profileStore
+	id    string
+	state profileSnapshot
+}
+
+var _ actor.Grain = (*PlayerProfileGrain)(nil)
+
+func (g *PlayerProfileGrain) OnActivate(ctx context.Context, props *actor.GrainProps) error {
+	g.id = strings.TrimPrefix(props.Identity().Name(), GrainPrefix)
+
+	snap, ok, err := g.store.Load(ctx, g.id)
+	switch {
+	case err != nil:
+		// Treat a load failure as "no profile yet" — the grain still
+		// activates with zero state. A persistence blip degrades the
+		// player to a fresh profile rather than taking them offline.
+		props.ActorSystem().Logger().Warnf("profile load failed for %s: %v", g.id, err)
+	case ok:
+		g.state = snap
+	}
+
+	return nil
+}
+
+func (g *PlayerProfileGrain) OnDeactivate(ctx context.Context, props *actor.GrainProps) error {
+	if err := g.store.Save(ctx, g.id, g.state); err != nil {
+		props.ActorSystem().Logger().Warnf("profile save failed for %s: %v", g.id, err)
+	}
+
+	return nil
+}
+
+func (g *PlayerProfileGrain) OnReceive(ctx *actor.GrainContext) {
+	switch msg := ctx.Message().(type) {
+	case *GetProfile:
+		ctx.Response(g.view(time.Now()))
+	case *RecordGame:
+		now := time.Now()
+		if msg.Name != "" {
+			g.state.Name = msg.Name
+		}
+		g.state.Games++
+		g.state.TotalLines += msg.Result.Lines
+
+		bests := make(map[string]Result, len(periods))
+		for _, period := range periods {
+			key := periodKey(period, now)
+			bests[key] = msg.Result
+			if best, ok := g.state.Bests[key]; ok && best.Score >= msg.Result.Score {
+				bests[key] = best
+			}
+		}
+		g.state.Bests = bests
+
+		// Save now rather than on deactivation, so a best survives a
+		// node that goes down before the grain is passivated.
+		if err := g.store.Save(ctx.Context(), g.id, g.state); err != nil {
+			ctx.ActorSystem().Logger().Warnf("profile save failed for %s: %v", g.id, err)
+		}
+		ctx.Response(g.view(now))
+	default:
+		ctx.Unhandled()
+	}
+}
+
+// view is the profile as of now: a day or week that has ended since
+// the player's last game reads as empty.
+func (g *PlayerProfileGrain) view(now time.Time) *ProfileView {
+	return &ProfileView{
+		PlayerID:   g.id,
+		Name:       g.state.Name,
+		Games:      g.state.Games,
+		TotalLines: g.state.TotalLines,
+		Best:       g.state.Bests[periodKey(PeriodAll, now)],
+		Today:      g.state.Bests[periodKey(PeriodDay, now)],
+		Week:       g.state.Bests[periodKey(PeriodWeek, now)],
+	}
+}
+
+// best is the player's best game of a period.
+func (v *ProfileView) best(period string) Result {
+	switch period {
+	case PeriodDay:
+		return v.Today
+	case PeriodWeek:
+		return v.Week
+	default:
+		return v.Best
+	}
+}
+
+// profileHandler serves GET /players/{id}: the player's ProfileView.
+// Asking for an unknown id activates an empty profile, which is what a
+// new player's browser gets on its first visit.
+func profileHandler(system actor.ActorSystem) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), leaderboardOpTimeout)
+		defer cancel()
+
+		ident, err := profileGrain(ctx, system, r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "profile unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		reply, err := system.AskGrain(ctx, ident, &GetProfile{}, leaderboardOpTimeout)
+		if err != nil {
+			http.Error(w, "profile unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		view, ok := reply.(*ProfileView)
+		if !ok {
+			http.Error(w, "unexpected profile reply", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(view)
+	}
+}
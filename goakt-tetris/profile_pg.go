@@ -0,0 +1,128 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// pgProfileStore persists PlayerProfileGrain state in Postgres so that
+// profiles survive node restarts and a grain reactivated on any node
+// picks up where the last activation left off.
+type pgProfileStore struct {
+	pool *pgxpool.Pool
+}
+
+var _ profileStore = (*pgProfileStore)(nil)
+
+const profileSchema = `
+CREATE TABLE IF NOT EXISTS tetris_profiles (
+    id          TEXT        PRIMARY KEY,
+    name        TEXT        NOT NULL DEFAULT '',
+    games       INTEGER     NOT NULL DEFAULT 0,
+    total_lines INTEGER     NOT NULL DEFAULT 0,
+    bests       JSONB       NOT NULL DEFAULT '{}',
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);`
+
+// newPgProfileStore connects to Postgres using the libpq-style URL,
+// runs the idempotent schema migration, and returns a store ready
+// for use. The caller owns lifecycle: call Close on shutdown.
+func newPgProfileStore(ctx context.Context, dsn string) (*pgProfileStore, error) {
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parse DATABASE_URL: %w", err)
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+
+	if _, err := pool.Exec(ctx, profileSchema); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("migrate: %w", err)
+	}
+
+	return &pgProfileStore{pool: pool}, nil
+}
+
+func (s *pgProfileStore) ID() string { return ProfileStoreExtensionID }
+
+func (s *pgProfileStore) Close() {
+	if s.pool != nil {
+		s.pool.Close()
+	}
+}
+
+func (s *pgProfileStore) Load(ctx context.Context, id string) (profileSnapshot, bool, error) {
+	const q = `SELECT name, games, total_lines, bests FROM tetris_profiles WHERE id = $1`
+
+	var (
+		snap  profileSnapshot
+		bests []byte
+	)
+	err := s.pool.QueryRow(ctx, q, id).Scan(&snap.Name, &snap.Games, &snap.TotalLines, &bests)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return profileSnapshot{}, false, nil
+	case err != nil:
+		return profileSnapshot{}, false, err
+	}
+
+	if err := json.Unmarshal(bests, &snap.Bests); err != nil {
+		return profileSnapshot{}, false, fmt.Errorf("decode bests: %w", err)
+	}
+
+	return snap, true, nil
+}
+
+func (s *pgProfileStore) Save(ctx context.Context, id string, snap profileSnapshot) error {
+	const q = `
+INSERT INTO tetris_profiles (id, name, games, total_lines, bests, updated_at)
+VALUES ($1, $2, $3, $4, $5, now())
+ON CONFLICT (id) DO UPDATE SET
+    name        = EXCLUDED.name,
+    games       = EXCLUDED.games,
+    total_lines = EXCLUDED.total_lines,
+    bests       = EXCLUDED.bests,
+    updated_at  = now();`
+
+	bests, err := json.Marshal(snap.Bests)
+	if err != nil {
+		return fmt.Errorf("encode bests: %w", err)
+	}
+	if snap.Bests == nil {
+		bests = []byte("{}")
+	}
+
+	_, err = s.pool.Exec(ctx, q, id, snap.Name, snap.Games, snap.TotalLines, bests)
+
+	return err
+}
@@ -0,0 +1,359 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tochemey/goakt/v4/actor"
+	"github.com/tochemey/goakt/v4/extension"
+)
+
+const (
+	ReplayStoreExtensionID = "tetris_replay_store"
+
+	// maxReplays bounds the in-memory store; the oldest replay is
+	// evicted once it is full.
+	maxReplays = 1024
+
+	// replayStoreTimeout bounds the store calls made from Receive and
+	// from the HTTP handlers.
+	replayStoreTimeout = 2 * time.Second
+
+	// topReplays is how many replays GET /replays lists.
+	topReplays = 20
+)
+
+// replayStore keeps finished games' replays. It is an actor-system
+// extension so matches, sessions and the HTTP handlers all reach the
+// same store; memReplayStore is the backend that ships.
+type replayStore interface {
+	extension.Extension
+	Save(ctx context.Context, replay *Replay) error
+	Get(ctx context.Context, id string) (replay *Replay, found bool, err error)
+	// Top returns up to n replays, highest score first.
+	Top(ctx context.Context, n int) ([]*Replay, error)
+}
+
+// memReplayStore keeps replays in a process-local map.
+//
+// The match saves each replay on its own node, and the player's
+// PlayerSessionActor saves the copy carried on GameRecorded, so a player
+// always finds their games on the node their browser is connected to.
+type memReplayStore struct {
+	mu      sync.Mutex
+	replays map[string]*Replay
+	order   []string
+}
+
+var _ replayStore = (*memReplayStore)(nil)
+
+func newMemReplayStore() *memReplayStore {
+	return &memReplayStore{replays: make(map[string]*Replay)}
+}
+
+func (s *memReplayStore) ID() string { return ReplayStoreExtensionID }
+
+// Save stores a replay. Saving the same replay twice keeps the first
+// copy.
+func (s *memReplayStore) Save(_ context.Context, replay *Replay) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.replays[replay.ID]; ok {
+		return nil
+	}
+
+	if len(s.order) >= maxReplays {
+		delete(s.replays, s.order[0])
+		s.order = s.order[1:]
+	}
+
+	s.replays[replay.ID] = replay
+	s.order = append(s.order, replay.ID)
+
+	return nil
+}
+
+func (s *memReplayStore) Get(_ context.Context, id string) (*Replay, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	replay, ok := s.replays[id]
+
+	return replay, ok, nil
+}
+
+func (s *memReplayStore) Top(_ context.Context, n int) ([]*Replay, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]*Replay, 0, len(s.replays))
+	for _, replay := range s.replays {
+		out = append(out, replay)
+	}
+	slices.SortFunc(out, func(a, b *Replay) int {
+		if a.Score != b.Score {
+			return cmp.Compare(b.Score, a.Score)
+		}
+		return cmp.Compare(a.Started, b.Started)
+	})
+
+	return out[:min(n, len(out))], nil
+}
+
+func replayStoreFromExtension(system actor.ActorSystem) replayStore {
+	for _, ext := range system.Extensions() {
+		if ext.ID() == ReplayStoreExtensionID {
+			if store, ok := ext.(replayStore); ok {
+				return store
+			}
+		}
+	}
+
+	return nil
+}
+
+// saveReplay stores a replay in this node's store, if it has one.
+func saveReplay(system actor.ActorSystem, replay *Replay) {
+	store := replayStoreFromExtension(system)
+	if store == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), replayStoreTimeout)
+	defer cancel()
+
+	if err := store.Save(ctx, replay); err != nil {
+		system.Logger().Warnf("replay save failed for %s: %v", replay.ID, err)
+	}
+}
+
+// deal seeds the piece RNG for a new game. The seed goes in the replay.
+func (m *MatchActor) deal(seed uint64) {
+	m.seed = seed
+	m.rng = rand.New(rand.NewPCG(seed, 0))
+}
+
+// startReplay begins recording the game that reset just set up.
+func (m *MatchActor) startReplay(mode string) {
+	m.rec = &Replay{
+		ID:      uuid.NewString(),
+		Mode:    mode,
+		Seed:    m.seed,
+		Started: time.Now().UnixMilli(),
+	}
+}
+
+// record appends an event at the current tick. Events are only recorded
+// while the board can act on them — not during a versus countdown, not
+// after game over — so replaying them never applies one the live match
+// ignored.
+func (m *MatchActor) record(event ReplayEvent) {
+	if m.rec == nil || m.gameOver || !(m.playing() || m.paused) {
+		return
+	}
+	event.Tick = m.tickN
+	m.rec.Events = append(m.rec.Events, event)
+}
+
+// finishReplay closes the recording once the game is over, stores it
+// and hands it to the subscribers for their own node's store.
+func (m *MatchActor) finishReplay(ctx *actor.ReceiveContext) {
+	replay := m.closeReplay()
+	if replay == nil {
+		return
+	}
+
+	saveReplay(ctx.ActorSystem(), replay)
+	recorded := &GameRecorded{Replay: *replay}
+	for _, sub := range m.subs {
+		ctx.Tell(sub, recorded)
+	}
+}
+
+// closeReplay ends the recording of a finished game and returns it, or
+// returns nil while the game is running or nothing is being recorded.
+func (m *MatchActor) closeReplay() *Replay {
+	if m.rec == nil || !m.gameOver {
+		return nil
+	}
+
+	replay := m.rec
+	m.rec = nil
+	replay.Ticks = m.tickN
+	replay.Score = m.score
+	replay.Lines = m.lines
+	replay.Level = m.level
+	m.replayID = replay.ID
+
+	return replay
+}
+
+// ghost steps a recorded game alongside a live one. It is a MatchActor
+// used as a plain state machine: never spawned, fed the replay's events
+// instead of messages.
+type ghost struct {
+	replay *Replay
+	board  *MatchActor
+	next   int // index of the next event to apply
+}
+
+func newGhost(replay *Replay) *ghost {
+	board := &MatchActor{}
+	board.deal(replay.Seed)
+	if replay.Mode == ModeVersus {
+		// The garbage events need somewhere to land. The game id only has
+		// to be non-empty for the board to count as live.
+		board.vs = &versusState{game: replay.ID, players: VersusMinPlayers, holes: rand.New(rand.NewPCG(replay.Seed, 1))}
+	}
+	board.reset()
+	return &ghost{replay: replay, board: board}
+}
+
+// done reports whether the ghost has played its whole game.
+func (g *ghost) done() bool {
+	b := g.board
+	return b.gameOver || (b.tickN >= g.replay.Ticks && g.next == len(g.replay.Events))
+}
+
+// step applies the events due at the ghost's current tick and, if the
+// board is still running, steps it. It reports false once the events
+// can no longer advance the board: the game ended, or the recording
+// stops while paused. The events of the last tick are applied even
+// though no step follows them — the hard drop that tops out, say.
+func (g *ghost) step() bool {
+	b := g.board
+	if b.gameOver {
+		return false
+	}
+
+	for g.next < len(g.replay.Events) && g.replay.Events[g.next].Tick <= b.tickN {
+		event := g.replay.Events[g.next]
+		g.next++
+		switch {
+		case event.Action != "":
+			b.handleInput(event.Action)
+		case event.Garbage > 0 && b.vs != nil:
+			b.queueGarbage(event.Garbage)
+		}
+	}
+
+	if !b.playing() || b.tickN >= g.replay.Ticks {
+		return false
+	}
+	b.step()
+	return true
+}
+
+// view is the ghost's board as the live player sees it.
+func (g *ghost) view() *OpponentView {
+	b := g.board
+	return &OpponentView{
+		ID:    g.replay.ID,
+		Grid:  b.gridCopy(),
+		Piece: b.piece,
+		Score: b.score,
+		Lines: b.lines,
+		Out:   g.done(),
+	}
+}
+
+// simulate re-runs a replay from its seed and events and returns the
+// final board.
+func simulate(replay *Replay) *MatchActor {
+	g := newGhost(replay)
+	for g.step() {
+	}
+	return g.board
+}
+
+// replaysHandler serves GET /replays: the best replays on this node,
+// without their events.
+func replaysHandler(store replayStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), replayStoreTimeout)
+		defer cancel()
+
+		replays, err := store.Top(ctx, topReplays)
+		if err != nil {
+			http.Error(w, "replay store unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		out := make([]Replay, len(replays))
+		for i, replay := range replays {
+			out[i] = *replay
+			out[i].Events = nil
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"replays": out})
+	}
+}
+
+// replayHandler serves GET /replays/{id}: the replay with its events,
+// re-simulated on the spot. Verified is false if the rebuilt game
+// doesn't end where the live match said it did — a tampered record, or
+// a change to the game rules since it was played.
+func replayHandler(store replayStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), replayStoreTimeout)
+		defer cancel()
+
+		replay, ok, err := store.Get(ctx, r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "replay store unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		board := simulate(replay)
+		verified := board.tickN == replay.Ticks && board.score == replay.Score &&
+			board.lines == replay.Lines && board.level == replay.Level
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"replay":   replay,
+			"verified": verified,
+			"simulated": map[string]any{
+				"ticks": board.tickN,
+				"score": board.score,
+				"lines": board.lines,
+				"level": board.level,
+				"grid":  board.gridCopy(),
+			},
+		})
+	}
+}
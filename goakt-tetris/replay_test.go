@@ -0,0 +1,194 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// scriptedActions are the inputs the scripts pick from. Pause is
+// scripted separately so a pause lasts a while.
+var scriptedActions = []string{
+	ActionLeft, ActionRight, ActionRotate, ActionRotateCCW, ActionHold,
+	ActionSoftDrop, ActionSoftDropEnd, ActionHardDrop,
+}
+
+// liveTick is one step of Receive's *tick case without an actor
+// system: the versus clock, then the board. versusTick only uses its
+// context to queue for a game, which a started game never does.
+func liveTick(m *MatchActor) {
+	if m.vs != nil {
+		m.versusTick(nil)
+	}
+	if m.playing() {
+		m.step()
+	}
+}
+
+// play drives a match until it tops out: each wall-clock tick steps
+// the board, then script may send it an input, a pause or garbage.
+func play(t *testing.T, m *MatchActor, script func(wall int)) *Replay {
+	t.Helper()
+
+	for wall := 0; !m.gameOver; wall++ {
+		if wall > 200_000 {
+			t.Fatal("game never ended")
+		}
+		liveTick(m)
+		script(wall)
+	}
+
+	replay := m.closeReplay()
+	if replay == nil {
+		t.Fatal("no replay recorded")
+	}
+	return replay
+}
+
+// randomScript plays random inputs with the odd pause, during which it
+// keeps pressing keys the board must ignore; with garbage set it also
+// sends attacks.
+func randomScript(m *MatchActor, seed uint64, garbage bool) func(int) {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	pausedFor := 0
+	return func(int) {
+		switch {
+		case pausedFor > 0:
+			pausedFor--
+			if pausedFor == 0 {
+				m.handleInput(ActionPause)
+			}
+		case rng.IntN(300) == 0:
+			m.handleInput(ActionPause)
+			pausedFor = 20 + rng.IntN(60)
+		}
+		if rng.IntN(6) == 0 {
+			m.handleInput(scriptedActions[rng.IntN(len(scriptedActions))])
+		}
+		if garbage && rng.IntN(120) == 0 {
+			m.queueGarbage(1 + rng.IntN(3))
+		}
+	}
+}
+
+func newSoloMatch(seed uint64) *MatchActor {
+	m := &MatchActor{}
+	m.deal(seed)
+	m.reset()
+	m.startReplay(ModeSolo)
+	return m
+}
+
+// newVersusMatch starts a versus board with no opponents, so it plays
+// on until it tops out instead of winning or waiting on frames.
+func newVersusMatch(seed uint64) *MatchActor {
+	m := &MatchActor{vs: &versusState{self: "a", players: VersusMinPlayers}}
+	m.startVersus(&VersusStart{Game: "game", Seed: seed, Opponents: []string{"a"}})
+	return m
+}
+
+// checkSimulated re-runs the replay and compares it with the live game.
+func checkSimulated(t *testing.T, live *MatchActor, replay *Replay) {
+	t.Helper()
+
+	if replay.Ticks != live.tickN || replay.Score != live.score || replay.Lines != live.lines || replay.Level != live.level {
+		t.Fatalf("replay %+v does not match the live game", replay)
+	}
+
+	board := simulate(replay)
+	if board.tickN != replay.Ticks || board.score != replay.Score ||
+		board.lines != replay.Lines || board.level != replay.Level {
+		t.Errorf("simulated ticks/score/lines/level %d/%d/%d/%d, live %d/%d/%d/%d",
+			board.tickN, board.score, board.lines, board.level,
+			replay.Ticks, replay.Score, replay.Lines, replay.Level)
+	}
+	if !board.gameOver {
+		t.Error("simulated game did not end")
+	}
+	if board.grid != live.grid {
+		t.Error("simulated grid differs from the live one")
+	}
+}
+
+func TestSimulateReproducesSoloGame(t *testing.T) {
+	for seed := range uint64(5) {
+		m := newSoloMatch(seed)
+		replay := play(t, m, randomScript(m, seed, false))
+		checkSimulated(t, m, replay)
+	}
+}
+
+func TestSimulateReproducesVersusGameWithGarbage(t *testing.T) {
+	for seed := range uint64(5) {
+		m := newVersusMatch(seed)
+		replay := play(t, m, randomScript(m, seed, true))
+
+		garbage := 0
+		for _, event := range replay.Events {
+			garbage += event.Garbage
+		}
+		if garbage == 0 {
+			t.Fatalf("seed %d: no garbage recorded", seed)
+		}
+		checkSimulated(t, m, replay)
+	}
+}
+
+// A hard drop that tops out ends the game on the tick it was pressed,
+// with no step after it.
+func TestSimulateReproducesHardDropTopOut(t *testing.T) {
+	m := newSoloMatch(1)
+	replay := play(t, m, func(wall int) {
+		if wall%5 == 0 {
+			m.handleInput(ActionHardDrop)
+		}
+	})
+
+	last := replay.Events[len(replay.Events)-1]
+	if last.Action != ActionHardDrop || last.Tick != replay.Ticks {
+		t.Fatalf("last event %+v, want the topping-out hard drop at tick %d", last, replay.Ticks)
+	}
+	checkSimulated(t, m, replay)
+}
+
+// Inputs during a versus countdown are not recorded: the ghost starts
+// live, and must not apply what the live board ignored.
+func TestSimulateIgnoresVersusCountdown(t *testing.T) {
+	m := newVersusMatch(3)
+	replay := play(t, m, func(wall int) {
+		switch {
+		case m.vs.countdown > 0:
+			m.handleInput(ActionLeft)
+		case wall%7 == 0:
+			m.handleInput(ActionHardDrop)
+		}
+	})
+
+	for _, event := range replay.Events {
+		if event.Action == ActionLeft {
+			t.Fatalf("countdown input recorded: %+v", event)
+		}
+	}
+	checkSimulated(t, m, replay)
+}
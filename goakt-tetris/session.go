@@ -43,8 +43,12 @@ const writeBudget = 50 * time.Millisecond
 // (which has to block in conn.Read) lives in the gateway and Tells this
 // actor a *closed{} when the socket ends.
 type PlayerSessionActor struct {
-	match *actor.PID
-	conn  *websocket.Conn
+	match    *actor.PID
+	conn     *websocket.Conn
+	playerID string  // from ?id=, who finished games are recorded for
+	name     string  // display name, from ?name=
+	preview  int     // preview queue length, from ?preview=
+	ghost    *Replay // the recorded game to race, from ?ghost=
 }
 
 var _ actor.Actor = (*PlayerSessionActor)(nil)
@@ -53,9 +57,12 @@ func (*PlayerSessionActor) PreStart(*actor.Context) error { return nil }
 func (*PlayerSessionActor) PostStop(*actor.Context) error { return nil }
 
 func (p *PlayerSessionActor) Receive(ctx *actor.ReceiveContext) {
-	switch ctx.Message().(type) {
+	switch msg := ctx.Message().(type) {
 	case *actor.PostStart:
-		ctx.Tell(p.match, &Subscribe{})
+		ctx.Tell(p.match, &Subscribe{Preview: p.preview})
+		if p.ghost != nil {
+			ctx.Tell(p.match, &RaceGhost{Replay: *p.ghost})
+		}
 
 	case *PlayerInput:
 		ctx.Tell(p.match, ctx.Message())
@@ -77,6 +84,12 @@ func (p *PlayerSessionActor) Receive(ctx *actor.ReceiveContext) {
 			ctx.Shutdown()
 		}
 
+	case *GameRecorded:
+		// The match has stored this on its own node already; keep a copy
+		// where the player's browser will ask for it.
+		saveReplay(ctx.ActorSystem(), &msg.Replay)
+		p.recordGame(ctx, &msg.Replay)
+
 	case *closed:
 		ctx.Shutdown()
 
@@ -85,5 +98,21 @@ func (p *PlayerSessionActor) Receive(ctx *actor.ReceiveContext) {
 	}
 }
 
+// recordGame adds a finished game to the player's profile and the
+// leaderboard. The grain and CRDT round-trips run off the actor's
+// goroutine so snapshot writes are not held up behind them.
+func (p *PlayerSessionActor) recordGame(ctx *actor.ReceiveContext, replay *Replay) {
+	leaderboard := leaderboardFromExtension(ctx.ActorSystem())
+	if leaderboard == nil {
+		return
+	}
+
+	playerID, name := p.playerID, p.name
+	result := Result{Score: replay.Score, Lines: replay.Lines, Level: replay.Level}
+	ctx.PipeTo(ctx.Self(), func() (any, error) {
+		return nil, leaderboard.RecordGame(context.Background(), playerID, name, result)
+	})
+}
+
 // closed is sent by the gateway reader goroutine when the WS connection ends.
 type closed struct{}
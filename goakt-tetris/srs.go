@@ -0,0 +1,126 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+// SRS wall kicks, as published in the Tetris guideline: for each
+// starting orientation (spawn, R, 2, L) and direction (clockwise, then
+// counter-clockwise), the five (dx, dy) offsets to try in order. The
+// first is always (0, 0), a plain rotation. The guideline writes dy
+// pointing up, and so do these tables; rotate flips it for the board.
+var (
+	jlstzKicks = [4][2][5][2]int{
+		{{{0, 0}, {-1, 0}, {-1, 1}, {0, -2}, {-1, -2}}, {{0, 0}, {1, 0}, {1, 1}, {0, -2}, {1, -2}}},  // 0→R, 0→L
+		{{{0, 0}, {1, 0}, {1, -1}, {0, 2}, {1, 2}}, {{0, 0}, {1, 0}, {1, -1}, {0, 2}, {1, 2}}},       // R→2, R→0
+		{{{0, 0}, {1, 0}, {1, 1}, {0, -2}, {1, -2}}, {{0, 0}, {-1, 0}, {-1, 1}, {0, -2}, {-1, -2}}},  // 2→L, 2→R
+		{{{0, 0}, {-1, 0}, {-1, -1}, {0, 2}, {-1, 2}}, {{0, 0}, {-1, 0}, {-1, -1}, {0, 2}, {-1, 2}}}, // L→0, L→2
+	}
+	iKicks = [4][2][5][2]int{
+		{{{0, 0}, {-2, 0}, {1, 0}, {-2, -1}, {1, 2}}, {{0, 0}, {-1, 0}, {2, 0}, {-1, 2}, {2, -1}}}, // 0→R, 0→L
+		{{{0, 0}, {-1, 0}, {2, 0}, {-1, 2}, {2, -1}}, {{0, 0}, {2, 0}, {-1, 0}, {2, 1}, {-1, -2}}}, // R→2, R→0
+		{{{0, 0}, {2, 0}, {-1, 0}, {2, 1}, {-1, -2}}, {{0, 0}, {1, 0}, {-2, 0}, {1, -2}, {-2, 1}}}, // 2→L, 2→R
+		{{{0, 0}, {1, 0}, {-2, 0}, {1, -2}, {-2, 1}}, {{0, 0}, {-2, 0}, {1, 0}, {-2, -1}, {1, 2}}}, // L→0, L→2
+	}
+)
+
+// tspinKind classifies the lock of a T piece for scoring and attack.
+type tspinKind int
+
+const (
+	tspinNone tspinKind = iota
+	tspinMini
+	tspinFull
+)
+
+// tFront lists, per T orientation, the two corners diagonal to the
+// T's centre on the side it points to.
+var tFront = [4][2][2]int{
+	{{-1, -1}, {1, -1}}, // pointing up
+	{{1, -1}, {1, 1}},   // right
+	{{-1, 1}, {1, 1}},   // down
+	{{-1, -1}, {-1, 1}}, // left
+}
+
+// rotate turns the piece a quarter turn, clockwise for dir = 1 and
+// counter-clockwise for dir = -1, taking the first SRS kick that fits.
+// The O piece doesn't turn.
+func (m *MatchActor) rotate(dir int) bool {
+	if m.piece.Kind == kindO {
+		return false
+	}
+
+	kicks := &jlstzKicks
+	if m.piece.Kind == kindI {
+		kicks = &iKicks
+	}
+	way := 0
+	if dir < 0 {
+		way = 1
+	}
+
+	for i, kick := range kicks[m.piece.Rot][way] {
+		next := m.piece
+		next.Rot = (next.Rot + dir + 4) % 4
+		next.X += kick[0]
+		next.Y -= kick[1]
+		if m.canPlace(next) {
+			m.piece = next
+			m.lastRotate = true
+			m.lastKick = i
+			return true
+		}
+	}
+	return false
+}
+
+// tSpin classifies the piece about to lock. It is a T-spin if it is a
+// T whose last move was a rotation, with at least three of the four
+// cells diagonal to its centre blocked; the walls and floor count as
+// blocked. It is a full T-spin if both corners it points to are among
+// them, or if the rotation needed the last kick test (the one that
+// lifts a T into a T-spin Triple slot); otherwise a Mini.
+func (m *MatchActor) tSpin() tspinKind {
+	if m.piece.Kind != kindT || !m.lastRotate {
+		return tspinNone
+	}
+
+	blocked := func(d [2]int) bool {
+		x, y := m.piece.X+d[0], m.piece.Y+d[1]
+		return x < 0 || x >= BoardW || y >= BoardH || (y >= 0 && m.grid[y][x] != 0)
+	}
+
+	corners := 0
+	for _, d := range [4][2]int{{-1, -1}, {1, -1}, {-1, 1}, {1, 1}} {
+		if blocked(d) {
+			corners++
+		}
+	}
+	if corners < 3 {
+		return tspinNone
+	}
+
+	front := tFront[m.piece.Rot]
+	if (blocked(front[0]) && blocked(front[1])) || m.lastKick == 4 {
+		return tspinFull
+	}
+	return tspinMini
+}
@@ -0,0 +1,186 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tochemey/goakt/v4/actor"
+	"github.com/tochemey/goakt/v4/extension"
+)
+
+const (
+	TickerExtensionID = "tetris_ticker"
+
+	tickInterval = 16 * time.Millisecond // 60 Hz — keeps input snappy
+
+	// tickerActorPrefix names each node's TickerActor; the rest is a
+	// UUID, since actor names are unique cluster-wide.
+	tickerActorPrefix = "ticker."
+
+	// schedRefPrefix is combined with the ticker's own name to produce a
+	// globally-unique reference for its schedule. (`Schedule` references
+	// collide system-wide, so this MUST be unique per node.)
+	schedRefPrefix = "tick."
+
+	// maxCatchUp bounds the board steps one tick may carry. A node that
+	// falls further behind than that lets the games slow down rather
+	// than jump.
+	maxCatchUp = 4
+)
+
+// Ticker is this node's handle on its TickerActor, found by every
+// MatchActor through the system's extensions. The actor is spawned by
+// whoever asks for it first: main right after the system starts, or a
+// match a peer placed here as soon as the node joined the cluster.
+type Ticker struct {
+	mu  sync.Mutex
+	pid *actor.PID
+}
+
+var _ extension.Extension = (*Ticker)(nil)
+
+func NewTicker() *Ticker { return &Ticker{} }
+
+func (t *Ticker) ID() string { return TickerExtensionID }
+
+// PID returns the node's TickerActor, spawning it if it is not running.
+// It is called from main and from the matches' own goroutines.
+func (t *Ticker) PID(ctx context.Context, system actor.ActorSystem) (*actor.PID, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.pid != nil && t.pid.IsRunning() {
+		return t.pid, nil
+	}
+
+	pid, err := system.Spawn(ctx, tickerActorPrefix+uuid.NewString(), &TickerActor{}, actor.WithLongLived())
+	if err != nil {
+		return nil, fmt.Errorf("spawn ticker: %w", err)
+	}
+	t.pid = pid
+	return pid, nil
+}
+
+func tickerFromExtension(system actor.ActorSystem) *Ticker {
+	for _, ext := range system.Extensions() {
+		if ext.ID() == TickerExtensionID {
+			if ticker, ok := ext.(*Ticker); ok {
+				return ticker
+			}
+		}
+	}
+
+	return nil
+}
+
+// TickerActor drives every MatchActor on its node from one recurring
+// schedule: each pulse it Tells every registered match a tick. A node
+// with a thousand matches runs one timer, not a thousand.
+//
+// The tick rate adapts to load. A pulse that arrives late — the node
+// was busy, or the ticker's mailbox backed up — carries the steps owed
+// since the last one, up to maxCatchUp, so games keep to the clock
+// with fewer, larger ticks; pulses that queued up behind it then carry
+// none and are dropped.
+//
+// Matches register with tickSubscribe. Both sides live on the same
+// node, so Watch drops a match from the list when it stops, and each
+// match watches the ticker in turn: if the ticker stops, its matches
+// subscribe to the one Ticker.PID spawns in its place.
+type TickerActor struct {
+	matches  []*actor.PID
+	schedRef string
+	last     time.Time // when the steps sent so far were due
+}
+
+var _ actor.Actor = (*TickerActor)(nil)
+
+func (*TickerActor) PreStart(*actor.Context) error { return nil }
+
+// PostStop cancels the recurring pulse so no further messages are
+// scheduled to a stopped actor.
+func (t *TickerActor) PostStop(ctx *actor.Context) error {
+	if t.schedRef != "" {
+		_ = ctx.ActorSystem().CancelSchedule(t.schedRef)
+	}
+	return nil
+}
+
+func (t *TickerActor) Receive(ctx *actor.ReceiveContext) {
+	switch msg := ctx.Message().(type) {
+	case *actor.PostStart:
+		t.last = time.Now()
+		t.schedRef = schedRefPrefix + ctx.Self().Name()
+		if err := ctx.ActorSystem().Schedule(ctx.Context(), &pulse{}, ctx.Self(),
+			tickInterval, actor.WithReference(t.schedRef)); err != nil {
+			ctx.Err(err)
+		}
+
+	case *tickSubscribe:
+		sender := ctx.Sender()
+		t.matches = append(t.matches, sender)
+		ctx.Watch(sender)
+
+	case *actor.Terminated:
+		for i, pid := range t.matches {
+			if pid.Path().Equals(msg.ActorPath()) {
+				t.matches = append(t.matches[:i], t.matches[i+1:]...)
+				break
+			}
+		}
+
+	case *pulse:
+		steps := t.owed(time.Now())
+		if steps == 0 {
+			return
+		}
+		next := &tick{steps: steps}
+		for _, pid := range t.matches {
+			ctx.Tell(pid, next)
+		}
+
+	default:
+		ctx.Unhandled()
+	}
+}
+
+// owed returns how many board steps are due at now, rounding to the
+// nearest so that timer jitter doesn't alternate empty and double
+// ticks.
+func (t *TickerActor) owed(now time.Time) int {
+	steps := int((now.Sub(t.last) + tickInterval/2) / tickInterval)
+	switch {
+	case steps == 0:
+	case steps > maxCatchUp:
+		steps = maxCatchUp
+		t.last = now
+	default:
+		t.last = t.last.Add(time.Duration(steps) * tickInterval)
+	}
+	return steps
+}
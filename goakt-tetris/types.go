@@ -36,9 +36,36 @@ const MessageTypeInput = "input"
 // Gateway goroutines resolve this via ActorOf to request a fresh match.
 const MatchmakerActorName = "matchmaker"
 
+// GrainPrefix scopes the player-profile grain names so they can't
+// collide with actor names: "tetris.profile.<player id>".
+const GrainPrefix = "tetris.profile."
+
+// Leaderboard periods, as GET /leaderboard?period= spells them. Days
+// and weeks (ISO, Monday first) are in UTC.
+const (
+	PeriodDay  = "day"
+	PeriodWeek = "week"
+	PeriodAll  = "all"
+)
+
+// Game modes, as the /ws ?mode= query and CreateMatch.Mode spell them.
+// A versus match is linked to 1..VersusMaxPlayers-1 opponents' matches,
+// possibly on other nodes; see versus.go.
+const (
+	ModeSolo   = "solo"
+	ModeVersus = "versus"
+
+	VersusMinPlayers = 2
+	VersusMaxPlayers = 4
+)
+
 // CreateMatch is the Ask-message sent by the gateway to the matchmaker
-// when a new WS connection arrives. Response is *MatchCreated.
-type CreateMatch struct{}
+// when a new WS connection arrives. Response is *MatchCreated. Players
+// is the versus game size and is ignored for solo matches.
+type CreateMatch struct {
+	Mode    string `json:"mode"`
+	Players int    `json:"players"`
+}
 
 // MatchCreated is the matchmaker's reply to CreateMatch. The gateway uses
 // the name to resolve a usable *PID via ActorOf — SpawnOn may have placed
@@ -53,7 +80,9 @@ type MatchCreated struct {
 const (
 	ActionLeft        = "left"
 	ActionRight       = "right"
-	ActionRotate      = "rotate"
+	ActionRotate      = "rotate" // clockwise
+	ActionRotateCCW   = "rotate-ccw"
+	ActionHold        = "hold"
 	ActionSoftDrop    = "softdrop"
 	ActionSoftDropEnd = "softdrop-end"
 	ActionHardDrop    = "harddrop"
@@ -63,10 +92,13 @@ const (
 
 // PlayerInput is sent by the browser on every keypress (and on key release
 // for softdrop). Type discriminates the inbound message kind; Action is
-// one of the Action* constants above.
+// one of the Action* constants above. Seq numbers the inputs of one
+// connection; frames acknowledge the highest applied so the client can
+// reconcile the moves it predicted.
 type PlayerInput struct {
 	Type   string `json:"type"`
 	Action string `json:"action"`
+	Seq    uint32 `json:"seq"`
 }
 
 // PieceState is the falling tetromino. Cells are derived from Kind+Rot via
@@ -74,38 +106,227 @@ type PlayerInput struct {
 // tiny (4 ints instead of 4 cell coordinates).
 type PieceState struct {
 	Kind int `json:"kind"` // 0..6 = I, O, T, S, Z, J, L
-	Rot  int `json:"rot"`  // 0..3
+	Rot  int `json:"rot"`  // 0..3: spawn, R, 2, L (SRS orientations)
 	X    int `json:"x"`    // pivot column
 	Y    int `json:"y"`    // pivot row, 0 = top
 }
 
-// Snapshot is the wire payload sent every tick. Grid is a flat 2-D slice;
-// 0 = empty cell, 1..7 = piece kind that filled the cell (for color).
+// Snapshot is the wire payload sent on every tick that changed something.
+// A keyframe (Key) carries the whole Grid, a flat 2-D slice; 0 = empty
+// cell, 1..7 = piece kind that filled the cell (for color), 8 = a
+// garbage row sent by a versus opponent. The frames in between carry
+// only the Rows that changed, and Opponents and Ghost only when they
+// did; every other field is always present. Ack is the highest input
+// Seq the match has applied.
 // GhostY is the y-coordinate the piece would land at if hard-dropped — the
-// client renders an outline there as a landing hint.
+// client renders an outline there as a landing hint. Next is the preview
+// queue, nearest first, as long as the player asked for; Hold is the
+// held kind (-1 = none), and CanHold is false once this piece has used
+// its hold.
+//
+// The versus fields are empty in solo play. Waiting is set while the
+// matchmaker gathers players (Queued of Needed so far); Countdown is the
+// seconds left before the linked game starts. Pending is the garbage
+// queued against this board, Attack names the last clear that sent any,
+// and Place is the finishing position once the game is over (1 = won).
+//
+// Replay is the id the finished game was recorded under (see replay.go);
+// Ghost is the recorded game being raced, stepped alongside this one.
 type Snapshot struct {
 	Tick     int        `json:"tick"`
 	T        int64      `json:"t"`
-	Grid     [][]int8   `json:"grid"`
+	Key      bool       `json:"key,omitempty"`
+	Grid     [][]int8   `json:"grid,omitempty"`
+	Rows     []GridRow  `json:"rows,omitempty"`
+	Ack      uint32     `json:"ack"`
 	Piece    PieceState `json:"piece"`
 	GhostY   int        `json:"ghostY"`
-	Next     int        `json:"next"`
+	Next     []int      `json:"next"`
+	Hold     int        `json:"hold"`
+	CanHold  bool       `json:"canHold"`
 	Score    int        `json:"score"`
 	Lines    int        `json:"lines"`
 	Level    int        `json:"level"`
 	GameOver bool       `json:"gameOver"`
 	Paused   bool       `json:"paused"`
+
+	Mode      string         `json:"mode"`
+	Waiting   bool           `json:"waiting,omitempty"`
+	Queued    int            `json:"queued,omitempty"`
+	Needed    int            `json:"needed,omitempty"`
+	Countdown int            `json:"countdown,omitempty"`
+	Pending   int            `json:"pending,omitempty"`
+	Attack    string         `json:"attack,omitempty"`
+	Place     int            `json:"place,omitempty"`
+	Opponents []OpponentView `json:"opponents,omitempty"`
+
+	Replay string        `json:"replay,omitempty"`
+	Ghost  *OpponentView `json:"ghost,omitempty"`
 }
 
-// tick is the internal scheduled message that drives the gravity loop.
-type tick struct{}
+// GridRow is one grid row of a delta frame.
+type GridRow struct {
+	Y     int    `json:"y"`
+	Cells []int8 `json:"cells"`
+}
+
+// OpponentView is one linked opponent's board as last reported by its
+// match. Out is set once that player's game is over — topped out, left,
+// or won — and Place is where they finished.
+type OpponentView struct {
+	ID      string     `json:"id"`
+	Grid    [][]int8   `json:"grid"`
+	Piece   PieceState `json:"piece"`
+	Score   int        `json:"score"`
+	Lines   int        `json:"lines"`
+	Pending int        `json:"pending"`
+	Out     bool       `json:"out"`
+	Place   int        `json:"place,omitempty"`
+}
+
+// JoinQueue is a versus match's Tell to the matchmaker once its player
+// has connected. It is repeated while the match waits, so a matchmaker
+// restarted by singleton failover rebuilds its queue. The matchmaker
+// replies to the sender with QueueStatus, then VersusStart.
+type JoinQueue struct {
+	Match   string `json:"match"`
+	Players int    `json:"players"`
+}
+
+// LeaveQueue takes a waiting match back out of the queue; sent from
+// MatchActor.PostStop.
+type LeaveQueue struct {
+	Match string `json:"match"`
+}
+
+// QueueStatus tells each waiting match how full its queue is.
+type QueueStatus struct {
+	Queued  int `json:"queued"`
+	Players int `json:"players"`
+}
+
+// VersusStart links a match to its opponents, by match name: every
+// match of a game gets the same Game id and Seed, so all players are
+// dealt the same pieces.
+type VersusStart struct {
+	Game      string   `json:"game"`
+	Seed      uint64   `json:"seed"`
+	Opponents []string `json:"opponents"`
+}
+
+// VersusFrame is a match's board, sent to each of its opponents a few
+// times a second, and at once when the player tops out or leaves.
+type VersusFrame struct {
+	Game  string       `json:"game"`
+	Board OpponentView `json:"board"`
+}
+
+// Garbage is an attack: Lines garbage rows sent by the match named
+// From to the receiving match.
+type Garbage struct {
+	Game  string `json:"game"`
+	From  string `json:"from"`
+	Lines int    `json:"lines"`
+}
+
+// Replay is the full record of one game: the seed that dealt its pieces
+// and every event that touched the board, stamped with the tick it
+// arrived at. A MatchActor is deterministic given those, so simulate
+// rebuilds the game exactly; Score, Lines and Level are what the live
+// match reported, for checking against the rebuild.
+type Replay struct {
+	ID      string        `json:"id"`
+	Mode    string        `json:"mode"`
+	Seed    uint64        `json:"seed"`
+	Started int64         `json:"started"` // Unix ms
+	Ticks   int           `json:"ticks"`   // board steps played
+	Score   int           `json:"score"`
+	Lines   int           `json:"lines"`
+	Level   int           `json:"level"`
+	Events  []ReplayEvent `json:"events"`
+}
+
+// ReplayEvent is one input, or in versus play one batch of incoming
+// garbage, applied after Tick board steps.
+type ReplayEvent struct {
+	Tick    int    `json:"tick"`
+	Action  string `json:"action,omitempty"`
+	Garbage int    `json:"garbage,omitempty"`
+}
+
+// GameRecorded carries a finished game's replay from the match to its
+// subscribers, so the replay is also stored on the node the player is
+// connected to.
+type GameRecorded struct {
+	Replay Replay `json:"replay"`
+}
+
+// RaceGhost asks a solo match to restart with a recorded game running
+// beside it. Sent by the session right after Subscribe.
+type RaceGhost struct {
+	Replay Replay `json:"replay"`
+}
+
+// Result is one finished game's score, lines and level.
+type Result struct {
+	Score int `json:"score"`
+	Lines int `json:"lines"`
+	Level int `json:"level"`
+}
+
+// GetProfile asks a PlayerProfileGrain for its ProfileView.
+type GetProfile struct{}
+
+// RecordGame adds a finished game to a player's profile; the grain
+// replies with the updated ProfileView. Name is the player's display
+// name at the time.
+type RecordGame struct {
+	Name   string `json:"name"`
+	Result Result `json:"result"`
+}
+
+// ProfileView is a player's profile: games played, total lines cleared,
+// and the best game — highest score — of all time, of today and of this
+// week. A period with no game yet is zero.
+type ProfileView struct {
+	PlayerID   string `json:"playerID"`
+	Name       string `json:"name"`
+	Games      int    `json:"games"`
+	TotalLines int    `json:"totalLines"`
+	Best       Result `json:"best"`
+	Today      Result `json:"today"`
+	Week       Result `json:"week"`
+}
+
+// LeaderboardEntry is one row of a period's leaderboard: a player and
+// their best game of the period.
+type LeaderboardEntry struct {
+	PlayerID string `json:"playerID"`
+	Name     string `json:"name"`
+	Result
+}
+
+// tick drives the gravity loop: the node's TickerActor sends one to each
+// of its matches per pulse. steps is how many board steps it stands
+// for — more than one when the ticker is catching up.
+type tick struct{ steps int }
+
+// pulse is the TickerActor's own scheduled message.
+type pulse struct{}
+
+// tickSubscribe registers the sending match with its node's
+// TickerActor.
+type tickSubscribe struct{}
 
 // Subscribe registers the sender as a subscriber for direct snapshot
 // delivery. The match identifies the subscriber via ctx.Sender() — we
 // intentionally do not embed an *actor.PID in the wire payload because
 // PIDs contain unexported fields that don't survive CBOR/cluster
 // serialization. ctx.Sender() is cluster-aware and always correct.
-type Subscribe struct{}
+// Preview is how many upcoming pieces the subscriber wants shown.
+type Subscribe struct {
+	Preview int `json:"preview"`
+}
 
 // Unsubscribe removes the named subscriber from the match's broadcast
 // list. SessionName is the actor name of the PlayerSessionActor to
@@ -0,0 +1,509 @@
+// MIT License
+//
+// Copyright (c) 2022-2026 GoAkt Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/tochemey/goakt/v4/actor"
+)
+
+const (
+	// garbageCell is the grid value of a garbage row; 1..7 are pieces.
+	garbageCell = 8
+
+	// All versus timings are in ticks (60 per second).
+	versusQueueTicks     = 300 // re-send JoinQueue this often while waiting
+	versusCountdownTicks = 180 // 3 s between VersusStart and the first drop
+	versusFrameTicks     = 4   // board frames to opponents, ~15 per second
+	versusHeartbeatTicks = 60  // frame interval once this player is out
+	versusStaleTicks     = 300 // an opponent silent this long is out
+	versusAttackTicks    = 60  // how long the attack label is shown
+)
+
+// Attack tables, guideline-style. linesAttack is indexed by lines
+// cleared and tspinAttack by lines cleared with a full T-spin (a Mini
+// sends what the plain clear would); comboAttack by combo count, its
+// last entry covering every longer combo.
+var (
+	linesAttack = [...]int{0, 0, 1, 2, 4}
+	tspinAttack = [...]int{0, 2, 4, 6}
+	comboAttack = [...]int{0, 1, 1, 2, 2, 3, 3, 4, 4, 4, 5}
+)
+
+// versusState is the part of a MatchActor that only exists in versus
+// play. A versus match is spawned under a name carrying its game size
+// (see versusMatchName), queues itself with the matchmaker once its
+// player connects, and on VersusStart is linked to its opponents'
+// matches, which may live on other nodes. From then on the linked
+// matches only talk to each other: each sends its board as VersusFrame
+// and its attacks as Garbage.
+//
+// GoAkt's Watch is local-only, so an opponent's death is inferred from
+// silence: frames flow several times a second, and an opponent not
+// heard from for versusStaleTicks is counted out.
+type versusState struct {
+	self    string // this match's actor name
+	players int    // game size, VersusMinPlayers..VersusMaxPlayers
+
+	clock     int // ticks since PostStart; tickN stops while not playing
+	nextQueue int // clock at which to (re-)send JoinQueue
+	queued    int // players in our queue, per the last QueueStatus
+
+	game      string // current game id; "" while waiting in the queue
+	opponents map[string]*opponent
+	order     []string // opponent names in VersusStart order
+	countdown int      // ticks left before the game starts
+	holes     *rand.Rand
+
+	pending  []int // incoming garbage, one entry per attack
+	outgoing int   // attack not yet sent, flushed by versusAfter
+
+	attack      string // label of the last attack, e.g. "T-Spin Double"
+	attackTicks int
+
+	place     int // finishing position once out; 0 while alive
+	lastFrame int
+
+	// fresh is set when an opponent's view changes, so the next delta
+	// frame carries the opponents.
+	fresh bool
+}
+
+type opponent struct {
+	pid   *actor.PID // nil until ActorOf resolves the name
+	view  OpponentView
+	heard int // clock of the last frame
+}
+
+// versusMatchName is the actor name of a new versus match for a game
+// of the given size: SpawnOn re-instantiates matches from the kind
+// registry, so the size travels in the name.
+func versusMatchName(players int, id string) string {
+	return matchActorPrefix + "vs" + strconv.Itoa(players) + "." + id
+}
+
+// versusPlayers parses the game size back out of a match's name, and
+// returns 0 for a solo match.
+func versusPlayers(name string) int {
+	rest, ok := strings.CutPrefix(name, matchActorPrefix+"vs")
+	if !ok {
+		return 0
+	}
+	size, _, ok := strings.Cut(rest, ".")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.Atoi(size)
+	if err != nil || n < VersusMinPlayers || n > VersusMaxPlayers {
+		return 0
+	}
+	return n
+}
+
+// live reports whether a versus game is under way for this board.
+func (v *versusState) live() bool {
+	return v.game != "" && v.countdown == 0 && v.place == 0
+}
+
+// leave drops out of the current game (if any) and queues again.
+func (v *versusState) leave() {
+	v.game = ""
+	v.opponents = nil
+	v.order = nil
+	v.countdown = 0
+	v.holes = nil
+	v.pending = nil
+	v.outgoing = 0
+	v.attack = ""
+	v.attackTicks = 0
+	v.place = 0
+	v.queued = 0
+	v.nextQueue = v.clock
+}
+
+// handleVersus processes the versus-only messages. It reports false for
+// anything else.
+func (m *MatchActor) handleVersus(ctx *actor.ReceiveContext) bool {
+	if m.vs == nil {
+		return false
+	}
+	v := m.vs
+
+	switch msg := ctx.Message().(type) {
+	case *QueueStatus:
+		if v.game == "" {
+			v.queued = msg.Queued
+		}
+
+	case *VersusStart:
+		if v.game != "" {
+			return true
+		}
+		m.startVersus(msg)
+		m.resolveOpponents(ctx)
+		ctx.Logger().Infof("%s: versus game %s with %d opponents", v.self, v.game, len(v.order))
+
+	case *VersusFrame:
+		if msg.Game != v.game {
+			return true
+		}
+		if opp, ok := v.opponents[msg.Board.ID]; ok {
+			opp.view = msg.Board
+			opp.heard = v.clock
+			v.fresh = true
+			m.checkWin()
+		}
+
+	case *Garbage:
+		if msg.Game == v.game {
+			m.queueGarbage(msg.Lines)
+		}
+
+	default:
+		return false
+	}
+	return true
+}
+
+// startVersus sets the board up for the game VersusStart announces and
+// starts counting down to it.
+func (m *MatchActor) startVersus(msg *VersusStart) {
+	v := m.vs
+	v.leave()
+	v.game = msg.Game
+	v.countdown = versusCountdownTicks
+	v.opponents = make(map[string]*opponent, len(msg.Opponents))
+	for _, name := range msg.Opponents {
+		if name == v.self {
+			continue
+		}
+		v.order = append(v.order, name)
+		v.opponents[name] = &opponent{view: OpponentView{ID: name}, heard: v.clock}
+	}
+	// Everyone in the game is dealt the same pieces. Garbage holes
+	// come from a stream of their own: how much garbage a board takes
+	// differs per player and must not shift its piece sequence.
+	m.deal(msg.Seed)
+	v.holes = rand.New(rand.NewPCG(msg.Seed, 1))
+	m.reset()
+	m.startReplay(ModeVersus)
+}
+
+// queueGarbage holds an incoming attack until the next lock that clears
+// nothing (see takeGarbage). Garbage only lands on a live game.
+func (m *MatchActor) queueGarbage(lines int) {
+	v := m.vs
+	if !v.live() || lines <= 0 {
+		return
+	}
+	m.record(ReplayEvent{Garbage: lines})
+	v.pending = append(v.pending, lines)
+}
+
+// versusTick runs once per tick, before the board steps.
+func (m *MatchActor) versusTick(ctx *actor.ReceiveContext) {
+	v := m.vs
+	v.clock++
+	if v.attackTicks > 0 {
+		v.attackTicks--
+	}
+
+	if v.game == "" {
+		// Wait for the player before queueing; a match whose session
+		// never arrives must not be matched with anyone.
+		if m.hadSubscriber && v.clock >= v.nextQueue {
+			m.joinQueue(ctx)
+			v.nextQueue = v.clock + versusQueueTicks
+		}
+		return
+	}
+
+	if v.countdown > 0 {
+		v.countdown--
+	}
+
+	for _, name := range v.order {
+		opp := v.opponents[name]
+		if !opp.view.Out && v.clock-opp.heard > versusStaleTicks {
+			opp.view.Out = true
+			opp.view.Place = 1 + m.alive()
+			v.fresh = true
+		}
+	}
+	m.checkWin()
+}
+
+// versusAfter runs after anything that may have moved the board: it
+// settles a top-out, sends pending attack and the board frame.
+func (m *MatchActor) versusAfter(ctx *actor.ReceiveContext) {
+	v := m.vs
+	if v.game == "" {
+		return
+	}
+
+	if m.gameOver && v.place == 0 {
+		v.place = 1 + m.alive()
+		v.pending = nil
+		m.sendFrame(ctx)
+		return
+	}
+
+	if v.outgoing > 0 {
+		m.sendGarbage(ctx, v.outgoing)
+		v.outgoing = 0
+	}
+
+	every := versusFrameTicks
+	if v.place != 0 {
+		every = versusHeartbeatTicks
+	}
+	if v.clock-v.lastFrame >= every {
+		m.sendFrame(ctx)
+	}
+}
+
+// alive counts the opponents still playing.
+func (m *MatchActor) alive() int {
+	n := 0
+	for _, opp := range m.vs.opponents {
+		if !opp.view.Out {
+			n++
+		}
+	}
+	return n
+}
+
+// checkWin ends the game in first place once every opponent is out.
+func (m *MatchActor) checkWin() {
+	v := m.vs
+	if v.game == "" || v.place != 0 || m.gameOver || len(v.opponents) == 0 || m.alive() > 0 {
+		return
+	}
+	v.place = 1
+	v.pending = nil
+	m.gameOver = true
+}
+
+func (m *MatchActor) joinQueue(ctx *actor.ReceiveContext) {
+	mm, err := ctx.ActorSystem().ActorOf(ctx.Context(), MatchmakerActorName)
+	if err != nil {
+		ctx.Logger().Warnf("%s: locate matchmaker: %v", m.vs.self, err)
+		return
+	}
+	ctx.Tell(mm, &JoinQueue{Match: m.vs.self, Players: m.vs.players})
+}
+
+// resolveOpponents looks up the opponents not resolved yet. A lookup
+// can fail while a just-spawned remote match is still registering; it
+// is retried on the next frame.
+func (m *MatchActor) resolveOpponents(ctx *actor.ReceiveContext) {
+	for _, name := range m.vs.order {
+		opp := m.vs.opponents[name]
+		if opp.pid != nil || opp.view.Out {
+			continue
+		}
+		if pid, err := ctx.ActorSystem().ActorOf(ctx.Context(), name); err == nil {
+			opp.pid = pid
+		}
+	}
+}
+
+// frame is this board as its opponents see it.
+func (m *MatchActor) frame() *VersusFrame {
+	v := m.vs
+	pending := 0
+	for _, lines := range v.pending {
+		pending += lines
+	}
+	return &VersusFrame{Game: v.game, Board: OpponentView{
+		ID:      v.self,
+		Grid:    m.gridCopy(),
+		Piece:   m.piece,
+		Score:   m.score,
+		Lines:   m.lines,
+		Pending: pending,
+		Out:     v.place != 0,
+		Place:   v.place,
+	}}
+}
+
+func (m *MatchActor) sendFrame(ctx *actor.ReceiveContext) {
+	m.resolveOpponents(ctx)
+	frame := m.frame()
+	for _, name := range m.vs.order {
+		if opp := m.vs.opponents[name]; opp.pid != nil && !opp.view.Out {
+			ctx.Tell(opp.pid, frame)
+		}
+	}
+	m.vs.lastFrame = m.vs.clock
+}
+
+// sendGarbage sends an attack to one opponent still playing, picked at
+// random so a crowded game spreads the pressure.
+func (m *MatchActor) sendGarbage(ctx *actor.ReceiveContext, lines int) {
+	var targets []*opponent
+	for _, name := range m.vs.order {
+		if opp := m.vs.opponents[name]; opp.pid != nil && !opp.view.Out {
+			targets = append(targets, opp)
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+	target := targets[rand.IntN(len(targets))]
+	ctx.Tell(target.pid, &Garbage{Game: m.vs.game, From: m.vs.self, Lines: lines})
+}
+
+// versusStop tells the matchmaker or the opponents that this match is
+// gone. It runs from PostStop; the opponents' stale timer covers a Tell
+// lost on the way out.
+func (m *MatchActor) versusStop(ctx *actor.Context) {
+	v := m.vs
+	if v.game == "" {
+		if mm, err := ctx.ActorSystem().ActorOf(ctx.Context(), MatchmakerActorName); err == nil {
+			_ = actor.Tell(context.Background(), mm, &LeaveQueue{Match: v.self})
+		}
+		return
+	}
+	if v.place != 0 {
+		return
+	}
+
+	v.place = 1 + m.alive()
+	frame := m.frame()
+	for _, name := range v.order {
+		if opp := v.opponents[name]; opp.pid != nil && !opp.view.Out {
+			_ = actor.Tell(context.Background(), opp.pid, frame)
+		}
+	}
+}
+
+// scoreAttack works out the garbage a lock sends, from the lines it
+// cleared, its T-spin and whether it was back-to-back, plus the combo
+// scoreClear has just counted. The attack cancels pending garbage
+// first. A lock that clears nothing lets the pending garbage in.
+func (m *MatchActor) scoreAttack(cleared int, spin tspinKind, b2b bool) {
+	v := m.vs
+	if !v.live() {
+		return
+	}
+
+	if cleared == 0 {
+		m.takeGarbage()
+		return
+	}
+
+	attack := linesAttack[cleared]
+	label := [...]string{"", "Single", "Double", "Triple", "Tetris"}[cleared]
+	switch spin {
+	case tspinFull:
+		attack = tspinAttack[cleared]
+		label = "T-Spin " + label
+	case tspinMini:
+		label = "T-Spin Mini " + label
+	}
+
+	if b2b {
+		attack++
+		label = "B2B " + label
+	}
+
+	if m.combo > 0 {
+		attack += comboAttack[min(m.combo, len(comboAttack)-1)]
+		label += " · Combo " + strconv.Itoa(m.combo)
+	}
+
+	for attack > 0 && len(v.pending) > 0 {
+		n := min(attack, v.pending[0])
+		attack -= n
+		v.pending[0] -= n
+		if v.pending[0] == 0 {
+			v.pending = v.pending[1:]
+		}
+	}
+
+	if attack > 0 {
+		v.outgoing += attack
+		v.attack = label
+		v.attackTicks = versusAttackTicks
+	}
+}
+
+// takeGarbage pushes the pending garbage up from the bottom of the
+// board, one hole column per attack. Garbage lifting the stack past the
+// top is a top-out.
+func (m *MatchActor) takeGarbage() {
+	v := m.vs
+	for _, lines := range v.pending {
+		hole := v.holes.IntN(BoardW)
+		for range lines {
+			for c := 0; c < BoardW; c++ {
+				if m.grid[0][c] != 0 {
+					m.gameOver = true
+				}
+			}
+			copy(m.grid[:], m.grid[1:])
+			for c := range m.grid[BoardH-1] {
+				m.grid[BoardH-1][c] = garbageCell
+			}
+			m.grid[BoardH-1][hole] = 0
+		}
+	}
+	v.pending = nil
+}
+
+// versusSnapshot fills in the versus fields of a Snapshot. Keyframes carry
+// the opponents; delta frames only when one of them changed.
+func (m *MatchActor) versusSnapshot(snap *Snapshot, key bool) {
+	v := m.vs
+	snap.Mode = ModeVersus
+	snap.Paused = false
+	snap.Needed = v.players
+	snap.Place = v.place
+	if v.game == "" {
+		snap.Waiting = true
+		snap.Queued = v.queued
+		return
+	}
+	if v.countdown > 0 {
+		snap.Countdown = (v.countdown + 59) / 60
+	}
+	for _, lines := range v.pending {
+		snap.Pending += lines
+	}
+	if v.attackTicks > 0 {
+		snap.Attack = v.attack
+	}
+	if !key && !v.fresh {
+		return
+	}
+	v.fresh = false
+	snap.Opponents = make([]OpponentView, 0, len(v.order))
+	for _, name := range v.order {
+		snap.Opponents = append(snap.Opponents, v.opponents[name].view)
+	}
+}
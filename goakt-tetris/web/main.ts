@@ -1,5 +1,8 @@
 // Tetris client. Renders the server-authoritative state via a 2D canvas
 // and sends every keypress as a discrete action over the WebSocket.
+// Shifts and rotations are predicted locally until the server
+// acknowledges them, so the piece moves on the keypress rather than a
+// round trip later.
 
 // ─── Wire-protocol types ────────────────────────────────────────────────
 //
@@ -11,7 +14,7 @@
 
 interface Piece {
   kind: number; // 0..6 → I, O, T, S, Z, J, L
-  rot: number;  // 0..3
+  rot: number;  // 0..3 → spawn, R, 2, L (SRS)
   x: number;
   y: number;
 }
@@ -20,16 +23,82 @@ interface Snapshot {
   tick: number;
   t: number;
   grid: number[][];
+  ack: number; // highest input seq the server has applied
   piece: Piece;
   ghostY: number;
-  next: number;
+  next: number[]; // preview queue, nearest first
+  hold: number;   // -1 = empty
+  canHold: boolean;
   score: number;
   lines: number;
   level: number;
   gameOver: boolean;
   paused: boolean;
+
+  // Versus mode only; see the Snapshot doc comment in types.go.
+  mode: string;
+  waiting?: boolean;
+  queued?: number;
+  needed?: number;
+  countdown?: number;
+  pending?: number;
+  attack?: string;
+  place?: number;
+  opponents?: OpponentView[];
+
+  replay?: string;      // set once the finished game is stored
+  ghost?: OpponentView; // the recorded game being raced (?ghost=)
+}
+
+// Frame is a Snapshot as it comes off the wire. A keyframe carries the
+// whole grid; the frames between carry only the rows that changed, and
+// the opponents and ghost only when they did. See broadcast in match.go.
+interface Frame extends Omit<Snapshot, "grid"> {
+  key?: boolean;
+  grid?: number[][];
+  rows?: GridRow[];
+}
+
+interface GridRow {
+  y: number;
+  cells: number[];
+}
+
+interface OpponentView {
+  id: string;
+  grid: number[][];
+  piece: Piece;
+  score: number;
+  lines: number;
+  pending: number;
+  out: boolean;
+  place?: number;
 }
 
+// Mirrors Result, ProfileView and LeaderboardEntry in types.go.
+interface Result {
+  score: number;
+  lines: number;
+  level: number;
+}
+
+interface Profile {
+  playerID: string;
+  name: string;
+  games: number;
+  totalLines: number;
+  best: Result;
+  today: Result;
+  week: Result;
+}
+
+interface LeaderboardEntry extends Result {
+  playerID: string;
+  name: string;
+}
+
+const MODE_VERSUS = "versus";
+
 const MSG_TYPE_INPUT = "input";
 
 // Action strings — mirror of the Go constants in types.go. Changing either
@@ -38,6 +107,8 @@ const ACTION = {
   LEFT:          "left",
   RIGHT:         "right",
   ROTATE:        "rotate",
+  ROTATE_CCW:    "rotate-ccw",
+  HOLD:          "hold",
   SOFT_DROP:     "softdrop",
   SOFT_DROP_END: "softdrop-end",
   HARD_DROP:     "harddrop",
@@ -50,7 +121,7 @@ type Action = typeof ACTION[keyof typeof ACTION];
 // ─── Render constants ───────────────────────────────────────────────────
 
 // Piece kind → hex color. Index 0 = empty, 1..7 = I, O, T, S, Z, J, L
-// (matches the order in match.go's pieceShapes table).
+// (matches the order in match.go's pieceShapes table), 8 = garbage.
 const COLORS: readonly string[] = [
   "#000",     // 0 = empty (unused — empty cells aren't drawn)
   "#22d3ee",  // 1 = I (cyan)
@@ -60,30 +131,82 @@ const COLORS: readonly string[] = [
   "#ef4444",  // 5 = Z (red)
   "#3b82f6",  // 6 = J (blue)
   "#fb923c",  // 7 = L (orange)
+  "#6b7280",  // 8 = garbage (grey)
 ];
 
-// Mirror of pieceShapes in match.go. Each piece's base orientation as 4
-// (dx, dy) cell offsets from its pivot.
+// Mirror of pieceShapes in match.go: each piece in its four SRS
+// orientations (spawn, R, 2, L), as 4 (dx, dy) cell offsets from its
+// pivot. The server's kick tables depend on exactly these positions.
 type Cell = readonly [number, number];
-const SHAPES: readonly Cell[][] = [
-  [[-1, 0], [0, 0], [1, 0], [2, 0]],   // I
-  [[0, 0],  [1, 0], [0, 1], [1, 1]],   // O
-  [[-1, 0], [0, 0], [1, 0], [0, 1]],   // T
-  [[0, 0],  [1, 0], [-1, 1], [0, 1]],  // S
-  [[-1, 0], [0, 0], [0, 1], [1, 1]],   // Z
-  [[-1, 0], [0, 0], [1, 0], [1, 1]],   // J
-  [[-1, 0], [0, 0], [1, 0], [-1, 1]],  // L
+const SHAPES: readonly (readonly (readonly Cell[])[])[] = [
+  [ // I
+    [[-1, 0], [0, 0], [1, 0], [2, 0]],
+    [[1, -1], [1, 0], [1, 1], [1, 2]],
+    [[-1, 1], [0, 1], [1, 1], [2, 1]],
+    [[0, -1], [0, 0], [0, 1], [0, 2]],
+  ],
+  [ // O
+    [[0, 0], [1, 0], [0, 1], [1, 1]],
+    [[0, 0], [1, 0], [0, 1], [1, 1]],
+    [[0, 0], [1, 0], [0, 1], [1, 1]],
+    [[0, 0], [1, 0], [0, 1], [1, 1]],
+  ],
+  [ // T
+    [[0, -1], [-1, 0], [0, 0], [1, 0]],
+    [[0, -1], [0, 0], [1, 0], [0, 1]],
+    [[-1, 0], [0, 0], [1, 0], [0, 1]],
+    [[0, -1], [-1, 0], [0, 0], [0, 1]],
+  ],
+  [ // S
+    [[0, -1], [1, -1], [-1, 0], [0, 0]],
+    [[0, -1], [0, 0], [1, 0], [1, 1]],
+    [[0, 0], [1, 0], [-1, 1], [0, 1]],
+    [[-1, -1], [-1, 0], [0, 0], [0, 1]],
+  ],
+  [ // Z
+    [[-1, -1], [0, -1], [0, 0], [1, 0]],
+    [[1, -1], [0, 0], [1, 0], [0, 1]],
+    [[-1, 0], [0, 0], [0, 1], [1, 1]],
+    [[0, -1], [-1, 0], [0, 0], [-1, 1]],
+  ],
+  [ // J
+    [[-1, -1], [-1, 0], [0, 0], [1, 0]],
+    [[0, -1], [1, -1], [0, 0], [0, 1]],
+    [[-1, 0], [0, 0], [1, 0], [1, 1]],
+    [[0, -1], [0, 0], [-1, 1], [0, 1]],
+  ],
+  [ // L
+    [[1, -1], [-1, 0], [0, 0], [1, 0]],
+    [[0, -1], [0, 0], [0, 1], [1, 1]],
+    [[-1, 0], [0, 0], [1, 0], [-1, 1]],
+    [[-1, -1], [0, -1], [0, 0], [0, 1]],
+  ],
 ];
 
-function rotateCells(cells: readonly Cell[], rot: number): Cell[] {
-  let out: Cell[] = cells.map(c => [c[0], c[1]]);
-  for (let i = 0; i < rot; i++) {
-    out = out.map(([x, y]): Cell => [-y, x]);
-  }
-  return out;
+function pieceCells(kind: number, rot: number): readonly Cell[] {
+  return SHAPES[kind]![rot]!;
 }
 
+// Mirror of the SRS kick tables in srs.go: per starting orientation,
+// clockwise then counter-clockwise, five (dx, dy) tests with dy up.
+type Kicks = readonly (readonly (readonly Cell[])[])[];
+const JLSTZ_KICKS: Kicks = [
+  [[[0, 0], [-1, 0], [-1, 1], [0, -2], [-1, -2]], [[0, 0], [1, 0], [1, 1], [0, -2], [1, -2]]],
+  [[[0, 0], [1, 0], [1, -1], [0, 2], [1, 2]], [[0, 0], [1, 0], [1, -1], [0, 2], [1, 2]]],
+  [[[0, 0], [1, 0], [1, 1], [0, -2], [1, -2]], [[0, 0], [-1, 0], [-1, 1], [0, -2], [-1, -2]]],
+  [[[0, 0], [-1, 0], [-1, -1], [0, 2], [-1, 2]], [[0, 0], [-1, 0], [-1, -1], [0, 2], [-1, 2]]],
+];
+const I_KICKS: Kicks = [
+  [[[0, 0], [-2, 0], [1, 0], [-2, -1], [1, 2]], [[0, 0], [-1, 0], [2, 0], [-1, 2], [2, -1]]],
+  [[[0, 0], [-1, 0], [2, 0], [-1, 2], [2, -1]], [[0, 0], [2, 0], [-1, 0], [2, 1], [-1, -2]]],
+  [[[0, 0], [2, 0], [-1, 0], [2, 1], [-1, -2]], [[0, 0], [1, 0], [-2, 0], [1, -2], [-2, 1]]],
+  [[[0, 0], [1, 0], [-2, 0], [1, -2], [-2, 1]], [[0, 0], [-2, 0], [1, 0], [-2, -1], [1, 2]]],
+];
+const KIND_I = 0;
+const KIND_O = 1;
+
 const CELL = 30;
+const MINI_CELL = 12; // opponent boards
 const BOARD_W = 10;
 const BOARD_H = 20;
 
@@ -104,6 +227,8 @@ const board = el<HTMLCanvasElement>("board");
 const bctx = canvasCtx(board);
 const next = el<HTMLCanvasElement>("nextCanvas");
 const nctx = canvasCtx(next);
+const holdCanvas = el<HTMLCanvasElement>("holdCanvas");
+const hctx = canvasCtx(holdCanvas);
 const scorePanel = el("scorePanel");
 const linesPanel = el("linesPanel");
 const levelPanel = el("levelPanel");
@@ -111,23 +236,135 @@ const scoreVal = el("scoreVal");
 const linesVal = el("linesVal");
 const levelVal = el("levelVal");
 const overlay = el("overlay");
+const overlayTitle = el("overlayTitle");
+const overlaySub = el("overlaySub");
 const pauseOverlay = el("pauseOverlay");
+const waitOverlay = el("waitOverlay");
+const waitTitle = el("waitTitle");
+const waitSub = el("waitSub");
+const opponentsEl = el("opponents");
+const attackEl = el("attack");
 const pauseBtn = el<HTMLButtonElement>("pauseBtn");
 const statusEl = el("status");
+const nameInput = el<HTMLInputElement>("nameInput");
+const profileEl = el("profile");
+const leadersEl = el("leaders");
 
 pauseBtn.addEventListener("click", () => {
   send(ACTION.PAUSE);
   pauseBtn.blur(); // so Space doesn't re-trigger the button
 });
 
+// Mode links: the page's own ?mode=versus&players=N (or ?ghost=) is
+// handed on to /ws.
+const params = new URLSearchParams(location.search);
+for (const link of document.querySelectorAll<HTMLAnchorElement>("#modes a")) {
+  const mode = link.dataset["mode"] ?? "";
+  const players = link.dataset["players"] ?? "";
+  const ghost = link.dataset["ghost"] ?? "";
+  link.classList.toggle("active",
+    mode === (params.get("mode") ?? "solo") && (mode !== MODE_VERSUS || players === (params.get("players") ?? "2"))
+      && (ghost === "" ? !params.has("ghost") : params.has("ghost")));
+}
+
+// ─── Player + leaderboard ───────────────────────────────────────────────
+
+// The player id lives in localStorage so a profile outlasts the tab;
+// the server records every finished game against it.
+function getOrCreatePlayerID(): string {
+  let id = localStorage.getItem("tetris.playerID");
+  if (!id) {
+    id = crypto.randomUUID();
+    localStorage.setItem("tetris.playerID", id);
+  }
+  return id;
+}
+
+const playerID = getOrCreatePlayerID();
+nameInput.value = localStorage.getItem("tetris.name") ?? "";
+
+// A new name is sent on the next connection, so changing it reconnects
+// — and starts a new game.
+nameInput.addEventListener("change", () => {
+  localStorage.setItem("tetris.name", nameInput.value.trim());
+  nameInput.blur();
+  ws?.close();
+});
+
+let period = "day";
+for (const tab of document.querySelectorAll<HTMLButtonElement>("#periods button")) {
+  tab.addEventListener("click", () => {
+    period = tab.dataset["period"] ?? "day";
+    tab.blur();
+    void loadLeaderboard();
+  });
+}
+
+async function loadProfile(): Promise<void> {
+  const res = await fetch(`/players/${encodeURIComponent(playerID)}`);
+  if (!res.ok) return;
+  const p = await res.json() as Profile;
+  profileEl.textContent = p.games === 0
+    ? "No games yet"
+    : `Best ${p.best.score.toLocaleString()} · ${p.best.lines} lines · L${p.best.level}\n`
+      + `Today ${p.today.score.toLocaleString()} · ${p.games} games`;
+}
+
+async function loadLeaderboard(): Promise<void> {
+  for (const tab of document.querySelectorAll<HTMLButtonElement>("#periods button")) {
+    tab.classList.toggle("active", tab.dataset["period"] === period);
+  }
+  const res = await fetch(`/leaderboard?period=${period}`);
+  if (!res.ok) return;
+  const { entries } = await res.json() as { entries: LeaderboardEntry[] };
+  leadersEl.replaceChildren(...entries.map((e) => {
+    const li = document.createElement("li");
+    li.classList.toggle("you", e.playerID === playerID);
+    const name = document.createElement("span");
+    name.textContent = e.name;
+    const score = document.createElement("span");
+    score.textContent = e.score.toLocaleString();
+    li.append(name, score);
+    return li;
+  }));
+  if (entries.length === 0) leadersEl.innerHTML = "<li>No games yet</li>";
+}
+
+void loadProfile();
+void loadLeaderboard();
+
+// The session records a game just after the snapshot that ends it, so
+// the refresh waits a moment.
+let recorded = "";
+function refreshAfterGame(replay: string): void {
+  if (replay === recorded) return;
+  recorded = replay;
+  setTimeout(() => { void loadProfile(); void loadLeaderboard(); }, 500);
+}
+
 // ─── State + WebSocket ──────────────────────────────────────────────────
 
 let state: Snapshot | null = null;
 let ws: WebSocket | null = null;
 
+// Inputs are numbered per connection. Those the server hasn't yet
+// acknowledged are replayed on every frame's piece; see predict.
+let seq = 0;
+let unacked: { seq: number; action: Action }[] = [];
+
 function connect(): void {
+  // The new connection gets a new match: nothing to apply deltas to,
+  // and no inputs in flight.
+  state = null;
+  seq = 0;
+  unacked = [];
+
   const proto = location.protocol === "https:" ? "wss:" : "ws:";
-  ws = new WebSocket(`${proto}//${location.host}/ws`);
+  const query = new URLSearchParams(location.search);
+  query.set("id", playerID);
+  const name = localStorage.getItem("tetris.name");
+  if (name) query.set("name", name);
+  ws = new WebSocket(`${proto}//${location.host}/ws?${query}`);
   ws.onopen = () => { statusEl.textContent = "connected"; };
   ws.onclose = () => {
     ws = null;
@@ -136,31 +373,108 @@ function connect(): void {
   };
   ws.onerror = () => ws?.close();
   ws.onmessage = (e: MessageEvent<string>) => {
-    state = JSON.parse(e.data) as Snapshot;
+    applyFrame(JSON.parse(e.data) as Frame);
     render();
   };
 }
 connect();
 
+// applyFrame merges a frame into state: a keyframe replaces it, a delta
+// patches the changed rows and keeps the opponents and ghost it leaves
+// out. A delta that arrives with nothing to patch is dropped; the next
+// keyframe is never more than a second away.
+function applyFrame(f: Frame): void {
+  if (f.key && f.grid) {
+    state = { ...f, grid: f.grid };
+  } else if (state) {
+    const grid = state.grid;
+    for (const row of f.rows ?? []) grid[row.y] = row.cells;
+    state = { ...f, grid, opponents: f.opponents ?? state.opponents, ghost: f.ghost ?? state.ghost };
+  } else {
+    return;
+  }
+  const ack = state.ack;
+  unacked = unacked.filter((input) => input.seq > ack);
+}
+
 function send(action: Action): void {
   if (ws && ws.readyState === WebSocket.OPEN) {
-    ws.send(JSON.stringify({ type: MSG_TYPE_INPUT, action }));
+    seq++;
+    ws.send(JSON.stringify({ type: MSG_TYPE_INPUT, action, seq }));
+    unacked.push({ seq, action });
+    render();
+  }
+}
+
+// ─── Prediction ─────────────────────────────────────────────────────────
+
+// predict replays the unacknowledged inputs on the frame's piece the way
+// the server's handleInput will: shifts, and rotations with SRS kicks.
+// Soft drop only changes the fall rate and moves nothing. At the first
+// input it can't foresee — a hold or a hard drop — it stops, and the
+// piece waits for the server from there.
+function predict(s: Snapshot): Piece {
+  let p = s.piece;
+  if (s.gameOver || s.paused || s.waiting || s.countdown) return p;
+  for (const { action } of unacked) {
+    switch (action) {
+      case ACTION.LEFT:       p = fits(s.grid, { ...p, x: p.x - 1 }) ?? p; break;
+      case ACTION.RIGHT:      p = fits(s.grid, { ...p, x: p.x + 1 }) ?? p; break;
+      case ACTION.ROTATE:     p = rotate(s.grid, p, 1); break;
+      case ACTION.ROTATE_CCW: p = rotate(s.grid, p, -1); break;
+      case ACTION.SOFT_DROP:
+      case ACTION.SOFT_DROP_END: break;
+      default: return p;
+    }
+  }
+  return p;
+}
+
+// rotate mirrors rotate in srs.go.
+function rotate(grid: number[][], p: Piece, dir: number): Piece {
+  if (p.kind === KIND_O) return p;
+  const kicks = p.kind === KIND_I ? I_KICKS : JLSTZ_KICKS;
+  for (const [dx, dy] of kicks[p.rot]![dir > 0 ? 0 : 1]!) {
+    const turned = fits(grid, { ...p, rot: (p.rot + dir + 4) % 4, x: p.x + dx, y: p.y - dy });
+    if (turned) return turned;
+  }
+  return p;
+}
+
+// fits returns p if it fits on the grid, as canPlace in match.go judges.
+function fits(grid: number[][], p: Piece): Piece | null {
+  for (const [dx, dy] of pieceCells(p.kind, p.rot)) {
+    const x = p.x + dx;
+    const y = p.y + dy;
+    if (x < 0 || x >= BOARD_W || y >= BOARD_H) return null;
+    if (y >= 0 && grid[y]![x] !== 0) return null;
   }
+  return p;
+}
+
+// landing returns the row p would hard-drop to.
+function landing(grid: number[][], p: Piece): number {
+  let y = p.y;
+  while (fits(grid, { ...p, y: y + 1 })) y++;
+  return y;
 }
 
 // Browser auto-repeats keydown while a key is held, which gives natural
-// auto-repeat for left/right/soft-drop. Rotate / harddrop / restart fire
-// once per physical press.
+// auto-repeat for left/right/soft-drop. Rotate / hold / harddrop / restart
+// fire once per physical press.
 addEventListener("keydown", (e: KeyboardEvent) => {
   const repeatable = e.code === "ArrowLeft"  || e.code === "KeyA"
                   || e.code === "ArrowRight" || e.code === "KeyD"
                   || e.code === "ArrowDown"  || e.code === "KeyS";
   if (e.repeat && !repeatable) return;
+  if (e.target instanceof HTMLInputElement) return; // typing a name
 
   switch (e.code) {
     case "ArrowLeft":  case "KeyA": send(ACTION.LEFT); break;
     case "ArrowRight": case "KeyD": send(ACTION.RIGHT); break;
-    case "ArrowUp":    case "KeyW": send(ACTION.ROTATE); break;
+    case "ArrowUp":    case "KeyW": case "KeyX": send(ACTION.ROTATE); break;
+    case "KeyZ":       case "ControlLeft":       send(ACTION.ROTATE_CCW); break;
+    case "KeyC":       case "ShiftLeft":         send(ACTION.HOLD); break;
     case "ArrowDown":  case "KeyS": send(ACTION.SOFT_DROP); break;
     case "Space":                   send(ACTION.HARD_DROP); break;
     case "KeyP":                    send(ACTION.PAUSE); break;
@@ -194,42 +508,38 @@ function render(): void {
   }
 
   if (!s.gameOver) {
-    const cells = rotateCells(SHAPES[s.piece.kind]!, s.piece.rot);
-    const dropDist = s.ghostY - s.piece.y;
+    const piece = predict(s);
+    const ghostY = piece === s.piece ? s.ghostY : landing(s.grid, piece);
+    const cells = pieceCells(piece.kind, piece.rot);
+    const dropDist = ghostY - piece.y;
     if (dropDist > 0) {
       for (const [dx, dy] of cells) {
-        const gx = s.piece.x + dx;
-        const gy = s.ghostY + dy;
+        const gx = piece.x + dx;
+        const gy = ghostY + dy;
         if (gy >= 0 && gy < s.grid.length) {
-          drawGhostCell(bctx, gx, gy, s.piece.kind + 1);
+          drawGhostCell(bctx, gx, gy, piece.kind + 1);
         }
       }
     }
     for (const [dx, dy] of cells) {
-      const x = s.piece.x + dx;
-      const y = s.piece.y + dy;
-      if (y >= 0) drawCell(bctx, x, y, s.piece.kind + 1);
+      const x = piece.x + dx;
+      const y = piece.y + dy;
+      if (y >= 0) drawCell(bctx, x, y, piece.kind + 1);
     }
   }
 
-  // Next-piece preview, centered on its bounding box.
+  // Preview queue, one slot per piece down the canvas, and the hold
+  // slot — dimmed while this piece has already used its hold.
   nctx.clearRect(0, 0, next.width, next.height);
-  const np = SHAPES[s.next]!;
-  let minX = Infinity, maxX = -Infinity, minY = Infinity, maxY = -Infinity;
-  for (const [dx, dy] of np) {
-    if (dx < minX) minX = dx;
-    if (dx > maxX) maxX = dx;
-    if (dy < minY) minY = dy;
-    if (dy > maxY) maxY = dy;
-  }
-  const previewCell = 20;
-  const offsetX = next.width / 2 - ((minX + maxX) / 2) * previewCell;
-  const offsetY = next.height / 2 - ((minY + maxY) / 2) * previewCell;
-  for (const [dx, dy] of np) {
-    drawSmallCell(nctx,
-      offsetX + dx * previewCell - previewCell / 2,
-      offsetY + dy * previewCell - previewCell / 2,
-      previewCell, s.next + 1);
+  const slot = next.height / Math.max(s.next.length, 1);
+  s.next.forEach((kind, i) => {
+    drawPreview(nctx, kind, next.width / 2, slot * i + slot / 2, i === 0 ? 20 : 15);
+  });
+  hctx.clearRect(0, 0, holdCanvas.width, holdCanvas.height);
+  if (s.hold >= 0) {
+    hctx.globalAlpha = s.canHold ? 1 : 0.35;
+    drawPreview(hctx, s.hold, holdCanvas.width / 2, holdCanvas.height / 2, 20);
+    hctx.globalAlpha = 1;
   }
 
   scoreVal.textContent = s.score.toLocaleString();
@@ -239,9 +549,114 @@ function render(): void {
   pulseOnChange(linesPanel, s.lines, "lastLines");
   pulseOnChange(levelPanel, s.level, "lastLevel");
 
+  drawPending(s.pending ?? 0);
+  attackEl.textContent = s.attack ?? "";
+
+  const versus = s.mode === MODE_VERSUS;
   overlay.classList.toggle("show", s.gameOver);
+  overlayTitle.textContent = !versus || !s.place ? "Game Over" : s.place === 1 ? "You Win" : `#${s.place}`;
+  overlaySub.innerHTML = (versus
+    ? "Press <kbd>R</kbd> for the next game"
+    : "Press <kbd>R</kbd> to restart")
+    + (s.replay ? `<br><a href="?ghost=${encodeURIComponent(s.replay)}">Race this game</a>` : "");
   pauseOverlay.classList.toggle("show", s.paused && !s.gameOver);
   pauseBtn.textContent = s.paused ? "Resume" : "Pause";
+  pauseBtn.disabled = versus;
+
+  const waiting = !!s.waiting || !!s.countdown;
+  waitOverlay.classList.toggle("show", waiting && !s.gameOver);
+  if (s.waiting) {
+    waitTitle.textContent = "Waiting";
+    waitSub.textContent = `${s.queued ?? 1} of ${s.needed ?? 2} players`;
+  } else if (s.countdown) {
+    waitTitle.textContent = String(s.countdown);
+    waitSub.textContent = `${(s.opponents?.length ?? 0) + 1}-player versus`;
+  }
+
+  renderOpponents(s.opponents ?? [], s.ghost);
+  if (s.gameOver && s.replay) refreshAfterGame(s.replay);
+}
+
+// drawPending draws the incoming-garbage meter up the board's right edge.
+function drawPending(lines: number): void {
+  if (lines <= 0) return;
+  const h = Math.min(lines, BOARD_H) * CELL;
+  bctx.fillStyle = "rgba(239, 68, 68, 0.85)";
+  bctx.fillRect(board.width - 5, board.height - h, 5, h);
+}
+
+// renderOpponents keeps one mini board per opponent, created on first
+// sight and dropped once the opponent leaves the snapshot. A raced ghost
+// gets a board of its own after them.
+function renderOpponents(opponents: OpponentView[], ghost?: OpponentView): void {
+  const boards = ghost ? [...opponents, ghost] : opponents;
+  const seen = new Set<string>();
+  for (const o of boards) {
+    seen.add(o.id);
+    let card = opponentsEl.querySelector<HTMLElement>(`[data-id="${o.id}"]`);
+    if (!card) {
+      card = document.createElement("div");
+      card.className = "opponent";
+      card.dataset["id"] = o.id;
+      card.innerHTML = `<canvas width="${BOARD_W * MINI_CELL}" height="${BOARD_H * MINI_CELL}"></canvas><div class="label"></div>`;
+      opponentsEl.appendChild(card);
+    }
+    card.classList.toggle("out", o.out);
+    card.classList.toggle("ghost", o === ghost);
+
+    const c = card.querySelector("canvas")!;
+    const ctx = canvasCtx(c);
+    ctx.clearRect(0, 0, c.width, c.height);
+    for (let y = 0; y < o.grid.length; y++) {
+      const row = o.grid[y]!;
+      for (let x = 0; x < row.length; x++) {
+        const v = row[x]!;
+        if (v !== 0) drawSmallCell(ctx, x * MINI_CELL, y * MINI_CELL, MINI_CELL, v);
+      }
+    }
+    if (!o.out) {
+      for (const [dx, dy] of pieceCells(o.piece.kind, o.piece.rot)) {
+        const y = o.piece.y + dy;
+        if (y >= 0) drawSmallCell(ctx, (o.piece.x + dx) * MINI_CELL, y * MINI_CELL, MINI_CELL, o.piece.kind + 1);
+      }
+    }
+    if (o.pending > 0) {
+      const h = Math.min(o.pending, BOARD_H) * MINI_CELL;
+      ctx.fillStyle = "rgba(239, 68, 68, 0.85)";
+      ctx.fillRect(c.width - 3, c.height - h, 3, h);
+    }
+
+    const label = card.querySelector<HTMLElement>(".label")!;
+    label.textContent = o === ghost
+      ? `ghost · ${o.score.toLocaleString()}`
+      : o.out
+        ? (o.place === 1 ? "winner" : `out · #${o.place ?? "?"}`)
+        : `${o.score.toLocaleString()} · ${o.lines} lines`;
+  }
+  for (const card of Array.from(opponentsEl.querySelectorAll<HTMLElement>(".opponent"))) {
+    if (!seen.has(card.dataset["id"] ?? "")) card.remove();
+  }
+}
+
+// drawPreview draws a piece in its spawn orientation, centered on its
+// bounding box at (cx, cy).
+function drawPreview(ctx: CanvasRenderingContext2D, kind: number, cx: number, cy: number, size: number): void {
+  const cells = pieceCells(kind, 0);
+  let minX = Infinity, maxX = -Infinity, minY = Infinity, maxY = -Infinity;
+  for (const [dx, dy] of cells) {
+    if (dx < minX) minX = dx;
+    if (dx > maxX) maxX = dx;
+    if (dy < minY) minY = dy;
+    if (dy > maxY) maxY = dy;
+  }
+  const offsetX = cx - ((minX + maxX) / 2) * size;
+  const offsetY = cy - ((minY + maxY) / 2) * size;
+  for (const [dx, dy] of cells) {
+    drawSmallCell(ctx,
+      offsetX + dx * size - size / 2,
+      offsetY + dy * size - size / 2,
+      size, kind + 1);
+  }
 }
 
 function pulseOnChange(elt: HTMLElement, current: number, key: string): void {